@@ -56,6 +56,22 @@ type DRClusterSpec struct {
 	// is stored to S3 profiles of all other drclusters in the same
 	// DRPolicy to enable recovery or relocate actions to those managed clusters.
 	S3ProfileName string `json:"s3ProfileName"`
+
+	// Maintenance, when true, marks the cluster as under maintenance. New
+	// applications are not placed on the cluster and applications are not
+	// relocated or failed over to it, while applications can be failed over
+	// away from it without fencing it. Marking it hence asserts that the
+	// cluster no longer writes to the volumes of synchronously replicated
+	// applications, else their data may diverge.
+	Maintenance bool `json:"maintenance,omitempty"`
+
+	// Drain, when true, moves every application placed on the cluster to its
+	// peer cluster. Applications are relocated, or failed over if the cluster
+	// is not available. Regardless of Drain, the DRCluster is drained as it is
+	// deleted, and is not deleted while applications are placed on it or
+	// VolumeReplicationGroup ManifestWorks, other than those being deleted,
+	// remain for it.
+	Drain bool `json:"drain,omitempty"`
 }
 
 const (
@@ -69,11 +85,78 @@ const (
 	// Fencing CR to fence off this cluster
 	// has been created
 	DRClusterConditionTypeFenced = "Fenced"
+
+	// The managed cluster is reported available by the hub
+	DRClusterConditionTypeManagedClusterAvailable = "ManagedClusterAvailable"
+
+	// The S3 profile of the cluster passed a write/read round-trip probe
+	DRClusterConditionTypeS3StoreHealthy = "S3StoreHealthy"
+
+	// The dr-cluster operator deployed to the cluster is applied and available
+	DRClusterConditionTypeOperatorReady = "OperatorReady"
+
+	// All peer clusters, across DRPolicies that include this cluster, are
+	// available: their ManagedClusters are available and their S3 stores
+	// healthy. It does not tell whether their replication endpoints are
+	// reachable from this cluster, which is not observable from the hub.
+	DRClusterConditionTypePeersAvailable = "PeersAvailable"
+
+	// The cluster is under maintenance. The condition message lists the
+	// DRPlacementControls whose applications are placed on the cluster.
+	DRClusterConditionTypeMaintenance = "Maintenance"
+
+	// All applications have been moved off the cluster, and no
+	// VolumeReplicationGroup ManifestWorks remain for it
+	DRClusterConditionTypeDrained = "Drained"
+
+	// The installed dr-cluster operator version is within the range supported
+	// by the hub
+	DRClusterConditionTypeOperatorVersionCompatible = "OperatorVersionCompatible"
+
+	// The dr-cluster operator is being installed or upgraded, or is waiting
+	// for its turn to be upgraded. DR actions involving the cluster are paused
+	// while it upgrades. An upgrade not completed in time is reported stuck,
+	// and no longer holds back the upgrade of the other clusters.
+	DRClusterConditionTypeOperatorUpgrading = "OperatorUpgrading"
 )
 
+// DRClusterOperatorStatus reports the dr-cluster operator on the cluster
+type DRClusterOperatorStatus struct {
+	// Name of the ClusterServiceVersion the hub last deployed to the cluster
+	TargetClusterServiceVersion string `json:"targetClusterServiceVersion,omitempty"`
+
+	// Name of the ClusterServiceVersion installed on the cluster
+	InstalledClusterServiceVersion string `json:"installedClusterServiceVersion,omitempty"`
+
+	// Version of the ClusterServiceVersion installed on the cluster
+	InstalledVersion string `json:"installedVersion,omitempty"`
+}
+
+// DRClusterDrainStatus reports the progress of draining a DRCluster
+type DRClusterDrainStatus struct {
+	// DRPlacementControls, as namespace/name, whose applications are still
+	// placed on the cluster
+	PendingDRPCs []string `json:"pendingDRPCs,omitempty"`
+
+	// DRPlacementControls, as namespace/name, that have no peer cluster to
+	// move their applications to
+	BlockedDRPCs []string `json:"blockedDRPCs,omitempty"`
+
+	// VolumeReplicationGroup ManifestWorks still present for the cluster, other
+	// than those being deleted
+	RemainingManifestWorks []string `json:"remainingManifestWorks,omitempty"`
+}
+
 // DRClusterStatus defines the observed state of DRCluster
 type DRClusterStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Drain reports the progress of draining the cluster, when requested
+	Drain *DRClusterDrainStatus `json:"drain,omitempty"`
+
+	// Operator reports the dr-cluster operator on the cluster, when its
+	// deployment is automated
+	Operator *DRClusterOperatorStatus `json:"operator,omitempty"`
 }
 
 //+kubebuilder:object:root=true
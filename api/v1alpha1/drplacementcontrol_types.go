@@ -104,8 +104,64 @@ type DRPlacementControlSpec struct {
 	// need DR protection. It will be passed in to the VRG when it is created
 	PVCSelector metav1.LabelSelector `json:"pvcSelector"`
 
+	// Additional namespaces whose PVCs, selected by the PVCSelector, need DR
+	// protection along with those in the DRPC namespace. They are passed in to
+	// the VRG when it is created, and are created on the cluster the
+	// application is deployed to.
+	ProtectedNamespaces []string `json:"protectedNamespaces,omitempty"`
+
+	// Label selector to identify additional namespaces whose PVCs, selected by
+	// the PVCSelector, need DR protection. It is passed in to the VRG when it
+	// is created.
+	ProtectedNamespaceSelector *metav1.LabelSelector `json:"protectedNamespaceSelector,omitempty"`
+
 	// Action is either Failover or Relocate operation
 	Action DRAction `json:"action,omitempty"`
+
+	// VolumeGroupReplication, when true, replicates the PVCs that share a
+	// provisioner as a group, for storage that supports it. It is passed in to
+	// the VRG when it is created.
+	VolumeGroupReplication bool `json:"volumeGroupReplication,omitempty"`
+
+	// PVRestore determines how PV cluster data is restored when the
+	// application is deployed, failed over or relocated to a cluster. It is
+	// passed in to the VRG, and can be changed to let a stalled restore
+	// complete.
+	//+optional
+	PVRestore PVRestorePolicy `json:"pvRestore,omitempty"`
+
+	// Suspended, when true, pauses the replication of the protected PVCs
+	// without removing their protection. It is passed in to the VRG, that
+	// pauses the replication of the PVCs protected using VolSync only. A
+	// failover or relocate is not started while replication is suspended,
+	// unless ForceAction is set to it.
+	//+optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// ForceAction lets the failover or relocate it names start while
+	// replication is suspended. It forces only that action, so that it does
+	// not carry over to a later action of another kind.
+	//+optional
+	ForceAction DRAction `json:"forceAction,omitempty"`
+
+	// WarmStandby, when true, keeps the volumes of the protected PVCs ready on
+	// the secondary clusters, to shorten a failover. It is passed in to the VRG.
+	//+optional
+	WarmStandby bool `json:"warmStandby,omitempty"`
+
+	// ScaleDownWorkloads, when true, lets a relocation scale down the
+	// workloads using the PVCs protected by VolSync on the current home
+	// cluster, so that their final sync does not wait for the application to
+	// be removed. It is passed in to the VRG, that restores the workloads if
+	// the relocation is aborted.
+	//+optional
+	ScaleDownWorkloads bool `json:"scaleDownWorkloads,omitempty"`
+
+	// RecoveryPointTime, when set, fails the PVCs protected by VolSync over to
+	// their latest recovery points at or before this time, instead of their
+	// latest images. The times available are listed in the status.
+	//+optional
+	RecoveryPointTime *metav1.Time `json:"recoveryPointTime,omitempty"`
 }
 
 // VRGResourceMeta represents the VRG resource.
@@ -125,6 +181,9 @@ type VRGResourceMeta struct {
 
 	// List of PVCs that are protected by the VRG resource
 	ProtectedPVCs []string `json:"protectedpvcs,omitempty"`
+
+	// List of namespaces whose PVCs are protected by the VRG resource
+	ProtectedNamespaces []string `json:"protectedNamespaces,omitempty"`
 }
 
 // VRGConditions represents the conditions of the resources deployed on a
@@ -139,6 +198,40 @@ type VRGConditions struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// PeerResyncStatus aggregates the resync progress of the PVCs protected on
+// the peer cluster, after it is demoted to secondary
+type PeerResyncStatus struct {
+	// Name of the peer cluster
+	ClusterName string `json:"clusterName"`
+
+	// Number of protected PVCs still resyncing
+	ResyncingPVCs int `json:"resyncingPVCs"`
+
+	// Number of protected PVCs
+	TotalPVCs int `json:"totalPVCs"`
+
+	// Estimated time all protected PVCs complete resyncing, based on the
+	// duration of their last synchronization
+	//+optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+}
+
+// VolSyncSecretStatus is the status of the ssh keys shared by the clusters of
+// the DRPlacementControl, that the VolSync rsync mover replicates the PVCs with
+type VolSyncSecretStatus struct {
+	// Generation of the ssh keys in use, incremented on each rotation
+	Generation int64 `json:"generation,omitempty"`
+
+	// Last time the ssh keys were rotated
+	//+optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// Value of the rotation request annotation, that the ssh keys were last
+	// rotated on demand for
+	//+optional
+	LastRotationRequest string `json:"lastRotationRequest,omitempty"`
+}
+
 // DRPlacementControlStatus defines the observed state of DRPlacementControl
 type DRPlacementControlStatus struct {
 	Phase              DRState                 `json:"phase,omitempty"`
@@ -149,6 +242,29 @@ type DRPlacementControlStatus struct {
 	Conditions         []metav1.Condition      `json:"conditions,omitempty"`
 	ResourceConditions VRGConditions           `json:"resourceConditions,omitempty"`
 	LastUpdateTime     metav1.Time             `json:"lastUpdateTime"`
+
+	// Oldest time the data of all PVCs protected on the current primary
+	// cluster was last replicated at
+	LastGroupSyncTime *metav1.Time `json:"lastGroupSyncTime,omitempty"`
+
+	// Last time the data of all protected PVCs was replicated to the failover
+	// cluster, recorded when the last failover started. Data written after
+	// this time was lost.
+	FailoverLastGroupSyncTime *metav1.Time `json:"failoverLastGroupSyncTime,omitempty"`
+
+	// Data loss window of the last failover, the time between the last group
+	// sync to the failover cluster and the start of the failover
+	FailoverDataLossWindow *metav1.Duration `json:"failoverDataLossWindow,omitempty"`
+
+	// Resync progress of the peer cluster, while it is not ready
+	PeerResync *PeerResyncStatus `json:"peerResync,omitempty"`
+
+	// Rotation status of the ssh keys of the VolSync rsync mover
+	VolSyncSecret *VolSyncSecretStatus `json:"volSyncSecret,omitempty"`
+
+	// Times, newest first, that the PVCs protected by VolSync can be failed
+	// over to, from the recovery points retained on the secondary cluster
+	VolSyncRecoveryPoints []metav1.Time `json:"volSyncRecoveryPoints,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -28,8 +28,9 @@ type DRPolicySpec struct {
 	// data to a peer cluster. Interval is typically in the
 	// form <num><m,h,d>. Here <num> is a number, 'm' means
 	// minutes, 'h' means hours and 'd' stands for days.
+	// The interval is at most 365 days.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Pattern=`^\d+[mhd]$`
+	// +kubebuilder:validation:Pattern=`^[1-9]\d{0,5}[mhd]$`
 	SchedulingInterval string `json:"schedulingInterval,omitempty"`
 
 	// Label selector to identify all the VolumeReplicationClasses.
@@ -46,6 +47,57 @@ type DRPolicySpec struct {
 
 	// List of DRCluster resources that are governed by this policy
 	DRClusters []string `json:"drClusters,omitempty"`
+
+	// StorageClassMappings declare StorageClasses on the DRClusters of the
+	// policy that are equivalent for DR, when peer clusters do not share
+	// StorageClass names or CSI drivers
+	//+optional
+	StorageClassMappings []StorageClassMapping `json:"storageClassMappings,omitempty"`
+
+	// VolSync data mover that replicates the PVCs that are not replicated by
+	// the storage. The restic mover replicates through the S3 stores of the
+	// DRClusters, and needs no network connectivity between the DRClusters.
+	// It will be passed in to the VRGs, and changing it switches existing VRGs
+	// to the mover
+	//+optional
+	VolSyncMover VolSyncMoverType `json:"volSyncMover,omitempty"`
+
+	// VolSyncProfile selects how the rsync mover connects the DRClusters, for
+	// DRClusters without multicluster service discovery. It will be passed in
+	// to the VRGs, and changing it reconnects existing VRGs as per the profile
+	//+optional
+	VolSyncProfile *VolSyncProfile `json:"volSyncProfile,omitempty"`
+
+	// VolSyncRecoveryPointRetention, when set, retains the images replicated
+	// by VolSync to the secondary DRClusters as recovery points, that a
+	// failover can restore the PVCs from. It will be passed in to the VRG
+	//+optional
+	VolSyncRecoveryPointRetention *VolSyncRecoveryPointRetention `json:"volSyncRecoveryPointRetention,omitempty"`
+
+	// VolSyncSchedulingIntervals, keyed by DRCluster name, override the
+	// SchedulingInterval of the replication by VolSync to the DRCluster, when
+	// the policy has more than two DRClusters that the primary replicates to
+	//+optional
+	VolSyncSchedulingIntervals map[string]string `json:"volSyncSchedulingIntervals,omitempty"`
+}
+
+// StorageClassMapping declares equivalent StorageClasses on the DRClusters of
+// a DRPolicy. Volumes protected on one cluster are restored on a peer cluster
+// using the StorageClass, CSI driver and volume attributes of the peer.
+type StorageClassMapping struct {
+	// StorageClass names, keyed by DRCluster name
+	StorageClassNames map[string]string `json:"storageClassNames"`
+
+	// CSI driver names of the StorageClasses, keyed by DRCluster name. The CSI
+	// driver of a restored PV is rewritten when set for both the cluster the
+	// PV was protected on and the cluster it is restored to.
+	//+optional
+	Provisioners map[string]string `json:"provisioners,omitempty"`
+
+	// CSI volume attributes, keyed by DRCluster name, set on PVs restored to
+	// the cluster, replacing any attributes with the same keys
+	//+optional
+	VolumeAttributes map[string]map[string]string `json:"volumeAttributes,omitempty"`
 }
 
 // DRPolicyStatus defines the observed state of DRPolicy
@@ -106,6 +106,10 @@ type RamenConfig struct {
 	// Defaults to 1.
 	MaxConcurrentReconciles int `json:",omitempty"`
 
+	// MaxConcurrentPVCs is the maximum number of PVCs of a VolumeReplicationGroup that are
+	// processed concurrently in a reconcile. Defaults to 1.
+	MaxConcurrentPVCs int `json:",omitempty"`
+
 	// dr-cluster operator deployment/undeployment automation configuration
 	DrClusterOperator struct {
 		// dr-cluster operator deployment/undeployment automation enabled
@@ -131,12 +135,21 @@ type RamenConfig struct {
 
 		// cluster service version name
 		ClusterServiceVersionName string `json:"clusterServiceVersionName,omitempty"`
+
+		// Range of dr-cluster operator versions supported by the hub, in semver
+		// range syntax, e.g. ">=0.1.0 <0.3.0". Defaults to versions with the
+		// same major version as the hub and at most one minor version older.
+		SupportedVersionRange string `json:"supportedVersionRange,omitempty"`
 	} `json:"drClusterOperator,omitempty"`
 
 	// VolSync configuration
 	VolSync struct {
 		// Disabled is used to disable VolSync usage in Ramen. Defaults to false.
 		Disabled bool `json:"disabled,omitempty"`
+
+		// SecretRotationInterval is the interval the ssh keys of the rsync mover
+		// are rotated at, by the hub. Defaults to 90 days.
+		SecretRotationInterval metav1.Duration `json:"secretRotationInterval,omitempty"`
 	} `json:"volSync,omitempty"`
 }
 
@@ -92,12 +92,20 @@ type VRGAsyncSpec struct {
 	// data to a peer cluster. Interval is typically in the
 	// form <num><m,h,d>. Here <num> is a number, 'm' means
 	// minutes, 'h' means hours and 'd' stands for days.
+	// The interval is at most 365 days.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Pattern=`^\d+[mhd]$`
+	// +kubebuilder:validation:Pattern=`^[1-9]\d{0,5}[mhd]$`
 	SchedulingInterval string `json:"schedulingInterval"`
 
 	// Mode determines if AsyncDR is enabled or not
 	Mode AsyncMode `json:"mode"`
+
+	// VolumeGroupReplication, when true, replicates the PVCs that share a
+	// provisioner as a group, using a VolumeGroupReplication resource per
+	// provisioner instead of a VolumeReplication resource per PVC, so that
+	// they are replicated to a consistent point in time.
+	//+optional
+	VolumeGroupReplication bool `json:"volumeGroupReplication,omitempty"`
 }
 
 // VRGSyncSpec has the parameters associated with MetroDR
@@ -120,6 +128,91 @@ type VolSyncReplicationSourceSpec struct {
 	// protectedPVC contains the information about the PVC to be protected by VolSync
 	//+optional
 	ProtectedPVC ProtectedPVC `json:"protectedPVC,omitempty"`
+
+	// paused, when set, pauses the synchronization of the ReplicationSource
+	//+optional
+	Paused bool `json:"paused,omitempty"`
+
+	// rsyncAddress is the address of the ReplicationDestination of the PVC on
+	// the peer cluster, as published by the Secondary VRG
+	//+optional
+	RsyncAddress *VolSyncRsyncAddress `json:"rsyncAddress,omitempty"`
+
+	// destination is the name of the destination, among the destinations of
+	// the Primary, that the ReplicationDestination of the PVC is on
+	//+optional
+	Destination string `json:"destination,omitempty"`
+}
+
+// VolSyncDestination is a peer cluster that the Primary replicates the PVCs
+// protected by VolSync to
+type VolSyncDestination struct {
+	// name of the destination, that the names of the ReplicationSources
+	// replicating to it, and of the ReplicationDestinations on it, end with
+	Name string `json:"name"`
+
+	// schedulingInterval for replicating the PVCs to the destination, in the
+	// form <num><m,h,d>. Default is the scheduling interval of the VRG
+	//+kubebuilder:validation:Pattern=`^[1-9]\d{0,5}[mhd]$`
+	//+optional
+	SchedulingInterval string `json:"schedulingInterval,omitempty"`
+}
+
+// VolSyncRsyncAddress is the address that a ReplicationSource of the rsync
+// mover connects to
+type VolSyncRsyncAddress struct {
+	// address is the IP address or hostname of the ReplicationDestination
+	Address string `json:"address"`
+
+	// port is the ssh port of the ReplicationDestination. Default is 22
+	//+optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// VolSyncProfile selects how the ReplicationSources of the rsync mover reach
+// the ReplicationDestinations on the peer cluster. It holds the transport of
+// the mover only: the VolSync API in use does not configure the mover pods,
+// such as their placement and resources, and the rsync mover always connects
+// as the root user, to the root of the destination volume, as VolSync expects
+type VolSyncProfile struct {
+	// serviceType of the services that expose the ReplicationDestinations. A
+	// ClusterIP service is exported to the peer cluster, and reached through
+	// multicluster service discovery. The address of a LoadBalancer or
+	// NodePort service is published by the Secondary VRG, and passed to the
+	// Primary VRG by the DRPC. Default is 'ClusterIP'
+	//+kubebuilder:validation:Enum=ClusterIP;LoadBalancer;NodePort
+	//+optional
+	ServiceType *corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// address is a fixed external IP address or hostname that reaches the
+	// services of the ReplicationDestinations, published instead of the
+	// address of the services. Required for NodePort services, as the address
+	// of a node, or of a load balancer in front of the nodes
+	//+optional
+	Address string `json:"address,omitempty"`
+
+	// port the ssh server of the rsync mover listens on. Default is 22
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	//+optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+// VolSyncRecoveryPointRetention selects the images replicated to the
+// ReplicationDestinations that are retained as recovery points. The latest
+// image is always retained. Images are retained by the Snapshot copy method
+// only.
+type VolSyncRecoveryPointRetention struct {
+	// count is the most recovery points retained per PVC, including the
+	// latest image. Default is no limit
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	Count *int32 `json:"count,omitempty"`
+
+	// maxAge is the longest a recovery point is retained for. Default is no
+	// limit
+	//+optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
 }
 
 // VolSynccSpec defines the ReplicationDestination specs for the Secondary VRG, or
@@ -131,8 +224,80 @@ type VolSyncSpec struct {
 
 	// disabled when set, all the VolSync code is bypassed. Default is 'false'
 	Disabled bool `json:"disabled,omitempty"`
+
+	// mover is the VolSync data mover that replicates the PVCs. The restic mover
+	// backs up the PVCs to, and restores them from, restic repositories in the
+	// store of the first S3 profile of the VRG. Default is 'rsync'
+	//+optional
+	Mover VolSyncMoverType `json:"mover,omitempty"`
+
+	// profile selects how the rsync mover connects the clusters. Default is a
+	// ClusterIP service reached through multicluster service discovery
+	//+optional
+	Profile *VolSyncProfile `json:"profile,omitempty"`
+
+	// rsSpec array contains the addresses of the ReplicationDestinations on the
+	// peer cluster, for the PVCs that are protected by VolSync on the Primary,
+	// when published by the Secondary VRG
+	//+optional
+	RSSpec []VolSyncReplicationSourceSpec `json:"rsSpec,omitempty"`
+
+	// destinations the Primary replicates the PVCs to, with a ReplicationSource
+	// per PVC and destination, each on a schedule of its own. The restic mover
+	// replicates through a restic repository per PVC and destination. Default
+	// is a single destination, replicated to with a ReplicationSource per PVC
+	//+optional
+	Destinations []VolSyncDestination `json:"destinations,omitempty"`
+
+	// destinationName is the name of the cluster among the destinations of the
+	// Primary, that the names of the ReplicationDestinations of the Secondary
+	// end with, so that the services of those of all destinations can be told
+	// apart. Default is a single destination
+	//+optional
+	DestinationName string `json:"destinationName,omitempty"`
+
+	// recoveryPointRetention, when set, retains the images replicated to the
+	// ReplicationDestinations of the Secondary VRG as recovery points, listed
+	// in the status of the protected PVCs
+	//+optional
+	RecoveryPointRetention *VolSyncRecoveryPointRetention `json:"recoveryPointRetention,omitempty"`
+
+	// recoveryPointTime, when set as the VRG becomes Primary, restores each PVC
+	// from its latest recovery point at or before this time, instead of the
+	// latest image of its ReplicationDestination
+	//+optional
+	RecoveryPointTime *metav1.Time `json:"recoveryPointTime,omitempty"`
+
+	// scaleDownWorkloads, when true, scales the Deployments, StatefulSets and
+	// other workloads whose pods use the PVCs down to zero replicas for the
+	// final sync of the Primary, recording their replicas in an annotation.
+	// Their replicas are restored once the Primary is no longer asked for a
+	// final sync, such as when the relocation is aborted, or the VRG is
+	// deleted, unless they were scaled up since
+	//+optional
+	ScaleDownWorkloads bool `json:"scaleDownWorkloads,omitempty"`
+
+	// sshSecretName is the name of the secret with the ssh keys of the rsync
+	// mover, propagated from the hub. Rotating the keys propagates a secret with
+	// a new name. Default is the name of the VRG suffixed with '-vs-secret'
+	//+optional
+	SSHSecretName string `json:"sshSecretName,omitempty"`
 }
 
+// VolSyncMoverType is the VolSync data mover that replicates PVCs
+// +kubebuilder:validation:Enum=rsync;restic
+type VolSyncMoverType string
+
+const (
+	// VolSyncMoverRsync transfers the PVC data over a connection between the
+	// clusters
+	VolSyncMoverRsync VolSyncMoverType = "rsync"
+
+	// VolSyncMoverRestic transfers the PVC data through restic repositories in
+	// an S3 store that both clusters reach
+	VolSyncMoverRestic VolSyncMoverType = "restic"
+)
+
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // VolumeReplicationGroup (VRG) spec declares the desired schedule for data
@@ -152,6 +317,16 @@ type VolumeReplicationGroupSpec struct {
 	// that needs to be replicated to the peer cluster.
 	PVCSelector metav1.LabelSelector `json:"pvcSelector"`
 
+	// Additional namespaces whose PVCs, selected by the PVCSelector, are
+	// protected by the VRG along with the PVCs in the VRG namespace
+	//+optional
+	ProtectedNamespaces []string `json:"protectedNamespaces,omitempty"`
+
+	// Label selector to identify additional namespaces whose PVCs, selected by
+	// the PVCSelector, are protected by the VRG
+	//+optional
+	ProtectedNamespaceSelector *metav1.LabelSelector `json:"protectedNamespaceSelector,omitempty"`
+
 	// Desired state of all volumes [primary or secondary] in this replication group;
 	// this value is propagated to children VolumeReplication CRs
 	ReplicationState ReplicationState `json:"replicationState"`
@@ -176,6 +351,174 @@ type VolumeReplicationGroupSpec struct {
 	// relocation only, and for VolSync only
 	//+optional
 	RunFinalSync bool `json:"runFinalSync,omitempty"`
+
+	// StorageClassMappings rewrite the StorageClass, CSI driver and volume
+	// attributes of volumes protected on a peer cluster, when their PVs,
+	// ReplicationDestinations and PVCs are created on this cluster
+	//+optional
+	StorageClassMappings []VRGStorageClassMapping `json:"storageClassMappings,omitempty"`
+
+	// PVRestore determines how PV cluster data is restored from the S3 store
+	// when the VRG becomes primary
+	//+optional
+	PVRestore PVRestorePolicy `json:"pvRestore,omitempty"`
+
+	// Suspended, when true, pauses the replication of the PVCs protected using
+	// VolSync, as primary, by pausing their ReplicationSources. The
+	// VolumeReplication API has no way to pause replication, hence the PVCs
+	// protected using VolumeReplication keep replicating. Their
+	// VolumeReplications are only annotated as suspended, for storage that
+	// honors the annotation, and the PVCs report SuspendNotSupported.
+	// Protection is otherwise kept up to date. A final sync requested for a
+	// relocation is run regardless.
+	//+optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// WarmStandby, when true, keeps the volumes of the protected PVCs ready on
+	// the cluster as secondary, so that they are bound as it becomes primary.
+	// VolSync PVCs are restored from the latest synchronized snapshot, and
+	// VolRep PVs from the S3 store.
+	//+optional
+	WarmStandby bool `json:"warmStandby,omitempty"`
+}
+
+// PVRestoreConflictResolution selects which of the PVs in the S3 store that
+// are bound to the same claim is restored
+// +kubebuilder:validation:Enum=None;Newest
+type PVRestoreConflictResolution string
+
+const (
+	// None of the PVs bound to the same claim is restored
+	PVRestoreConflictResolutionNone = PVRestoreConflictResolution("None")
+
+	// The PV most recently uploaded to the S3 store is restored
+	PVRestoreConflictResolutionNewest = PVRestoreConflictResolution("Newest")
+)
+
+// PVRestorePolicy determines how PV cluster data is restored
+type PVRestorePolicy struct {
+	// ConflictResolution selects which of the PVs in the S3 store that are
+	// bound to the same claim is restored. Defaults to None.
+	//+optional
+	ConflictResolution PVRestoreConflictResolution `json:"conflictResolution,omitempty"`
+
+	// AllowPartial, when true, completes the restore, and reports the cluster
+	// data ready, even if some PVs were not restored. Those PVs are reported
+	// in the PVRestoreResults of the VRG status.
+	//+optional
+	AllowPartial bool `json:"allowPartial,omitempty"`
+}
+
+// PVRestoreResultType is the outcome of restoring a PV
+// +kubebuilder:validation:Enum=Restored;AlreadyExists;Conflict;Error
+type PVRestoreResultType string
+
+const (
+	// The PV was created from its cluster data in the S3 store
+	PVRestoreResultRestored = PVRestoreResultType("Restored")
+
+	// The PV was already present, restored by Ramen or kept in sync mode
+	PVRestoreResultAlreadyExists = PVRestoreResultType("AlreadyExists")
+
+	// The PV is bound to the same claim as another PV in the S3 store
+	PVRestoreResultConflict = PVRestoreResultType("Conflict")
+
+	// The PV could not be restored
+	PVRestoreResultError = PVRestoreResultType("Error")
+)
+
+// PVRestoreResult reports the outcome of restoring a PV
+type PVRestoreResult struct {
+	// Name of the PV
+	Name string `json:"name"`
+
+	// Claim the PV is bound to, as namespace/name
+	//+optional
+	ClaimName string `json:"claimName,omitempty"`
+
+	// S3 profile the PV cluster data was fetched from
+	//+optional
+	S3ProfileName string `json:"s3ProfileName,omitempty"`
+
+	// Result of restoring the PV
+	Result PVRestoreResultType `json:"result"`
+
+	// Message describing the result
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// PVCSyncState is the replication state of a protected PVC
+// +kubebuilder:validation:Enum=InitialSync;Resyncing;InSync;Degraded;Unknown
+type PVCSyncState string
+
+const (
+	// The volume data is being synchronized to a peer cluster for the first time
+	PVCSyncStateInitialSync = PVCSyncState("InitialSync")
+
+	// The volume data is being resynchronized from the primary cluster
+	PVCSyncStateResyncing = PVCSyncState("Resyncing")
+
+	// The volume data was last replicated without errors
+	PVCSyncStateInSync = PVCSyncState("InSync")
+
+	// The volume replication is degraded
+	PVCSyncStateDegraded = PVCSyncState("Degraded")
+
+	// The volume replication state is not known yet
+	PVCSyncStateUnknown = PVCSyncState("Unknown")
+)
+
+// PVCSyncStatus reports the replication progress of a protected PVC, as
+// reported by its VolumeReplication or VolSync ReplicationSource or
+// ReplicationDestination
+type PVCSyncStatus struct {
+	// Replication state of the PVC
+	State PVCSyncState `json:"state"`
+
+	// Time the current resync or synchronization started
+	//+optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Time the last synchronization completed, the data of the PVC is at
+	// least as recent as this time
+	//+optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Duration of the last synchronization
+	//+optional
+	LastSyncDuration *metav1.Duration `json:"lastSyncDuration,omitempty"`
+
+	// Estimated time the current resync completes, based on the duration of
+	// the last synchronization
+	//+optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+
+	// Message describing the replication state
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// VRGStorageClassMapping maps a StorageClass on a peer cluster to its
+// equivalent on this cluster
+type VRGStorageClassMapping struct {
+	// Name of the StorageClass on the peer cluster
+	PeerStorageClassName string `json:"peerStorageClassName"`
+
+	// CSI driver name of the StorageClass on the peer cluster
+	//+optional
+	PeerProvisioner string `json:"peerProvisioner,omitempty"`
+
+	// Name of the StorageClass on this cluster
+	StorageClassName string `json:"storageClassName"`
+
+	// CSI driver name of the StorageClass on this cluster
+	//+optional
+	Provisioner string `json:"provisioner,omitempty"`
+
+	// CSI volume attributes set on PVs restored to this cluster
+	//+optional
+	VolumeAttributes map[string]string `json:"volumeAttributes,omitempty"`
 }
 
 type ProtectedPVC struct {
@@ -183,6 +526,10 @@ type ProtectedPVC struct {
 	//+optional
 	Name string `json:"name,omitempty"`
 
+	// Namespace of the PVC, when it is not the VRG namespace
+	//+optional
+	Namespace string `json:"namespace,omitempty"`
+
 	// VolSyncPVC can be used to denote whether this PVC is protected by VolSync. Defaults to "false".
 	//+optional
 	ProtectedByVolSync bool `json:"protectedByVolSync,omitempty"`
@@ -191,6 +538,11 @@ type ProtectedPVC struct {
 	//+optional
 	StorageClassName *string `json:"storageClassName,omitempty"`
 
+	// CSI driver of the StorageClass of the claim, on the cluster it is
+	// protected on, that a peer cluster maps its own StorageClass from
+	//+optional
+	StorageProvisioner string `json:"storageProvisioner,omitempty"`
+
 	// Labels for the PVC
 	//+optional
 	Labels map[string]string `json:"labels,omitempty"`
@@ -203,9 +555,122 @@ type ProtectedPVC struct {
 	//+optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// VolumeMode set in the claim to be replicated, Filesystem when not set
+	//+optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+
+	// Annotations for the PVC, other than those set while binding or
+	// provisioning it and those of the application that deployed it
+	//+optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
 	// Conditions for this protected pvc
 	//+optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Replication progress of this protected pvc
+	//+optional
+	SyncStatus *PVCSyncStatus `json:"syncStatus,omitempty"`
+
+	// ResourceVersion of the claim when its cluster data was last uploaded to
+	// the S3 stores
+	//+optional
+	UploadedResourceVersion string `json:"uploadedResourceVersion,omitempty"`
+
+	// Address of the rsync ReplicationDestination of this protected pvc, that
+	// the ReplicationSource on the peer cluster connects to, when published
+	//+optional
+	RsyncAddress *VolSyncRsyncAddress `json:"rsyncAddress,omitempty"`
+
+	// Images of this protected pvc retained as recovery points by the
+	// ReplicationDestination, newest first
+	//+optional
+	RecoveryPoints []VolSyncRecoveryPoint `json:"recoveryPoints,omitempty"`
+
+	// Replication of this protected pvc to each of the destinations, when
+	// replicated to more than one
+	//+optional
+	Destinations []VolSyncDestinationStatus `json:"destinations,omitempty"`
+}
+
+// VolSyncDestinationStatus reports the replication of a PVC protected by
+// VolSync to one of the destinations of the Primary
+type VolSyncDestinationStatus struct {
+	// name of the destination
+	Name string `json:"name"`
+
+	// DataProtected condition of the PVC on the destination
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Replication progress of the PVC to the destination
+	//+optional
+	SyncStatus *PVCSyncStatus `json:"syncStatus,omitempty"`
+}
+
+// VolSyncRecoveryPoint is an image of a PVC protected by VolSync, retained on
+// the Secondary cluster, that the PVC can be restored from
+type VolSyncRecoveryPoint struct {
+	// snapshotName is the name of the VolumeSnapshot of the image
+	SnapshotName string `json:"snapshotName"`
+
+	// time the image was taken at
+	Time metav1.Time `json:"time"`
+}
+
+// ProtectedVolumeGroup is a group of PVCs replicated together by a
+// VolumeGroupReplication resource
+type ProtectedVolumeGroup struct {
+	// Name of the VolumeGroupReplication resource
+	Name string `json:"name"`
+
+	// Provisioner of the PVCs in the group
+	//+optional
+	Provisioner string `json:"provisioner,omitempty"`
+
+	// Names of the PVCs in the group
+	//+optional
+	PVCs []string `json:"pvcs,omitempty"`
+
+	// Replication state reported by the VolumeGroupReplication resource
+	//+optional
+	State string `json:"state,omitempty"`
+
+	// Conditions reported by the VolumeGroupReplication resource
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ProtectedNamespace reports the protection of the PVCs of a namespace
+type ProtectedNamespace struct {
+	// Name of the namespace
+	Name string `json:"name"`
+
+	// Names of the protected PVCs in the namespace
+	//+optional
+	ProtectedPVCs []string `json:"protectedPVCs,omitempty"`
+
+	// DataReady and DataProtected conditions summarizing those of the
+	// protected PVCs in the namespace
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// WarmStandbyStatus reports the warm standby volumes of the protected PVCs,
+// restored on the cluster as secondary
+type WarmStandbyStatus struct {
+	// Number of VolSync PVCs restored from the latest synchronized snapshot
+	ReadyPVCs int `json:"readyPVCs"`
+
+	// Number of VolSync PVCs replicated to the cluster
+	TotalPVCs int `json:"totalPVCs"`
+
+	// Number of VolRep PVs restored from the S3 store
+	RestoredPVs int `json:"restoredPVs"`
+
+	// Time the VolRep PVs were last restored from the S3 store
+	//+optional
+	LastPVRestoreTime *metav1.Time `json:"lastPVRestoreTime,omitempty"`
 }
 
 // VolumeReplicationGroupStatus defines the observed state of VolumeReplicationGroup
@@ -216,6 +681,33 @@ type VolumeReplicationGroupStatus struct {
 	// All the protected pvcs
 	ProtectedPVCs []ProtectedPVC `json:"protectedPVCs,omitempty"`
 
+	// All the protected volume groups, when volume group replication is enabled
+	ProtectedVolumeGroups []ProtectedVolumeGroup `json:"protectedVolumeGroups,omitempty"`
+
+	// PVCs, as namespace/name, selected by the PVCSelector that are excluded
+	// from protection by the exclusion annotation
+	ExcludedPVCs []string `json:"excludedPVCs,omitempty"`
+
+	// Protection status of each namespace protected by the VRG
+	ProtectedNamespaces []ProtectedNamespace `json:"protectedNamespaces,omitempty"`
+
+	// Outcome of restoring each PV, from the last PV restore
+	PVRestoreResults []PVRestoreResult `json:"pvRestoreResults,omitempty"`
+
+	// Oldest time the data of all protected PVCs was last replicated at. Data
+	// written after this time may be lost if the VRG fails over.
+	LastGroupSyncTime *metav1.Time `json:"lastGroupSyncTime,omitempty"`
+
+	// Warm standby volumes restored on the cluster as secondary, when the
+	// warm standby is enabled
+	//+optional
+	WarmStandby *WarmStandbyStatus `json:"warmStandby,omitempty"`
+
+	// Name of the secret with the ssh keys that all VolSync
+	// ReplicationSources or ReplicationDestinations of the VRG use
+	//+optional
+	VolSyncSSHSecretName string `json:"volSyncSSHSecretName,omitempty"`
+
 	// Conditions are the list of VRG's summary conditions and their status.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -53,6 +53,36 @@ func (in *DRCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterDrainStatus) DeepCopyInto(out *DRClusterDrainStatus) {
+	*out = *in
+	if in.PendingDRPCs != nil {
+		in, out := &in.PendingDRPCs, &out.PendingDRPCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockedDRPCs != nil {
+		in, out := &in.BlockedDRPCs, &out.BlockedDRPCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemainingManifestWorks != nil {
+		in, out := &in.RemainingManifestWorks, &out.RemainingManifestWorks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterDrainStatus.
+func (in *DRClusterDrainStatus) DeepCopy() *DRClusterDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DRClusterList) DeepCopyInto(out *DRClusterList) {
 	*out = *in
@@ -85,6 +115,21 @@ func (in *DRClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRClusterOperatorStatus) DeepCopyInto(out *DRClusterOperatorStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterOperatorStatus.
+func (in *DRClusterOperatorStatus) DeepCopy() *DRClusterOperatorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DRClusterOperatorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DRClusterSpec) DeepCopyInto(out *DRClusterSpec) {
 	*out = *in
@@ -115,6 +160,16 @@ func (in *DRClusterStatus) DeepCopyInto(out *DRClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DRClusterDrainStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
+		*out = new(DRClusterOperatorStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRClusterStatus.
@@ -192,6 +247,21 @@ func (in *DRPlacementControlSpec) DeepCopyInto(out *DRPlacementControlSpec) {
 	out.PlacementRef = in.PlacementRef
 	out.DRPolicyRef = in.DRPolicyRef
 	in.PVCSelector.DeepCopyInto(&out.PVCSelector)
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedNamespaceSelector != nil {
+		in, out := &in.ProtectedNamespaceSelector, &out.ProtectedNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.PVRestore = in.PVRestore
+	if in.RecoveryPointTime != nil {
+		in, out := &in.RecoveryPointTime, &out.RecoveryPointTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlSpec.
@@ -226,6 +296,36 @@ func (in *DRPlacementControlStatus) DeepCopyInto(out *DRPlacementControlStatus)
 	}
 	in.ResourceConditions.DeepCopyInto(&out.ResourceConditions)
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.LastGroupSyncTime != nil {
+		in, out := &in.LastGroupSyncTime, &out.LastGroupSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailoverLastGroupSyncTime != nil {
+		in, out := &in.FailoverLastGroupSyncTime, &out.FailoverLastGroupSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailoverDataLossWindow != nil {
+		in, out := &in.FailoverDataLossWindow, &out.FailoverDataLossWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PeerResync != nil {
+		in, out := &in.PeerResync, &out.PeerResync
+		*out = new(PeerResyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncSecret != nil {
+		in, out := &in.VolSyncSecret, &out.VolSyncSecret
+		*out = new(VolSyncSecretStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncRecoveryPoints != nil {
+		in, out := &in.VolSyncRecoveryPoints, &out.VolSyncRecoveryPoints
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPlacementControlStatus.
@@ -307,6 +407,30 @@ func (in *DRPolicySpec) DeepCopyInto(out *DRPolicySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StorageClassMappings != nil {
+		in, out := &in.StorageClassMappings, &out.StorageClassMappings
+		*out = make([]StorageClassMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolSyncProfile != nil {
+		in, out := &in.VolSyncProfile, &out.VolSyncProfile
+		*out = new(VolSyncProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncRecoveryPointRetention != nil {
+		in, out := &in.VolSyncRecoveryPointRetention, &out.VolSyncRecoveryPointRetention
+		*out = new(VolSyncRecoveryPointRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolSyncSchedulingIntervals != nil {
+		in, out := &in.VolSyncSchedulingIntervals, &out.VolSyncSchedulingIntervals
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRPolicySpec.
@@ -341,6 +465,114 @@ func (in *DRPolicyStatus) DeepCopy() *DRPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCSyncStatus) DeepCopyInto(out *PVCSyncStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSyncDuration != nil {
+		in, out := &in.LastSyncDuration, &out.LastSyncDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCSyncStatus.
+func (in *PVCSyncStatus) DeepCopy() *PVCSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVRestorePolicy) DeepCopyInto(out *PVRestorePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVRestorePolicy.
+func (in *PVRestorePolicy) DeepCopy() *PVRestorePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PVRestorePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVRestoreResult) DeepCopyInto(out *PVRestoreResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVRestoreResult.
+func (in *PVRestoreResult) DeepCopy() *PVRestoreResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PVRestoreResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerResyncStatus) DeepCopyInto(out *PeerResyncStatus) {
+	*out = *in
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerResyncStatus.
+func (in *PeerResyncStatus) DeepCopy() *PeerResyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerResyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectedNamespace) DeepCopyInto(out *ProtectedNamespace) {
+	*out = *in
+	if in.ProtectedPVCs != nil {
+		in, out := &in.ProtectedPVCs, &out.ProtectedPVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedNamespace.
+func (in *ProtectedNamespace) DeepCopy() *ProtectedNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectedNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProtectedPVC) DeepCopyInto(out *ProtectedPVC) {
 	*out = *in
@@ -362,6 +594,18 @@ func (in *ProtectedPVC) DeepCopyInto(out *ProtectedPVC) {
 		copy(*out, *in)
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(corev1.PersistentVolumeMode)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -369,6 +613,30 @@ func (in *ProtectedPVC) DeepCopyInto(out *ProtectedPVC) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(PVCSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RsyncAddress != nil {
+		in, out := &in.RsyncAddress, &out.RsyncAddress
+		*out = new(VolSyncRsyncAddress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecoveryPoints != nil {
+		in, out := &in.RecoveryPoints, &out.RecoveryPoints
+		*out = make([]VolSyncRecoveryPoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]VolSyncDestinationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedPVC.
@@ -381,6 +649,33 @@ func (in *ProtectedPVC) DeepCopy() *ProtectedPVC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectedVolumeGroup) DeepCopyInto(out *ProtectedVolumeGroup) {
+	*out = *in
+	if in.PVCs != nil {
+		in, out := &in.PVCs, &out.PVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedVolumeGroup.
+func (in *ProtectedVolumeGroup) DeepCopy() *ProtectedVolumeGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectedVolumeGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RamenConfig) DeepCopyInto(out *RamenConfig) {
 	*out = *in
@@ -429,6 +724,52 @@ func (in *S3StoreProfile) DeepCopy() *S3StoreProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClassMapping) DeepCopyInto(out *StorageClassMapping) {
+	*out = *in
+	if in.StorageClassNames != nil {
+		in, out := &in.StorageClassNames, &out.StorageClassNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Provisioners != nil {
+		in, out := &in.Provisioners, &out.Provisioners
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.VolumeAttributes != nil {
+		in, out := &in.VolumeAttributes, &out.VolumeAttributes
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassMapping.
+func (in *StorageClassMapping) DeepCopy() *StorageClassMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClassMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VRGAsyncSpec) DeepCopyInto(out *VRGAsyncSpec) {
 	*out = *in
@@ -477,6 +818,11 @@ func (in *VRGResourceMeta) DeepCopyInto(out *VRGResourceMeta) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRGResourceMeta.
@@ -489,6 +835,28 @@ func (in *VRGResourceMeta) DeepCopy() *VRGResourceMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VRGStorageClassMapping) DeepCopyInto(out *VRGStorageClassMapping) {
+	*out = *in
+	if in.VolumeAttributes != nil {
+		in, out := &in.VolumeAttributes, &out.VolumeAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VRGStorageClassMapping.
+func (in *VRGStorageClassMapping) DeepCopy() *VRGStorageClassMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(VRGStorageClassMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VRGSyncSpec) DeepCopyInto(out *VRGSyncSpec) {
 	*out = *in
@@ -504,6 +872,114 @@ func (in *VRGSyncSpec) DeepCopy() *VRGSyncSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncDestination) DeepCopyInto(out *VolSyncDestination) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncDestination.
+func (in *VolSyncDestination) DeepCopy() *VolSyncDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncDestinationStatus) DeepCopyInto(out *VolSyncDestinationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(PVCSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncDestinationStatus.
+func (in *VolSyncDestinationStatus) DeepCopy() *VolSyncDestinationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncDestinationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncProfile) DeepCopyInto(out *VolSyncProfile) {
+	*out = *in
+	if in.ServiceType != nil {
+		in, out := &in.ServiceType, &out.ServiceType
+		*out = new(corev1.ServiceType)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncProfile.
+func (in *VolSyncProfile) DeepCopy() *VolSyncProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRecoveryPoint) DeepCopyInto(out *VolSyncRecoveryPoint) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRecoveryPoint.
+func (in *VolSyncRecoveryPoint) DeepCopy() *VolSyncRecoveryPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRecoveryPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRecoveryPointRetention) DeepCopyInto(out *VolSyncRecoveryPointRetention) {
+	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRecoveryPointRetention.
+func (in *VolSyncRecoveryPointRetention) DeepCopy() *VolSyncRecoveryPointRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRecoveryPointRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolSyncReplicationDestinationSpec) DeepCopyInto(out *VolSyncReplicationDestinationSpec) {
 	*out = *in
@@ -524,6 +1000,11 @@ func (in *VolSyncReplicationDestinationSpec) DeepCopy() *VolSyncReplicationDesti
 func (in *VolSyncReplicationSourceSpec) DeepCopyInto(out *VolSyncReplicationSourceSpec) {
 	*out = *in
 	in.ProtectedPVC.DeepCopyInto(&out.ProtectedPVC)
+	if in.RsyncAddress != nil {
+		in, out := &in.RsyncAddress, &out.RsyncAddress
+		*out = new(VolSyncRsyncAddress)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncReplicationSourceSpec.
@@ -536,6 +1017,45 @@ func (in *VolSyncReplicationSourceSpec) DeepCopy() *VolSyncReplicationSourceSpec
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRsyncAddress) DeepCopyInto(out *VolSyncRsyncAddress) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRsyncAddress.
+func (in *VolSyncRsyncAddress) DeepCopy() *VolSyncRsyncAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRsyncAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncSecretStatus) DeepCopyInto(out *VolSyncSecretStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncSecretStatus.
+func (in *VolSyncSecretStatus) DeepCopy() *VolSyncSecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncSecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolSyncSpec) DeepCopyInto(out *VolSyncSpec) {
 	*out = *in
@@ -546,6 +1066,32 @@ func (in *VolSyncSpec) DeepCopyInto(out *VolSyncSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(VolSyncProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RSSpec != nil {
+		in, out := &in.RSSpec, &out.RSSpec
+		*out = make([]VolSyncReplicationSourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]VolSyncDestination, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecoveryPointRetention != nil {
+		in, out := &in.RecoveryPointRetention, &out.RecoveryPointRetention
+		*out = new(VolSyncRecoveryPointRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecoveryPointTime != nil {
+		in, out := &in.RecoveryPointTime, &out.RecoveryPointTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncSpec.
@@ -621,6 +1167,16 @@ func (in *VolumeReplicationGroupList) DeepCopyObject() runtime.Object {
 func (in *VolumeReplicationGroupSpec) DeepCopyInto(out *VolumeReplicationGroupSpec) {
 	*out = *in
 	in.PVCSelector.DeepCopyInto(&out.PVCSelector)
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedNamespaceSelector != nil {
+		in, out := &in.ProtectedNamespaceSelector, &out.ProtectedNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.S3Profiles != nil {
 		in, out := &in.S3Profiles, &out.S3Profiles
 		*out = make([]string, len(*in))
@@ -628,7 +1184,15 @@ func (in *VolumeReplicationGroupSpec) DeepCopyInto(out *VolumeReplicationGroupSp
 	}
 	in.Async.DeepCopyInto(&out.Async)
 	out.Sync = in.Sync
-	in.VolSync.DeepCopyInto(&out.VolSync)
+	out.VolSync = in.VolSync
+	if in.StorageClassMappings != nil {
+		in, out := &in.StorageClassMappings, &out.StorageClassMappings
+		*out = make([]VRGStorageClassMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.PVRestore = in.PVRestore
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeReplicationGroupSpec.
@@ -651,6 +1215,39 @@ func (in *VolumeReplicationGroupStatus) DeepCopyInto(out *VolumeReplicationGroup
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ProtectedVolumeGroups != nil {
+		in, out := &in.ProtectedVolumeGroups, &out.ProtectedVolumeGroups
+		*out = make([]ProtectedVolumeGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludedPVCs != nil {
+		in, out := &in.ExcludedPVCs, &out.ExcludedPVCs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = make([]ProtectedNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PVRestoreResults != nil {
+		in, out := &in.PVRestoreResults, &out.PVRestoreResults
+		*out = make([]PVRestoreResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastGroupSyncTime != nil {
+		in, out := &in.LastGroupSyncTime, &out.LastGroupSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.WarmStandby != nil {
+		in, out := &in.WarmStandby, &out.WarmStandby
+		*out = new(WarmStandbyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -670,3 +1267,22 @@ func (in *VolumeReplicationGroupStatus) DeepCopy() *VolumeReplicationGroupStatus
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmStandbyStatus) DeepCopyInto(out *WarmStandbyStatus) {
+	*out = *in
+	if in.LastPVRestoreTime != nil {
+		in, out := &in.LastPVRestoreTime, &out.LastPVRestoreTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmStandbyStatus.
+func (in *WarmStandbyStatus) DeepCopy() *WarmStandbyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmStandbyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -44,6 +44,7 @@ type DRClusterReconciler struct {
 	APIReader         client.Reader
 	Scheme            *runtime.Scheme
 	ObjectStoreGetter ObjectStoreGetter
+	MCVGetter         ManagedClusterViewGetter
 }
 
 // DRCluster condition reasons
@@ -71,6 +72,10 @@ const (
 //+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups=work.open-cluster-management.io,resources=manifestworks,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch
+//+kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
+//+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drplacementcontrols,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -108,15 +113,41 @@ func (r *DRClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if !drcluster.ObjectMeta.DeletionTimestamp.IsZero() {
 		log.Info("delete")
 
+		// Move applications off the cluster before letting it go
+		drained, err := u.drain()
+		if updateErr := u.statusUpdate(); updateErr != nil {
+			log.Error(updateErr, "status update failed")
+		}
+
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("drain: %w", err)
+		}
+
+		if !drained {
+			log.Info("waiting for drain to complete before deleting")
+
+			return ctrl.Result{RequeueAfter: drClusterDrainInterval}, nil
+		}
+
 		// Undeploy manifests
 		if err := drClusterUndeploy(drcluster, &manifestWorkUtil); err != nil {
 			return ctrl.Result{}, fmt.Errorf("drclusters undeploy: %w", err)
 		}
 
+		if err := u.operatorViewsDelete(); err != nil {
+			return ctrl.Result{}, fmt.Errorf("dr-cluster operator views delete: %w", err)
+		}
+
+		if err := u.operatorUpgradeLeaseRelease(); err != nil {
+			return ctrl.Result{}, fmt.Errorf("dr-cluster operator upgrade lease release: %w", err)
+		}
+
 		if err := u.finalizerRemove(); err != nil {
 			return ctrl.Result{}, fmt.Errorf("finalizer remove update: %w", err)
 		}
 
+		drClusterHealthMetricsDelete(drcluster)
+
 		return ctrl.Result{}, nil
 	}
 
@@ -131,7 +162,8 @@ func (r *DRClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, fmt.Errorf("validate: %w", u.validatedSetFalse(reason, err))
 	}
 
-	if err := drClusterDeploy(drcluster, &manifestWorkUtil, ramenConfig); err != nil {
+	deployDeferred, err := u.operatorDeploy(ramenConfig)
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("drclusters deploy: %w", u.validatedSetFalse("DrClustersDeployFailed", err))
 	}
 
@@ -139,7 +171,33 @@ func (r *DRClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	setDRClusterValidatedCondition(&drcluster.Status.Conditions, drcluster.Generation, "Validated the cluster")
 
-	return r.processFencing(u)
+	// The installed operator version is read before it is reported with the operator readiness
+	u.operatorVersionCheck(ramenConfig, deployDeferred)
+	u.healthProbe(ramenConfig)
+
+	if err := u.maintenanceStatusUpdate(); err != nil {
+		log.Error(err, "maintenance status update failed")
+	}
+
+	drained, drainErr := u.drain()
+	if drainErr != nil {
+		log.Error(drainErr, "drain failed")
+	}
+
+	result, err := r.processFencing(u)
+	if err == nil && !result.Requeue {
+		// Periodically re-probe the cluster health, and drain and upgrade progress more often
+		result.RequeueAfter = drClusterHealthProbeInterval
+
+		switch {
+		case drcluster.Spec.Drain && !drained:
+			result.RequeueAfter = drClusterDrainInterval
+		case deployDeferred || drClusterUpgrading([]ramen.DRCluster{*drcluster}, drcluster.Name):
+			result.RequeueAfter = drClusterOperatorUpgradeCheckInterval
+		}
+	}
+
+	return result, err
 }
 
 func (u *drclusterUpdater) initializeStatus() {
@@ -578,9 +636,35 @@ func (r *DRClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&source.Kind{Type: &corev1.ConfigMap{}},
 			handler.EnqueueRequestsFromMapFunc(r.drClusterConfigMapMapFunc),
 		).
+		Watches(
+			&source.Kind{Type: &ramen.DRPlacementControl{}},
+			handler.EnqueueRequestsFromMapFunc(r.drClusterDRPCMapFunc),
+		).
 		Complete(r)
 }
 
+// drClusterDRPCMapFunc enqueues the DRClusters of a DRPC's DRPolicy, to update their maintenance and drain status
+// as the DRPC's placement changes
+func (r *DRClusterReconciler) drClusterDRPCMapFunc(drpc client.Object) []reconcile.Request {
+	drpcObj, ok := drpc.(*ramen.DRPlacementControl)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	drPolicy := &ramen.DRPolicy{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: drpcObj.Spec.DRPolicyRef.Name},
+		drPolicy); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(drPolicy.Spec.DRClusters))
+	for i, drcluster := range drPolicy.Spec.DRClusters {
+		requests[i].Name = drcluster
+	}
+
+	return requests
+}
+
 func (r *DRClusterReconciler) drClusterConfigMapMapFunc(configMap client.Object) []reconcile.Request {
 	if configMap.GetName() != HubOperatorConfigMapName || configMap.GetNamespace() != NamespaceName() {
 		return []reconcile.Request{}
@@ -255,6 +255,26 @@ var _ = Describe("DRClusterController", func() {
 				conditionExpect(drcluster, false, metav1.ConditionTrue, Equal("Succeeded"), Ignore(),
 					ramen.DRClusterValidated)
 			})
+			It("reports health conditions", func() {
+				By("not finding a ManagedCluster for the DRCluster")
+				conditionExpect(drcluster, false, metav1.ConditionUnknown,
+					Equal(controllers.DRClusterConditionReasonProbeFailed), Ignore(),
+					ramen.DRClusterConditionTypeManagedClusterAvailable)
+				By("not finding the ManagedCluster of its peer")
+				conditionExpect(drcluster, false, metav1.ConditionUnknown,
+					Equal(controllers.DRClusterConditionReasonProbeFailed), ContainSubstring("drc-cluster1"),
+					ramen.DRClusterConditionTypePeersAvailable)
+			})
+			It("reports the dr-cluster operator version as compatible and up to date", func() {
+				conditionExpect(drcluster, false, metav1.ConditionTrue,
+					Equal(controllers.DRClusterConditionReasonVersionCompatible), Ignore(),
+					ramen.DRClusterConditionTypeOperatorVersionCompatible)
+				conditionExpect(drcluster, false, metav1.ConditionFalse,
+					Equal(controllers.DRClusterConditionReasonUpToDate), Ignore(),
+					ramen.DRClusterConditionTypeOperatorUpgrading)
+				Expect(drcluster.Status.Operator).NotTo(BeNil())
+				Expect(drcluster.Status.Operator.InstalledVersion).To(Equal("0.0.1"))
+			})
 		})
 		When("S3Profile is changed to an invalid profile in ramen config", func() {
 			It("reports NOT validated with reason s3ConnectionFailed", func() {
@@ -408,6 +428,71 @@ var _ = Describe("DRClusterController", func() {
 		})
 	})
 
+	Context("DRCluster resource maintenance", func() {
+		Specify("create a drcluster copy for changes", func() {
+			createPolicies()
+			drcluster = drclusters[0].DeepCopy()
+		})
+		When("maintenance is enabled", func() {
+			It("reports under maintenance with no affected DRPCs", func() {
+				drcluster.Spec.Maintenance = true
+				Expect(k8sClient.Create(context.TODO(), drcluster)).To(Succeed())
+				conditionExpect(drcluster, false, metav1.ConditionTrue,
+					Equal(controllers.DRClusterConditionReasonUnderMaintenance),
+					Equal("Cluster is under maintenance, no DRPCs affected"),
+					ramen.DRClusterConditionTypeMaintenance)
+			})
+		})
+		When("maintenance is disabled", func() {
+			It("reports not under maintenance", func() {
+				drcluster.Spec.Maintenance = false
+				Expect(k8sClient.Update(context.TODO(), drcluster)).To(Succeed())
+				conditionExpect(drcluster, false, metav1.ConditionFalse,
+					Equal(controllers.DRClusterConditionReasonNotUnderMaintenance), Ignore(),
+					ramen.DRClusterConditionTypeMaintenance)
+			})
+		})
+		When("deleting a DRCluster that was under maintenance", func() {
+			It("is successful", func() {
+				drpolicyDelete(syncDRPolicy)
+				drclusterDelete(drcluster)
+			})
+		})
+	})
+
+	Context("DRCluster resource drain", func() {
+		Specify("create a drcluster copy for changes", func() {
+			createPolicies()
+			drcluster = drclusters[0].DeepCopy()
+		})
+		When("drain is not requested", func() {
+			It("reports drain not requested", func() {
+				Expect(k8sClient.Create(context.TODO(), drcluster)).To(Succeed())
+				conditionExpect(drcluster, false, metav1.ConditionFalse,
+					Equal(controllers.DRClusterConditionReasonDrainNotRequested), Ignore(),
+					ramen.DRClusterConditionTypeDrained)
+			})
+		})
+		When("drain is requested and no DRPCs are placed on the cluster", func() {
+			It("reports drained", func() {
+				drcluster.Spec.Drain = true
+				Expect(k8sClient.Update(context.TODO(), drcluster)).To(Succeed())
+				conditionExpect(drcluster, false, metav1.ConditionTrue,
+					Equal(controllers.DRClusterConditionReasonDrained), Equal("Cluster is drained"),
+					ramen.DRClusterConditionTypeDrained)
+				Expect(drcluster.Status.Drain).NotTo(BeNil())
+				Expect(drcluster.Status.Drain.PendingDRPCs).To(BeEmpty())
+				Expect(drcluster.Status.Drain.RemainingManifestWorks).To(BeEmpty())
+			})
+		})
+		When("deleting a drained DRCluster", func() {
+			It("is successful", func() {
+				drpolicyDelete(syncDRPolicy)
+				drclusterDelete(drcluster)
+			})
+		})
+	})
+
 	Context("DRCluster resource cluster name validation", func() {
 		Specify("create a drcluster copy for changes", func() {
 			createPolicies()
@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// DRCluster drain condition reasons
+const (
+	DRClusterConditionReasonDrainNotRequested = "NotRequested"
+	DRClusterConditionReasonDraining          = "Draining"
+	DRClusterConditionReasonDrainBlocked      = "DrainBlocked"
+	DRClusterConditionReasonDrained           = "Drained"
+)
+
+// drClusterDrainInterval is the interval at which drain progress is rechecked
+const drClusterDrainInterval = 30 * time.Second
+
+// drain moves the applications of all DRPCs placed on the DRCluster to their
+// peer cluster, relocating them if the cluster is available and failing them
+// over otherwise. It reports the progress in the DRCluster status and returns
+// true once no DRPC is placed on the cluster and no VRG ManifestWork remains for
+// it. A DRCluster being deleted is drained, even if a drain is not requested.
+func (u *drclusterUpdater) drain() (bool, error) {
+	const drained = true
+
+	if !u.object.Spec.Drain && u.object.DeletionTimestamp.IsZero() {
+		u.object.Status.Drain = nil
+		setDRClusterDrainedCondition(&u.object.Status.Conditions, u.object.Generation, metav1.ConditionFalse,
+			DRClusterConditionReasonDrainNotRequested, "Drain not requested")
+
+		return !drained, nil
+	}
+
+	drainStatus := &ramen.DRClusterDrainStatus{}
+	u.object.Status.Drain = drainStatus
+
+	drpcs := &ramen.DRPlacementControlList{}
+	if err := u.reconciler.APIReader.List(u.ctx, drpcs); err != nil {
+		setDRClusterDrainedCondition(&u.object.Status.Conditions, u.object.Generation, metav1.ConditionUnknown,
+			DRClusterConditionReasonDraining, "Failed to list DRPCs")
+
+		return !drained, fmt.Errorf("drpcs list: %w", err)
+	}
+
+	for i := range drpcs.Items {
+		drpc := &drpcs.Items[i]
+		if drpc.Status.PreferredDecision.ClusterName != u.object.Name {
+			continue
+		}
+
+		drpcName := drpc.Namespace + "/" + drpc.Name
+		drainStatus.PendingDRPCs = append(drainStatus.PendingDRPCs, drpcName)
+
+		moving, err := u.drainDRPC(drpc)
+		if err != nil {
+			return !drained, fmt.Errorf("drpc %s drain: %w", drpcName, err)
+		}
+
+		if !moving {
+			drainStatus.BlockedDRPCs = append(drainStatus.BlockedDRPCs, drpcName)
+		}
+	}
+
+	mwNames, err := drClusterVRGManifestWorkNames(u)
+	if err != nil {
+		return !drained, err
+	}
+
+	drainStatus.RemainingManifestWorks = mwNames
+
+	sort.Strings(drainStatus.PendingDRPCs)
+	sort.Strings(drainStatus.BlockedDRPCs)
+
+	switch {
+	case len(drainStatus.BlockedDRPCs) != 0:
+		setDRClusterDrainedCondition(&u.object.Status.Conditions, u.object.Generation, metav1.ConditionFalse,
+			DRClusterConditionReasonDrainBlocked,
+			fmt.Sprintf("No peer cluster available for %d DRPCs", len(drainStatus.BlockedDRPCs)))
+	case len(drainStatus.PendingDRPCs) != 0 || len(drainStatus.RemainingManifestWorks) != 0:
+		setDRClusterDrainedCondition(&u.object.Status.Conditions, u.object.Generation, metav1.ConditionFalse,
+			DRClusterConditionReasonDraining,
+			fmt.Sprintf("Waiting for %d DRPCs to move and %d VRG ManifestWorks to be deleted",
+				len(drainStatus.PendingDRPCs), len(drainStatus.RemainingManifestWorks)))
+	default:
+		setDRClusterDrainedCondition(&u.object.Status.Conditions, u.object.Generation, metav1.ConditionTrue,
+			DRClusterConditionReasonDrained, "Cluster is drained")
+
+		return drained, nil
+	}
+
+	return !drained, nil
+}
+
+// drainDRPC requests the DRPC to move its application away from the DRCluster,
+// unless it is already doing so: to relocate it to the peer cluster, or to fail
+// it over to the peer cluster if the DRCluster is not available. It returns
+// false if no peer cluster can receive the application.
+func (u *drclusterUpdater) drainDRPC(drpc *ramen.DRPlacementControl) (bool, error) {
+	// An action already moving the application away from this cluster is left alone
+	switch drpc.Spec.Action {
+	case ramen.ActionFailover:
+		if drpc.Spec.FailoverCluster != "" && drpc.Spec.FailoverCluster != u.object.Name {
+			return true, nil
+		}
+	case ramen.ActionRelocate:
+		if drpc.Spec.PreferredCluster != "" && drpc.Spec.PreferredCluster != u.object.Name {
+			return true, nil
+		}
+	}
+
+	peer, err := u.drainPeerCluster(drpc)
+	if err != nil || peer == "" {
+		return false, err
+	}
+
+	if drClusterAvailable(u.object) {
+		drpc.Spec.Action = ramen.ActionRelocate
+		drpc.Spec.PreferredCluster = peer
+	} else {
+		drpc.Spec.Action = ramen.ActionFailover
+		drpc.Spec.FailoverCluster = peer
+	}
+
+	u.log.Info(fmt.Sprintf("Draining DRPC %s/%s, action %s to cluster %s",
+		drpc.Namespace, drpc.Name, drpc.Spec.Action, peer))
+
+	if err := u.client.Update(u.ctx, drpc); err != nil {
+		return false, fmt.Errorf("update: %w", err)
+	}
+
+	return true, nil
+}
+
+// drainPeerCluster returns the peer of the DRCluster in the DRPolicy of the
+// DRPC, the cluster in the same region for a metro DRPolicy or else the first
+// other cluster of the DRPolicy. It returns an empty string if there is no peer
+// or if the peer is unavailable, out of service or being deleted.
+func (u *drclusterUpdater) drainPeerCluster(drpc *ramen.DRPlacementControl) (string, error) {
+	drpolicy := &ramen.DRPolicy{}
+	if err := u.reconciler.APIReader.Get(u.ctx, types.NamespacedName{Name: drpc.Spec.DRPolicyRef.Name},
+		drpolicy); err != nil {
+		return "", fmt.Errorf("drpolicy get: %w", err)
+	}
+
+	var peer *ramen.DRCluster
+
+	for _, clusterName := range util.DrpolicyClusterNames(drpolicy) {
+		if clusterName == u.object.Name {
+			continue
+		}
+
+		drcluster := &ramen.DRCluster{}
+		if err := u.reconciler.APIReader.Get(u.ctx, types.NamespacedName{Name: clusterName}, drcluster); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return "", fmt.Errorf("drcluster %s get: %w", clusterName, err)
+		}
+
+		if peer == nil || drcluster.Spec.Region == u.object.Spec.Region && peer.Spec.Region != u.object.Spec.Region {
+			peer = drcluster
+		}
+	}
+
+	if peer == nil || drClusterOutOfServiceReason([]ramen.DRCluster{*peer}, peer.Name) != "" ||
+		!peer.DeletionTimestamp.IsZero() || !drClusterAvailable(peer) {
+		return "", nil
+	}
+
+	return peer.Name, nil
+}
+
+// drClusterVRGManifestWorkNames returns the sorted names of the VRG
+// ManifestWorks present in the DRCluster namespace. ManifestWorks already being
+// deleted are left out, as the work agent of an unreachable cluster never
+// confirms their deletion, which would otherwise keep the DRCluster forever.
+func drClusterVRGManifestWorkNames(u *drclusterUpdater) ([]string, error) {
+	mws := &ocmworkv1.ManifestWorkList{}
+	if err := u.reconciler.APIReader.List(u.ctx, mws, client.InNamespace(u.object.Name),
+		client.MatchingLabels{"app": "VRG"}); err != nil {
+		return nil, fmt.Errorf("manifestworks list: %w", err)
+	}
+
+	names := make([]string, 0, len(mws.Items))
+	for i := range mws.Items {
+		if !mws.Items[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		names = append(names, mws.Items[i].Name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func setDRClusterDrainedCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	status metav1.ConditionStatus, reason, message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeDrained,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             status,
+		Message:            message,
+	})
+}
+
+// drClusterDraining returns true if a drain is requested for the named cluster
+func drClusterDraining(drClusters []ramen.DRCluster, cluster string) bool {
+	for i := range drClusters {
+		if drClusters[i].Name == cluster {
+			return drClusters[i].Spec.Drain
+		}
+	}
+
+	return false
+}
+
+// drClusterOutOfServiceReason returns why applications should not be moved to
+// the named cluster, or an empty string if they can be
+func drClusterOutOfServiceReason(drClusters []ramen.DRCluster, cluster string) string {
+	switch {
+	case drClusterUnderMaintenance(drClusters, cluster):
+		return "under maintenance"
+	case drClusterDraining(drClusters, cluster):
+		return "being drained"
+	case drClusterUpgrading(drClusters, cluster):
+		return "upgrading its dr-cluster operator"
+	}
+
+	return ""
+}
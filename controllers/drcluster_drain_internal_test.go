@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRCluster drain", func() {
+	const (
+		drainedCluster = "east"
+		peerCluster    = "west"
+	)
+
+	var (
+		k8sClient client.Client
+		u         *drclusterUpdater
+	)
+
+	getDRPC := func() *ramen.DRPlacementControl {
+		drpc := &ramen.DRPlacementControl{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: "app", Namespace: "app-ns"}, drpc)).
+			To(Succeed())
+
+		return drpc
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(ramen.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+
+		drpolicy := &ramen.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec:       ramen.DRPolicySpec{DRClusters: []string{drainedCluster, peerCluster}},
+		}
+		drpc := &ramen.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "app-ns"},
+			Spec:       ramen.DRPlacementControlSpec{DRPolicyRef: corev1.ObjectReference{Name: "policy"}},
+			Status: ramen.DRPlacementControlStatus{
+				PreferredDecision: plrv1.PlacementDecision{ClusterName: drainedCluster},
+			},
+		}
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			drpolicy, drpc,
+			&ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: drainedCluster}},
+			&ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: peerCluster}},
+		).Build()
+
+		drcluster := &ramen.DRCluster{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: drainedCluster}, drcluster)).To(Succeed())
+		drcluster.Spec.Drain = true
+
+		u = &drclusterUpdater{
+			ctx:        context.TODO(),
+			object:     drcluster,
+			client:     k8sClient,
+			log:        logr.Discard(),
+			reconciler: &DRClusterReconciler{Client: k8sClient, APIReader: k8sClient, Scheme: scheme},
+		}
+	})
+
+	It("Should relocate the DRPCs placed on an available cluster to the peer cluster", func() {
+		drained, err := u.drain()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drained).To(BeFalse())
+		Expect(u.object.Status.Drain.PendingDRPCs).To(Equal([]string{"app-ns/app"}))
+		Expect(u.object.Status.Drain.BlockedDRPCs).To(BeEmpty())
+
+		drpc := getDRPC()
+		Expect(drpc.Spec.Action).To(Equal(ramen.ActionRelocate))
+		Expect(drpc.Spec.PreferredCluster).To(Equal(peerCluster))
+	})
+
+	It("Should fail over the DRPCs placed on an unavailable cluster to the peer cluster", func() {
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeManagedClusterAvailable,
+			u.object.Generation, metav1.ConditionFalse, "Unavailable", "ManagedCluster is not available")
+
+		_, err := u.drain()
+		Expect(err).NotTo(HaveOccurred())
+
+		drpc := getDRPC()
+		Expect(drpc.Spec.Action).To(Equal(ramen.ActionFailover))
+		Expect(drpc.Spec.FailoverCluster).To(Equal(peerCluster))
+	})
+
+	It("Should report the DRPCs blocked when the peer cluster is being drained too", func() {
+		peer := &ramen.DRCluster{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: peerCluster}, peer)).To(Succeed())
+		peer.Spec.Drain = true
+		Expect(k8sClient.Update(context.TODO(), peer)).To(Succeed())
+
+		_, err := u.drain()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.object.Status.Drain.BlockedDRPCs).To(Equal([]string{"app-ns/app"}))
+		Expect(getDRPC().Spec.Action).To(BeEmpty())
+	})
+
+	It("Should not wait for the VRG ManifestWorks already being deleted", func() {
+		drpc := getDRPC()
+		drpc.Status.PreferredDecision.ClusterName = peerCluster
+		Expect(k8sClient.Status().Update(context.TODO(), drpc)).To(Succeed())
+
+		for _, name := range []string{"app-vrg-mw", "gone-vrg-mw"} {
+			Expect(k8sClient.Create(context.TODO(), &ocmworkv1.ManifestWork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       name,
+					Namespace:  drainedCluster,
+					Labels:     map[string]string{"app": "VRG"},
+					Finalizers: []string{"cluster.open-cluster-management.io/manifest-work-cleanup"},
+				},
+			})).To(Succeed())
+		}
+
+		drained, err := u.drain()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drained).To(BeFalse())
+		Expect(u.object.Status.Drain.RemainingManifestWorks).To(Equal([]string{"app-vrg-mw", "gone-vrg-mw"}))
+
+		// The work agent of an unreachable cluster never removes the finalizers
+		for _, name := range []string{"app-vrg-mw", "gone-vrg-mw"} {
+			Expect(k8sClient.Delete(context.TODO(), &ocmworkv1.ManifestWork{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: drainedCluster},
+			})).To(Succeed())
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: drainedCluster},
+				&ocmworkv1.ManifestWork{})).To(Succeed())
+		}
+
+		drained, err = u.drain()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drained).To(BeTrue())
+		Expect(u.object.Status.Drain.RemainingManifestWorks).To(BeEmpty())
+	})
+})
@@ -0,0 +1,535 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ocmclv1 "github.com/open-cluster-management/api/cluster/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+// DRCluster health is probed on every reconcile, and the DRCluster is
+// requeued at this interval to keep the health conditions and metrics fresh.
+// The S3 store round trip, which writes to the store, is run at most once per
+// interval.
+const drClusterHealthProbeInterval = 5 * time.Minute
+
+// DRCluster health condition reasons
+const (
+	DRClusterConditionReasonHealthy         = "Healthy"
+	DRClusterConditionReasonUnhealthy       = "Unhealthy"
+	DRClusterConditionReasonPeerUnavailable = "PeerUnavailable"
+	DRClusterConditionReasonProbeFailed     = "ProbeFailed"
+	DRClusterConditionReasonNotApplicable   = "NotApplicable"
+)
+
+// drClusterHealthProbeKeySuffix is the name of the object written to, and read
+// back from, the S3 store of a DRCluster to measure its round-trip latency.
+const drClusterHealthProbeKeySuffix = "ramen-health-probe"
+
+// drClusterHealthProbeKeyPrefix is the reserved key prefix the probe objects
+// of the DRClusters are written under, followed by the DRCluster name. Its
+// first segment is not a valid namespace name, so it can not collide with the
+// <namespace>/<vrg>/ keys of the VRGs.
+const drClusterHealthProbeKeyPrefix = "ramendr.openshift.io/health-probe/"
+
+// drClusterHealthProbeState keeps, per DRCluster, the time of the last S3 store
+// round trip and the operator version its readiness metric is labeled with
+type drClusterHealthProbeState struct {
+	s3StoreProbeTime    time.Time
+	s3StoreProbeProfile string
+	operatorVersion     string
+}
+
+var (
+	drClusterHealthProbeStates     = map[string]*drClusterHealthProbeState{}
+	drClusterHealthProbeStatesLock sync.Mutex
+)
+
+func drClusterHealthProbeStateGet(drclusterName string) *drClusterHealthProbeState {
+	state, ok := drClusterHealthProbeStates[drclusterName]
+	if !ok {
+		state = &drClusterHealthProbeState{}
+		drClusterHealthProbeStates[drclusterName] = state
+	}
+
+	return state
+}
+
+var (
+	drClusterManagedClusterAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_drcluster_managed_cluster_available",
+			Help: "Whether the managed cluster of a DRCluster is available (1) or not (0)",
+		},
+		[]string{"drcluster"},
+	)
+
+	drClusterS3StoreHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_drcluster_s3_store_healthy",
+			Help: "Whether the S3 profile of a DRCluster passed its last round-trip probe (1) or not (0)",
+		},
+		[]string{"drcluster", "s3profile"},
+	)
+
+	drClusterS3StoreLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_drcluster_s3_store_latency_seconds",
+			Help: "Duration of the last write/read round-trip probe of the S3 profile of a DRCluster",
+		},
+		[]string{"drcluster", "s3profile"},
+	)
+
+	drClusterOperatorReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_drcluster_operator_ready",
+			Help: "Whether the dr-cluster operator deployed to a DRCluster is ready (1) or not (0)",
+		},
+		[]string{"drcluster", "version"},
+	)
+
+	drClusterPeersAvailable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_drcluster_peers_available",
+			Help: "Whether the ManagedClusters of all peers of a DRCluster are available and their S3 stores " +
+				"healthy (1) or not (0)",
+		},
+		[]string{"drcluster"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		drClusterManagedClusterAvailable,
+		drClusterS3StoreHealthy,
+		drClusterS3StoreLatency,
+		drClusterOperatorReady,
+		drClusterPeersAvailable,
+	)
+}
+
+func boolToGaugeValue(value bool) float64 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
+
+// healthProbe probes the health of the DRCluster and its peers, and reports
+// the results as DRCluster conditions and metrics. Probe failures are never
+// returned as errors, as they should not block other DRCluster processing.
+func (u *drclusterUpdater) healthProbe(ramenConfig *ramen.RamenConfig) {
+	u.managedClusterAvailabilityProbe()
+	u.s3StoreProbe()
+	u.operatorReadinessProbe(ramenConfig)
+	u.peersAvailabilityProbe()
+}
+
+func (u *drclusterUpdater) managedClusterAvailabilityProbe() {
+	status, reason, message := managedClusterAvailability(u, u.object.Name)
+
+	drClusterManagedClusterAvailable.WithLabelValues(u.object.Name).Set(
+		boolToGaugeValue(status == metav1.ConditionTrue))
+
+	setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeManagedClusterAvailable,
+		u.object.Generation, status, reason, message)
+}
+
+// managedClusterAvailability returns the availability of the named managed
+// cluster, as reported by its ManagedCluster resource on the hub.
+func managedClusterAvailability(u *drclusterUpdater, clusterName string) (
+	metav1.ConditionStatus, string, string,
+) {
+	managedCluster := &ocmclv1.ManagedCluster{}
+
+	if err := u.reconciler.APIReader.Get(u.ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+		u.log.Info("managed cluster get failed", "cluster", clusterName, "error", err)
+
+		return metav1.ConditionUnknown, DRClusterConditionReasonProbeFailed,
+			fmt.Sprintf("failed to get managed cluster %s: %v", clusterName, err)
+	}
+
+	condition := meta.FindStatusCondition(managedCluster.Status.Conditions, ocmclv1.ManagedClusterConditionAvailable)
+	if condition == nil {
+		return metav1.ConditionUnknown, DRClusterConditionReasonProbeFailed,
+			fmt.Sprintf("managed cluster %s has not reported its availability", clusterName)
+	}
+
+	switch condition.Status {
+	case metav1.ConditionTrue:
+		return metav1.ConditionTrue, DRClusterConditionReasonHealthy,
+			fmt.Sprintf("managed cluster %s is available", clusterName)
+	case metav1.ConditionFalse:
+		return metav1.ConditionFalse, DRClusterConditionReasonUnhealthy,
+			fmt.Sprintf("managed cluster %s is not available: %s", clusterName, condition.Message)
+	default:
+		return metav1.ConditionUnknown, DRClusterConditionReasonUnhealthy,
+			fmt.Sprintf("managed cluster %s availability is unknown: %s", clusterName, condition.Message)
+	}
+}
+
+// s3StoreProbe uploads a probe object to the S3 profile of the DRCluster,
+// downloads it back, and deletes it, measuring the round-trip latency. The
+// latency is only reported as a metric, to keep the condition message stable
+// across probes and avoid needless status updates.
+func (u *drclusterUpdater) s3StoreProbe() {
+	s3ProfileName := u.object.Spec.S3ProfileName
+
+	if s3ProfileName == NoS3StoreAvailable {
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeS3StoreHealthy,
+			u.object.Generation, metav1.ConditionTrue, DRClusterConditionReasonNotApplicable,
+			"no S3 profile configured")
+
+		return
+	}
+
+	// Similar to validateS3Profile, a fenced cluster's S3 store is not expected to be reachable
+	if u.object.Spec.ClusterFence == ramen.ClusterFenceStateFenced ||
+		u.object.Spec.ClusterFence == ramen.ClusterFenceStateManuallyFenced {
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeS3StoreHealthy,
+			u.object.Generation, metav1.ConditionUnknown, DRClusterConditionReasonNotApplicable,
+			"cluster is fenced")
+
+		return
+	}
+
+	if !u.s3StoreProbeDue(s3ProfileName) {
+		return
+	}
+
+	start := time.Now()
+	err := s3StoreRoundTrip(u, s3ProfileName)
+	latency := time.Since(start)
+
+	drClusterS3StoreHealthy.WithLabelValues(u.object.Name, s3ProfileName).Set(boolToGaugeValue(err == nil))
+
+	if err != nil {
+		u.log.Info("s3 store probe failed", "profile", s3ProfileName, "error", err)
+		drClusterS3StoreLatency.DeleteLabelValues(u.object.Name, s3ProfileName)
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeS3StoreHealthy,
+			u.object.Generation, metav1.ConditionFalse, DRClusterConditionReasonUnhealthy, err.Error())
+
+		return
+	}
+
+	drClusterS3StoreLatency.WithLabelValues(u.object.Name, s3ProfileName).Set(latency.Seconds())
+	setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeS3StoreHealthy,
+		u.object.Generation, metav1.ConditionTrue, DRClusterConditionReasonHealthy,
+		fmt.Sprintf("s3 profile %s passed the write/read round-trip probe", s3ProfileName))
+}
+
+// s3StoreProbeDue returns true if the S3 profile of the DRCluster was not
+// probed within the probe interval, or its condition is not current, and
+// records the probe time if so
+func (u *drclusterUpdater) s3StoreProbeDue(s3ProfileName string) bool {
+	drClusterHealthProbeStatesLock.Lock()
+	defer drClusterHealthProbeStatesLock.Unlock()
+
+	state := drClusterHealthProbeStateGet(u.object.Name)
+
+	condition := findCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeS3StoreHealthy)
+	if condition != nil && condition.ObservedGeneration == u.object.Generation &&
+		condition.Reason != DRClusterConditionReasonNotApplicable &&
+		state.s3StoreProbeProfile == s3ProfileName &&
+		time.Since(state.s3StoreProbeTime) < drClusterHealthProbeInterval {
+		return false
+	}
+
+	if state.s3StoreProbeProfile != "" && state.s3StoreProbeProfile != s3ProfileName {
+		drClusterS3StoreHealthy.DeleteLabelValues(u.object.Name, state.s3StoreProbeProfile)
+		drClusterS3StoreLatency.DeleteLabelValues(u.object.Name, state.s3StoreProbeProfile)
+	}
+
+	state.s3StoreProbeTime = time.Now()
+	state.s3StoreProbeProfile = s3ProfileName
+
+	return true
+}
+
+func s3StoreRoundTrip(u *drclusterUpdater, s3ProfileName string) error {
+	objectStore, err := u.reconciler.ObjectStoreGetter.ObjectStore(u.ctx, u.reconciler.APIReader,
+		s3ProfileName, "drcluster health probe", u.log)
+	if err != nil {
+		return fmt.Errorf("s3 profile %s connection failed: %w", s3ProfileName, err)
+	}
+
+	keyPrefix := drClusterHealthProbeKeyPrefix + u.object.Name + "/"
+	probe := corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        drClusterHealthProbeKeySuffix,
+			Annotations: map[string]string{"ramendr.openshift.io/probe-time": time.Now().UTC().Format(time.RFC3339)},
+		},
+	}
+
+	if err := objectStore.UploadPV(keyPrefix, drClusterHealthProbeKeySuffix, probe); err != nil {
+		return fmt.Errorf("s3 profile %s write failed: %w", s3ProfileName, err)
+	}
+
+	downloaded, err := objectStore.DownloadPVs(keyPrefix)
+	if err != nil {
+		return fmt.Errorf("s3 profile %s read failed: %w", s3ProfileName, err)
+	}
+
+	found := false
+
+	for i := range downloaded {
+		if downloaded[i].Name == probe.Name &&
+			downloaded[i].Annotations["ramendr.openshift.io/probe-time"] ==
+				probe.Annotations["ramendr.openshift.io/probe-time"] {
+			found = true
+
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("s3 profile %s read did not return the probe object that was written", s3ProfileName)
+	}
+
+	if err := objectStore.DeleteObjects(keyPrefix); err != nil {
+		return fmt.Errorf("s3 profile %s delete failed: %w", s3ProfileName, err)
+	}
+
+	return nil
+}
+
+// operatorReadinessProbe reports the readiness of the dr-cluster operator, as
+// deployed by drClusterDeploy, from the status of its ManifestWork, along with
+// its version installed on the cluster, as read by operatorVersionCheck.
+func (u *drclusterUpdater) operatorReadinessProbe(ramenConfig *ramen.RamenConfig) {
+	if !ramenConfig.DrClusterOperator.DeploymentAutomationEnabled {
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeOperatorReady,
+			u.object.Generation, metav1.ConditionTrue, DRClusterConditionReasonNotApplicable,
+			"dr-cluster operator deployment automation is disabled")
+
+		return
+	}
+
+	version := "unknown"
+	if u.object.Status.Operator != nil && u.object.Status.Operator.InstalledVersion != "" {
+		version = u.object.Status.Operator.InstalledVersion
+	}
+
+	mw, err := u.mwUtil.FindManifestWork(util.DrClusterManifestWorkName, u.object.Name)
+	if err != nil {
+		drClusterOperatorReadySet(u.object.Name, version, false)
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeOperatorReady,
+			u.object.Generation, metav1.ConditionUnknown, DRClusterConditionReasonProbeFailed,
+			fmt.Sprintf("failed to get dr-cluster operator ManifestWork: %v", err))
+
+		return
+	}
+
+	ready := util.IsManifestInAppliedState(mw)
+
+	drClusterOperatorReadySet(u.object.Name, version, ready)
+
+	if !ready {
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeOperatorReady,
+			u.object.Generation, metav1.ConditionFalse, DRClusterConditionReasonUnhealthy,
+			fmt.Sprintf("dr-cluster operator version %s is not yet applied and available", version))
+
+		return
+	}
+
+	setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypeOperatorReady,
+		u.object.Generation, metav1.ConditionTrue, DRClusterConditionReasonHealthy,
+		fmt.Sprintf("dr-cluster operator version %s is applied and available", version))
+}
+
+// drClusterOperatorReadySet sets the operator readiness metric of the
+// DRCluster, deleting the series of the version it was last labeled with, if
+// the version changed
+func drClusterOperatorReadySet(drclusterName, version string, ready bool) {
+	drClusterHealthProbeStatesLock.Lock()
+	defer drClusterHealthProbeStatesLock.Unlock()
+
+	state := drClusterHealthProbeStateGet(drclusterName)
+	if state.operatorVersion != "" && state.operatorVersion != version {
+		drClusterOperatorReady.DeleteLabelValues(drclusterName, state.operatorVersion)
+	}
+
+	state.operatorVersion = version
+
+	drClusterOperatorReady.WithLabelValues(drclusterName, version).Set(boolToGaugeValue(ready))
+}
+
+// peersAvailabilityProbe reports whether every peer of this DRCluster, in any
+// DRPolicy that includes it, is available: its ManagedCluster, through which
+// the VRGs and VolSync destinations are managed, is available, and its S3
+// store, the PV cluster data is replicated through, is healthy as last probed
+// by the peer DRCluster. The replication endpoints, such as the storage
+// replication link or the VolSync rsync addresses, are not probed, as they are
+// not observable from the hub.
+func (u *drclusterUpdater) peersAvailabilityProbe() {
+	peers, err := drClusterPeerNames(u)
+	if err != nil {
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypePeersAvailable,
+			u.object.Generation, metav1.ConditionUnknown, DRClusterConditionReasonProbeFailed, err.Error())
+
+		return
+	}
+
+	if len(peers) == 0 {
+		drClusterPeersAvailable.WithLabelValues(u.object.Name).Set(1)
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypePeersAvailable,
+			u.object.Generation, metav1.ConditionTrue, DRClusterConditionReasonNotApplicable,
+			"no peers found in any DRPolicy")
+
+		return
+	}
+
+	unavailable := []string{}
+	unknown := []string{}
+
+	for _, peer := range peers {
+		status, message := u.peerAvailability(peer)
+
+		switch status {
+		case metav1.ConditionFalse:
+			unavailable = append(unavailable, message)
+		case metav1.ConditionUnknown:
+			unknown = append(unknown, message)
+		}
+	}
+
+	drClusterPeersAvailable.WithLabelValues(u.object.Name).Set(boolToGaugeValue(len(unavailable)+len(unknown) == 0))
+
+	switch {
+	case len(unavailable) != 0:
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypePeersAvailable,
+			u.object.Generation, metav1.ConditionFalse, DRClusterConditionReasonPeerUnavailable,
+			fmt.Sprintf("unavailable peers: %s", strings.Join(append(unavailable, unknown...), "; ")))
+	case len(unknown) != 0:
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypePeersAvailable,
+			u.object.Generation, metav1.ConditionUnknown, DRClusterConditionReasonProbeFailed,
+			fmt.Sprintf("peers of unknown availability: %s", strings.Join(unknown, "; ")))
+	default:
+		setDRClusterHealthCondition(&u.object.Status.Conditions, ramen.DRClusterConditionTypePeersAvailable,
+			u.object.Generation, metav1.ConditionTrue, DRClusterConditionReasonHealthy,
+			fmt.Sprintf("available peers: %s", strings.Join(peers, ", ")))
+	}
+}
+
+// peerAvailability returns whether the ManagedCluster of the peer is available
+// and its S3 store healthy, and a message naming the peer and the one that is
+// not
+func (u *drclusterUpdater) peerAvailability(peer string) (metav1.ConditionStatus, string) {
+	status, _, message := managedClusterAvailability(u, peer)
+	if status != metav1.ConditionTrue {
+		return status, message
+	}
+
+	drcluster := &ramen.DRCluster{}
+	if err := u.reconciler.APIReader.Get(u.ctx, types.NamespacedName{Name: peer}, drcluster); err != nil {
+		return metav1.ConditionUnknown, fmt.Sprintf("failed to get drcluster %s: %v", peer, err)
+	}
+
+	condition := findCondition(drcluster.Status.Conditions, ramen.DRClusterConditionTypeS3StoreHealthy)
+	if condition == nil {
+		return metav1.ConditionUnknown, fmt.Sprintf("s3 store of %s not probed yet", peer)
+	}
+
+	if condition.Status == metav1.ConditionFalse {
+		return metav1.ConditionFalse, fmt.Sprintf("s3 store of %s: %s", peer, condition.Message)
+	}
+
+	return metav1.ConditionTrue, ""
+}
+
+func drClusterPeerNames(u *drclusterUpdater) ([]string, error) {
+	drpolicies, err := util.GetAllDRPolicies(u.ctx, u.reconciler.APIReader)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := map[string]struct{}{}
+
+	for i := range drpolicies.Items {
+		clusters := util.DrpolicyClusterNames(&drpolicies.Items[i])
+		if !containsString(clusters, u.object.Name) {
+			continue
+		}
+
+		for _, cluster := range clusters {
+			if cluster != u.object.Name {
+				peers[cluster] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(peers))
+	for peer := range peers {
+		names = append(names, peer)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func drClusterHealthMetricsDelete(drcluster *ramen.DRCluster) {
+	drClusterManagedClusterAvailable.DeleteLabelValues(drcluster.Name)
+	drClusterS3StoreHealthy.DeleteLabelValues(drcluster.Name, drcluster.Spec.S3ProfileName)
+	drClusterS3StoreLatency.DeleteLabelValues(drcluster.Name, drcluster.Spec.S3ProfileName)
+	drClusterPeersAvailable.DeleteLabelValues(drcluster.Name)
+
+	drClusterHealthProbeStatesLock.Lock()
+	defer drClusterHealthProbeStatesLock.Unlock()
+
+	if state, ok := drClusterHealthProbeStates[drcluster.Name]; ok {
+		drClusterS3StoreHealthy.DeleteLabelValues(drcluster.Name, state.s3StoreProbeProfile)
+		drClusterS3StoreLatency.DeleteLabelValues(drcluster.Name, state.s3StoreProbeProfile)
+		drClusterOperatorReady.DeleteLabelValues(drcluster.Name, state.operatorVersion)
+		delete(drClusterHealthProbeStates, drcluster.Name)
+	}
+}
+
+// drClusterAvailable returns false only if the DRCluster has positively
+// reported that its managed cluster is not available.
+func drClusterAvailable(drcluster *ramen.DRCluster) bool {
+	condition := findCondition(drcluster.Status.Conditions, ramen.DRClusterConditionTypeManagedClusterAvailable)
+
+	return condition == nil || condition.Status != metav1.ConditionFalse
+}
+
+func setDRClusterHealthCondition(conditions *[]metav1.Condition, conditionType string, observedGeneration int64,
+	status metav1.ConditionStatus, reason, message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             status,
+		Message:            message,
+	})
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// DRCluster maintenance condition reasons
+const (
+	DRClusterConditionReasonUnderMaintenance    = "UnderMaintenance"
+	DRClusterConditionReasonNotUnderMaintenance = "NotUnderMaintenance"
+)
+
+// maintenanceStatusUpdate reports whether the DRCluster is under maintenance,
+// listing the DRPCs whose applications are currently placed on it.
+func (u *drclusterUpdater) maintenanceStatusUpdate() error {
+	if !u.object.Spec.Maintenance {
+		setDRClusterMaintenanceCondition(&u.object.Status.Conditions, u.object.Generation, metav1.ConditionFalse,
+			DRClusterConditionReasonNotUnderMaintenance, "Cluster is not under maintenance")
+
+		return nil
+	}
+
+	drpcs, err := drClusterDRPCNames(u)
+	if err != nil {
+		setDRClusterMaintenanceCondition(&u.object.Status.Conditions, u.object.Generation, metav1.ConditionTrue,
+			DRClusterConditionReasonUnderMaintenance, "Cluster is under maintenance, failed to list affected DRPCs")
+
+		return err
+	}
+
+	msg := "Cluster is under maintenance, no DRPCs affected"
+	if len(drpcs) != 0 {
+		msg = fmt.Sprintf("Cluster is under maintenance, affected DRPCs: %s", strings.Join(drpcs, ", "))
+	}
+
+	setDRClusterMaintenanceCondition(&u.object.Status.Conditions, u.object.Generation, metav1.ConditionTrue,
+		DRClusterConditionReasonUnderMaintenance, msg)
+
+	return nil
+}
+
+// drClusterDRPCNames returns the sorted namespaced names of the DRPCs whose
+// applications are currently placed on the DRCluster
+func drClusterDRPCNames(u *drclusterUpdater) ([]string, error) {
+	drpcs := &ramen.DRPlacementControlList{}
+	if err := u.reconciler.APIReader.List(u.ctx, drpcs); err != nil {
+		return nil, fmt.Errorf("drpcs list: %w", err)
+	}
+
+	names := []string{}
+
+	for i := range drpcs.Items {
+		drpc := &drpcs.Items[i]
+		if drpc.Status.PreferredDecision.ClusterName == u.object.Name {
+			names = append(names, drpc.Namespace+"/"+drpc.Name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func setDRClusterMaintenanceCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	status metav1.ConditionStatus, reason, message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeMaintenance,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             status,
+		Message:            message,
+	})
+}
+
+// drClusterUnderMaintenance returns true if the named cluster is marked as
+// under maintenance in its DRCluster
+func drClusterUnderMaintenance(drClusters []ramen.DRCluster, cluster string) bool {
+	for i := range drClusters {
+		if drClusters[i].Name == cluster {
+			return drClusters[i].Spec.Maintenance
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,538 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/blang/semver/v4"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	viewv1beta1 "github.com/stolostron/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// DRCluster operator version and upgrade condition reasons
+const (
+	DRClusterConditionReasonVersionCompatible   = "Compatible"
+	DRClusterConditionReasonVersionIncompatible = "Incompatible"
+	DRClusterConditionReasonVersionUnknown      = "VersionUnknown"
+	DRClusterConditionReasonUpgrading           = "Upgrading"
+	DRClusterConditionReasonUpgradePending      = "UpgradePending"
+	DRClusterConditionReasonUpgradeStuck        = "UpgradeStuck"
+	DRClusterConditionReasonUpToDate            = "UpToDate"
+)
+
+const (
+	// drClusterOperatorUpgradeCheckInterval is the interval at which an upgrade
+	// in progress, or one waiting for its turn, is rechecked
+	drClusterOperatorUpgradeCheckInterval = 30 * time.Second
+
+	// drClusterOperatorUpgradeLeaseDuration is how long the upgrade Lease is
+	// held without being renewed. It is renewed at every check of an upgrade
+	// in progress, hence it expires only if the holder stops upgrading.
+	drClusterOperatorUpgradeLeaseDuration = 5 * time.Minute
+
+	// drClusterOperatorUpgradeTimeout is how long a cluster may hold the
+	// upgrade Lease before its upgrade is reported stuck and the Lease is no
+	// longer renewed, letting the next cluster upgrade
+	drClusterOperatorUpgradeTimeout = 30 * time.Minute
+)
+
+// Version is the version of the hub operator. It is set at build time with
+// -ldflags "-X github.com/ramendr/ramen/controllers.Version=<version>".
+var Version = "0.0.1"
+
+const (
+	mcvTypeSubscription          = "sub"
+	mcvTypeClusterServiceVersion = "csv"
+
+	// drClusterOperatorViewLabel labels the ManagedClusterViews used to read
+	// the dr-cluster operator state from a managed cluster
+	drClusterOperatorViewLabel = "ramendr.openshift.io/drcluster-operator-view"
+
+	// drClusterOperatorUpgradeLeaseName names the hub Lease held by the
+	// DRCluster whose dr-cluster operator is being upgraded
+	drClusterOperatorUpgradeLeaseName = "ramen-dr-cluster-operator-upgrade"
+)
+
+// operatorDeploy deploys the dr-cluster operator manifests to the cluster. A
+// change of the operator ClusterServiceVersion is rolled out to one cluster at
+// a time, by the holder of the hub upgrade Lease, hence while another cluster
+// holds it the Subscription keeps the ClusterServiceVersion last deployed,
+// the other manifests are still deployed, and true is returned.
+func (u *drclusterUpdater) operatorDeploy(ramenConfig *ramen.RamenConfig) (bool, error) {
+	const deferred = true
+
+	if !ramenConfig.DrClusterOperator.DeploymentAutomationEnabled {
+		u.object.Status.Operator = nil
+
+		if err := u.operatorUpgradeLeaseRelease(); err != nil {
+			return !deferred, err
+		}
+
+		return !deferred, drClusterDeploy(u.object, &u.mwUtil, ramenConfig, "")
+	}
+
+	if u.object.Status.Operator == nil {
+		u.object.Status.Operator = &ramen.DRClusterOperatorStatus{}
+	}
+
+	operatorStatus := u.object.Status.Operator
+	targetCSV := drClusterOperatorClusterServiceVersionNameOrDefault(ramenConfig)
+
+	if operatorStatus.TargetClusterServiceVersion != "" && operatorStatus.TargetClusterServiceVersion != targetCSV {
+		upgradingCluster, err := u.operatorUpgradeLeaseAcquire()
+		if err != nil {
+			return !deferred, err
+		}
+
+		if upgradingCluster != u.object.Name {
+			u.log.Info(fmt.Sprintf("Deferring upgrade to %s while cluster %s upgrades", targetCSV, upgradingCluster))
+			setDRClusterOperatorUpgradingCondition(&u.object.Status.Conditions, u.object.Generation,
+				metav1.ConditionTrue, DRClusterConditionReasonUpgradePending,
+				fmt.Sprintf("Upgrade to %s waiting for cluster %s to complete its upgrade", targetCSV, upgradingCluster))
+
+			return deferred, drClusterDeploy(u.object, &u.mwUtil, ramenConfig,
+				operatorStatus.TargetClusterServiceVersion)
+		}
+	}
+
+	if err := drClusterDeploy(u.object, &u.mwUtil, ramenConfig, targetCSV); err != nil {
+		return !deferred, err
+	}
+
+	operatorStatus.TargetClusterServiceVersion = targetCSV
+
+	return !deferred, nil
+}
+
+// operatorVersionCheck reads the dr-cluster operator ClusterServiceVersion
+// installed on the cluster, and reports whether its version is supported by
+// the hub and whether the cluster is still upgrading to the
+// ClusterServiceVersion last deployed to it
+func (u *drclusterUpdater) operatorVersionCheck(ramenConfig *ramen.RamenConfig, deferred bool) {
+	if !ramenConfig.DrClusterOperator.DeploymentAutomationEnabled {
+		msg := "dr-cluster operator deployment automation disabled"
+		setDRClusterOperatorVersionCompatibleCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionTrue, DRClusterConditionReasonNotApplicable, msg)
+		setDRClusterOperatorUpgradingCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionFalse, DRClusterConditionReasonNotApplicable, msg)
+
+		return
+	}
+
+	operatorStatus := u.object.Status.Operator
+
+	csv, err := u.operatorInstalledClusterServiceVersion(ramenConfig)
+	if err != nil {
+		u.log.Info("dr-cluster operator installed version unknown", "error", err.Error())
+
+		msg := "Failed to read the installed dr-cluster operator version"
+		setDRClusterOperatorVersionCompatibleCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionUnknown, DRClusterConditionReasonVersionUnknown, msg)
+
+		if !deferred {
+			setDRClusterOperatorUpgradingCondition(&u.object.Status.Conditions, u.object.Generation,
+				metav1.ConditionUnknown, DRClusterConditionReasonVersionUnknown, msg)
+		}
+
+		return
+	}
+
+	if operatorStatus.InstalledClusterServiceVersion != "" &&
+		operatorStatus.InstalledClusterServiceVersion != csv.Name {
+		// The view of the previously installed ClusterServiceVersion is no longer needed
+		if err := u.operatorViewDelete(BuildManagedClusterViewName(operatorStatus.InstalledClusterServiceVersion,
+			drClusterOperatorNamespaceNameOrDefault(ramenConfig), mcvTypeClusterServiceVersion)); err != nil {
+			u.log.Info("Failed to delete stale ClusterServiceVersion view", "error", err.Error())
+		}
+	}
+
+	operatorStatus.InstalledClusterServiceVersion = csv.Name
+	operatorStatus.InstalledVersion = csv.Spec.Version.String()
+
+	u.operatorVersionCompatibilityUpdate(ramenConfig, csv)
+
+	if deferred {
+		return
+	}
+
+	if csv.Name == operatorStatus.TargetClusterServiceVersion &&
+		csv.Status.Phase == operatorsv1alpha1.CSVPhaseSucceeded {
+		setDRClusterOperatorUpgradingCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionFalse, DRClusterConditionReasonUpToDate, "dr-cluster operator is up to date")
+
+		// Let the next cluster upgrade
+		if err := u.operatorUpgradeLeaseRelease(); err != nil {
+			u.log.Info("Failed to release the upgrade lease", "error", err.Error())
+		}
+
+		return
+	}
+
+	if u.operatorUpgradeStuck() {
+		setDRClusterOperatorUpgradingCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionTrue, DRClusterConditionReasonUpgradeStuck,
+			fmt.Sprintf("Upgrade to %s not completed within %v, installed %s is in phase %q",
+				operatorStatus.TargetClusterServiceVersion, drClusterOperatorUpgradeTimeout, csv.Name, csv.Status.Phase))
+
+		return
+	}
+
+	setDRClusterOperatorUpgradingCondition(&u.object.Status.Conditions, u.object.Generation,
+		metav1.ConditionTrue, DRClusterConditionReasonUpgrading,
+		fmt.Sprintf("Upgrading to %s", operatorStatus.TargetClusterServiceVersion))
+}
+
+// operatorUpgradeStuck returns whether the upgrade of the cluster has not
+// completed within the upgrade timeout. Until then, the upgrade Lease is
+// renewed if the cluster holds it. Once stuck, the Lease is left to expire so
+// that the next cluster may upgrade, and the upgrade stays reported stuck
+// until it completes or the cluster takes the Lease again.
+func (u *drclusterUpdater) operatorUpgradeStuck() bool {
+	lease, err := u.operatorUpgradeLeaseGet()
+	if err != nil {
+		u.log.Info("Failed to read the upgrade lease", "error", err.Error())
+	}
+
+	if lease != nil && operatorUpgradeLeaseHeldBy(lease, u.object.Name) {
+		if lease.Spec.AcquireTime != nil && time.Since(lease.Spec.AcquireTime.Time) > drClusterOperatorUpgradeTimeout {
+			return true
+		}
+
+		if err := u.operatorUpgradeLeaseRenew(lease); err != nil {
+			u.log.Info("Failed to renew the upgrade lease", "error", err.Error())
+		}
+
+		return false
+	}
+
+	condition := findCondition(u.object.Status.Conditions, ramen.DRClusterConditionTypeOperatorUpgrading)
+
+	return condition != nil && condition.Status == metav1.ConditionTrue &&
+		condition.Reason == DRClusterConditionReasonUpgradeStuck
+}
+
+func (u *drclusterUpdater) operatorVersionCompatibilityUpdate(ramenConfig *ramen.RamenConfig,
+	csv *operatorsv1alpha1.ClusterServiceVersion,
+) {
+	installedVersion := csv.Spec.Version.Version
+
+	supportedRange, err := operatorSupportedVersionRange(ramenConfig)
+	if err != nil {
+		setDRClusterOperatorVersionCompatibleCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionUnknown, DRClusterConditionReasonVersionUnknown, err.Error())
+
+		return
+	}
+
+	isSupported, err := semver.ParseRange(supportedRange)
+	if err != nil {
+		setDRClusterOperatorVersionCompatibleCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionUnknown, DRClusterConditionReasonVersionUnknown,
+			fmt.Sprintf("Invalid supported version range %q: %v", supportedRange, err))
+
+		return
+	}
+
+	if !isSupported(installedVersion) {
+		setDRClusterOperatorVersionCompatibleCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionFalse, DRClusterConditionReasonVersionIncompatible,
+			fmt.Sprintf("Installed version %s is not within the supported range %q", installedVersion, supportedRange))
+
+		return
+	}
+
+	setDRClusterOperatorVersionCompatibleCondition(&u.object.Status.Conditions, u.object.Generation,
+		metav1.ConditionTrue, DRClusterConditionReasonVersionCompatible,
+		fmt.Sprintf("Installed version %s is within the supported range %q", installedVersion, supportedRange))
+}
+
+// operatorInstalledClusterServiceVersion returns the ClusterServiceVersion the
+// dr-cluster operator Subscription reports as installed on the cluster
+func (u *drclusterUpdater) operatorInstalledClusterServiceVersion(
+	ramenConfig *ramen.RamenConfig,
+) (*operatorsv1alpha1.ClusterServiceVersion, error) {
+	namespaceName := drClusterOperatorNamespaceNameOrDefault(ramenConfig)
+
+	subscription, err := u.reconciler.MCVGetter.GetSubscriptionFromManagedCluster(
+		drClusterOperatorSubscriptionName, namespaceName, u.object.Name)
+	if err != nil {
+		return nil, fmt.Errorf("subscription get: %w", err)
+	}
+
+	if subscription.Status.InstalledCSV == "" {
+		return nil, fmt.Errorf("subscription %s reports no installed ClusterServiceVersion",
+			drClusterOperatorSubscriptionName)
+	}
+
+	csv, err := u.reconciler.MCVGetter.GetClusterServiceVersionFromManagedCluster(
+		subscription.Status.InstalledCSV, namespaceName, u.object.Name)
+	if err != nil {
+		return nil, fmt.Errorf("clusterserviceversion %s get: %w", subscription.Status.InstalledCSV, err)
+	}
+
+	return csv, nil
+}
+
+// operatorSupportedVersionRange returns the configured range of dr-cluster
+// operator versions supported by the hub, or a default range derived from the
+// hub operator version: the same major version, and a minor version at most
+// one older than and no newer than the hub's
+func operatorSupportedVersionRange(ramenConfig *ramen.RamenConfig) (string, error) {
+	if ramenConfig.DrClusterOperator.SupportedVersionRange != "" {
+		return ramenConfig.DrClusterOperator.SupportedVersionRange, nil
+	}
+
+	hubVersion, err := semver.ParseTolerant(Version)
+	if err != nil {
+		return "", fmt.Errorf("hub operator version %q parse: %w", Version, err)
+	}
+
+	minMinor := hubVersion.Minor
+	if minMinor > 0 {
+		minMinor--
+	}
+
+	return fmt.Sprintf(">=%d.%d.0 <%d.%d.0", hubVersion.Major, minMinor, hubVersion.Major, hubVersion.Minor+1), nil
+}
+
+// operatorUpgradeLeaseAcquire takes the hub upgrade Lease for the DRCluster,
+// unless another existing DRCluster holds it and has renewed it within its
+// duration, and returns the name of the holder. Lease updates are conditional
+// on its resource version, hence two DRClusters cannot both take it.
+func (u *drclusterUpdater) operatorUpgradeLeaseAcquire() (string, error) {
+	holder := u.object.Name
+	now := metav1.NewMicroTime(time.Now())
+	leaseDurationSeconds := int32(drClusterOperatorUpgradeLeaseDuration.Seconds())
+
+	lease, err := u.operatorUpgradeLeaseGet()
+	if err != nil {
+		return "", err
+	}
+
+	if lease == nil {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: NamespaceName(), Name: drClusterOperatorUpgradeLeaseName},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &holder,
+				LeaseDurationSeconds: &leaseDurationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+
+		if err := u.client.Create(u.ctx, lease); err != nil {
+			return "", fmt.Errorf("lease create: %w", err)
+		}
+
+		return holder, nil
+	}
+
+	if operatorUpgradeLeaseHeldBy(lease, holder) {
+		return holder, u.operatorUpgradeLeaseRenew(lease)
+	}
+
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" &&
+		!operatorUpgradeLeaseExpired(lease) {
+		// The lease of a deleted DRCluster is taken over
+		drcluster := &ramen.DRCluster{}
+
+		err := u.reconciler.APIReader.Get(u.ctx, types.NamespacedName{Name: *lease.Spec.HolderIdentity}, drcluster)
+		if err == nil {
+			return *lease.Spec.HolderIdentity, nil
+		}
+
+		if !k8serrors.IsNotFound(err) {
+			return "", fmt.Errorf("drcluster %s get: %w", *lease.Spec.HolderIdentity, err)
+		}
+	}
+
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" {
+		u.log.Info("Taking over the upgrade lease", "holder", *lease.Spec.HolderIdentity)
+	}
+
+	lease.Spec.HolderIdentity = &holder
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+
+	if err := u.client.Update(u.ctx, lease); err != nil {
+		return "", fmt.Errorf("lease update: %w", err)
+	}
+
+	return holder, nil
+}
+
+// operatorUpgradeLeaseRenew renews the hub upgrade Lease held by the DRCluster
+func (u *drclusterUpdater) operatorUpgradeLeaseRenew(lease *coordinationv1.Lease) error {
+	now := metav1.NewMicroTime(time.Now())
+	leaseDurationSeconds := int32(drClusterOperatorUpgradeLeaseDuration.Seconds())
+
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.RenewTime = &now
+
+	if err := u.client.Update(u.ctx, lease); err != nil {
+		return fmt.Errorf("lease update: %w", err)
+	}
+
+	return nil
+}
+
+// operatorUpgradeLeaseRelease releases the hub upgrade Lease, if the DRCluster
+// holds it
+func (u *drclusterUpdater) operatorUpgradeLeaseRelease() error {
+	lease, err := u.operatorUpgradeLeaseGet()
+	if err != nil {
+		return err
+	}
+
+	if lease == nil || !operatorUpgradeLeaseHeldBy(lease, u.object.Name) {
+		return nil
+	}
+
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.AcquireTime = nil
+	lease.Spec.RenewTime = nil
+
+	if err := u.client.Update(u.ctx, lease); err != nil {
+		return fmt.Errorf("lease update: %w", err)
+	}
+
+	return nil
+}
+
+// operatorUpgradeLeaseGet returns the hub upgrade Lease, or nil if it does not
+// exist
+func (u *drclusterUpdater) operatorUpgradeLeaseGet() (*coordinationv1.Lease, error) {
+	lease := &coordinationv1.Lease{}
+
+	err := u.reconciler.APIReader.Get(u.ctx,
+		types.NamespacedName{Namespace: NamespaceName(), Name: drClusterOperatorUpgradeLeaseName}, lease)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("lease get: %w", err)
+	}
+
+	return lease, nil
+}
+
+func operatorUpgradeLeaseHeldBy(lease *coordinationv1.Lease, holder string) bool {
+	return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == holder
+}
+
+// operatorUpgradeLeaseExpired returns whether the hub upgrade Lease has not
+// been renewed within its duration
+func operatorUpgradeLeaseExpired(lease *coordinationv1.Lease) bool {
+	renewTime := lease.Spec.RenewTime
+	if renewTime == nil {
+		renewTime = lease.Spec.AcquireTime
+	}
+
+	if renewTime == nil {
+		return true
+	}
+
+	leaseDuration := drClusterOperatorUpgradeLeaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		leaseDuration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+
+	return time.Since(renewTime.Time) > leaseDuration
+}
+
+func (u *drclusterUpdater) operatorViewDelete(name string) error {
+	mcv := &viewv1beta1.ManagedClusterView{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: u.object.Name},
+	}
+
+	if err := u.client.Delete(u.ctx, mcv); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("managedclusterview %s delete: %w", name, err)
+	}
+
+	return nil
+}
+
+// operatorViewsDelete deletes the ManagedClusterViews used to read the
+// dr-cluster operator state from the cluster
+func (u *drclusterUpdater) operatorViewsDelete() error {
+	mcvs := &viewv1beta1.ManagedClusterViewList{}
+	if err := u.client.List(u.ctx, mcvs, client.InNamespace(u.object.Name),
+		client.HasLabels{drClusterOperatorViewLabel}); err != nil {
+		return fmt.Errorf("managedclusterviews list: %w", err)
+	}
+
+	for i := range mcvs.Items {
+		if err := u.operatorViewDelete(mcvs.Items[i].Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setDRClusterOperatorVersionCompatibleCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	status metav1.ConditionStatus, reason, message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeOperatorVersionCompatible,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             status,
+		Message:            message,
+	})
+}
+
+func setDRClusterOperatorUpgradingCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	status metav1.ConditionStatus, reason, message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               ramen.DRClusterConditionTypeOperatorUpgrading,
+		Reason:             reason,
+		ObservedGeneration: observedGeneration,
+		Status:             status,
+		Message:            message,
+	})
+}
+
+// drClusterUpgrading returns true if the dr-cluster operator of the named
+// cluster is being installed or upgraded, including an upgrade reported stuck
+func drClusterUpgrading(drClusters []ramen.DRCluster, cluster string) bool {
+	for i := range drClusters {
+		if drClusters[i].Name != cluster {
+			continue
+		}
+
+		condition := findCondition(drClusters[i].Status.Conditions, ramen.DRClusterConditionTypeOperatorUpgrading)
+
+		return condition != nil && condition.Status == metav1.ConditionTrue &&
+			(condition.Reason == DRClusterConditionReasonUpgrading ||
+				condition.Reason == DRClusterConditionReasonUpgradeStuck)
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramen "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+var _ = Describe("DRCluster operator upgrade lease", func() {
+	const (
+		namespaceName = "ramen-system"
+		holderCluster = "east"
+		otherCluster  = "west"
+	)
+
+	var (
+		k8sClient        client.Client
+		podNamespaceName string
+	)
+
+	updater := func(name string) *drclusterUpdater {
+		drcluster := &ramen.DRCluster{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: name}, drcluster)).To(Succeed())
+
+		return &drclusterUpdater{
+			ctx:        context.TODO(),
+			object:     drcluster,
+			client:     k8sClient,
+			log:        logr.Discard(),
+			reconciler: &DRClusterReconciler{Client: k8sClient, APIReader: k8sClient},
+		}
+	}
+
+	getLease := func() *coordinationv1.Lease {
+		lease := &coordinationv1.Lease{}
+		Expect(k8sClient.Get(context.TODO(),
+			types.NamespacedName{Namespace: namespaceName, Name: drClusterOperatorUpgradeLeaseName}, lease)).
+			To(Succeed())
+
+		return lease
+	}
+
+	ageLease := func(age time.Duration) {
+		lease := getLease()
+		past := metav1.NewMicroTime(time.Now().Add(-age))
+		lease.Spec.AcquireTime = &past
+		lease.Spec.RenewTime = &past
+		Expect(k8sClient.Update(context.TODO(), lease)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		podNamespaceName = os.Getenv("POD_NAMESPACE")
+		Expect(os.Setenv("POD_NAMESPACE", namespaceName)).To(Succeed())
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(ramen.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: holderCluster}},
+			&ramen.DRCluster{ObjectMeta: metav1.ObjectMeta{Name: otherCluster}},
+		).Build()
+
+		holder, err := updater(holderCluster).operatorUpgradeLeaseAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(holder).To(Equal(holderCluster))
+	})
+
+	AfterEach(func() {
+		Expect(os.Setenv("POD_NAMESPACE", podNamespaceName)).To(Succeed())
+	})
+
+	It("Should be held for its duration, and renewed by its holder", func() {
+		lease := getLease()
+		Expect(*lease.Spec.LeaseDurationSeconds).To(
+			Equal(int32(drClusterOperatorUpgradeLeaseDuration.Seconds())))
+		Expect(lease.Spec.RenewTime).NotTo(BeNil())
+
+		holder, err := updater(otherCluster).operatorUpgradeLeaseAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(holder).To(Equal(holderCluster))
+
+		ageLease(time.Minute)
+		Expect(updater(holderCluster).operatorUpgradeStuck()).To(BeFalse())
+		Expect(getLease().Spec.RenewTime.Time).To(BeTemporally("~", time.Now(), 10*time.Second))
+	})
+
+	It("Should be taken over once expired", func() {
+		ageLease(2 * drClusterOperatorUpgradeLeaseDuration)
+
+		holder, err := updater(otherCluster).operatorUpgradeLeaseAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(holder).To(Equal(otherCluster))
+		Expect(*getLease().Spec.HolderIdentity).To(Equal(otherCluster))
+	})
+
+	It("Should report the upgrade of its holder stuck, and no longer be renewed", func() {
+		ageLease(2 * drClusterOperatorUpgradeTimeout)
+
+		u := updater(holderCluster)
+		Expect(u.operatorUpgradeStuck()).To(BeTrue())
+		Expect(getLease().Spec.RenewTime.Time).To(
+			BeTemporally("<", time.Now().Add(-drClusterOperatorUpgradeTimeout)))
+
+		holder, err := updater(otherCluster).operatorUpgradeLeaseAcquire()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(holder).To(Equal(otherCluster))
+
+		setDRClusterOperatorUpgradingCondition(&u.object.Status.Conditions, u.object.Generation,
+			metav1.ConditionTrue, DRClusterConditionReasonUpgradeStuck, "stuck")
+		Expect(u.operatorUpgradeStuck()).To(BeTrue())
+		Expect(drClusterUpgrading([]ramen.DRCluster{*u.object}, holderCluster)).To(BeTrue())
+	})
+})
@@ -28,13 +28,17 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-func drClusterDeploy(drcluster *rmn.DRCluster, mwu *util.MWUtil, ramenConfig *rmn.RamenConfig) error {
+// drClusterDeploy deploys the dr-cluster operator manifests to the cluster,
+// subscribing to the named ClusterServiceVersion of the operator
+func drClusterDeploy(drcluster *rmn.DRCluster, mwu *util.MWUtil, ramenConfig *rmn.RamenConfig,
+	clusterServiceVersionName string,
+) error {
 	objects := []interface{}{}
 
 	if ramenConfig.DrClusterOperator.DeploymentAutomationEnabled {
 		var err error
 
-		objects, err = objectsToDeploy(ramenConfig)
+		objects, err = objectsToDeploy(ramenConfig, clusterServiceVersionName)
 		if err != nil {
 			return err
 		}
@@ -55,7 +59,7 @@ var olmClusterRole = &rbacv1.ClusterRole{
 	},
 }
 
-func objectsToDeploy(hubOperatorRamenConfig *rmn.RamenConfig) ([]interface{}, error) {
+func objectsToDeploy(hubOperatorRamenConfig *rmn.RamenConfig, clusterServiceVersionName string) ([]interface{}, error) {
 	objects := []interface{}{}
 
 	drClusterOperatorRamenConfig := *hubOperatorRamenConfig
@@ -84,7 +88,7 @@ func objectsToDeploy(hubOperatorRamenConfig *rmn.RamenConfig) ([]interface{}, er
 			drClusterOperatorPackageNameOrDefault(ramenConfig),
 			drClusterOperatorCatalogSourceNameOrDefault(ramenConfig),
 			drClusterOperatorCatalogSourceNamespaceNameOrDefault(ramenConfig),
-			drClusterOperatorClusterServiceVersionNameOrDefault(ramenConfig),
+			clusterServiceVersionName,
 		),
 		drClusterOperatorConfigMap,
 	), nil
@@ -129,7 +133,7 @@ func subscription(
 ) *operatorsv1alpha1.Subscription {
 	return &operatorsv1alpha1.Subscription{
 		TypeMeta:   metav1.TypeMeta{Kind: "Subscription", APIVersion: "operators.coreos.com/v1alpha1"},
-		ObjectMeta: metav1.ObjectMeta{Name: "ramen-dr-cluster-subscription", Namespace: namespaceName},
+		ObjectMeta: metav1.ObjectMeta{Name: drClusterOperatorSubscriptionName, Namespace: namespaceName},
 		Spec: &operatorsv1alpha1.SubscriptionSpec{
 			CatalogSource:          catalogSourceName,
 			CatalogSourceNamespace: catalogSourceNamespaceName,
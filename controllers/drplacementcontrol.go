@@ -30,9 +30,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	rmnutil "github.com/ramendr/ramen/controllers/util"
+	"github.com/ramendr/ramen/controllers/volsync"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -56,6 +58,7 @@ type DRPCInstance struct {
 	drClusters           []rmn.DRCluster
 	mcvRequestInProgress bool
 	volSyncDisabled      bool
+	volSyncKeyRotation   time.Duration
 	userPlacementRule    *plrv1.PlacementRule
 	drpcPlacementRule    *plrv1.PlacementRule
 	vrgs                 map[string]*rmn.VolumeReplicationGroup
@@ -92,6 +95,8 @@ func (d *DRPCInstance) startProcessing() bool {
 func (d *DRPCInstance) processPlacement() (bool, error) {
 	d.log.Info("Process DRPC Placement", "DRAction", d.instance.Spec.Action)
 
+	d.updateVolSyncRecoveryPoints()
+
 	switch d.instance.Spec.Action {
 	case rmn.ActionFailover:
 		return d.RunFailover()
@@ -138,6 +143,17 @@ func (d *DRPCInstance) RunInitialDeployment() (bool, error) {
 		return done, nil
 	}
 
+	// Do not place new deployments on a cluster under maintenance, being drained or upgrading
+	if reason := drClusterOutOfServiceReason(d.drClusters, homeCluster); !deployed && reason != "" {
+		err := fmt.Errorf("cluster %s is %s, not placing new deployments on it", homeCluster, reason)
+		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonDeployFail, err.Error())
+
+		return !done, err
+	}
+
 	// Ensure that initial deployment is complete
 	if !deployed || !d.isUserPlRuleUpdated(homeCluster) {
 		_, err := d.startDeploying(homeCluster, homeClusterNamespace)
@@ -158,7 +174,22 @@ func (d *DRPCInstance) RunInitialDeployment() (bool, error) {
 	}
 
 	// If we get here, the deployment is successful
-	err := d.EnsureVolSyncReplicationSetup(homeCluster)
+	err := d.updateVRGSuspended(homeCluster)
+	if err != nil {
+		return !done, err
+	}
+
+	err = d.updatePeerVRGsWarmStandby(homeCluster)
+	if err != nil {
+		return !done, err
+	}
+
+	err = d.updateVRGsVolSyncMover()
+	if err != nil {
+		return !done, err
+	}
+
+	err = d.EnsureVolSyncReplicationSetup(homeCluster)
 	if err != nil {
 		return !done, err
 	}
@@ -314,10 +345,15 @@ func (d *DRPCInstance) RunFailover() (bool, error) {
 	d.log.Info("Entering RunFailover", "state", d.getLastDRState())
 
 	if !d.isFailingOverOrFailedOver() {
+		if err := d.ensureActionNotSuspended(rmn.ActionFailover); err != nil {
+			return false, err
+		}
+
 		d.instance.Status.ActionStartTime = &metav1.Time{Time: time.Now()}
 		d.instance.Status.ActionDuration = nil
 		d.setDRState(rmn.Initiating)
 		d.setProgression("")
+		d.recordFailoverDataLossWindow()
 	}
 
 	const done = true
@@ -355,6 +391,22 @@ func (d *DRPCInstance) RunFailover() (bool, error) {
 		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
 			metav1.ConditionTrue, string(d.instance.Status.Phase), "Completed")
 
+		if err := d.updateVRGSuspended(d.instance.Spec.FailoverCluster); err != nil {
+			return !done, err
+		}
+
+		if err := d.clearVRGFinalSync(d.instance.Spec.FailoverCluster); err != nil {
+			return !done, err
+		}
+
+		if err := d.updatePeerVRGsWarmStandby(d.instance.Spec.FailoverCluster); err != nil {
+			return !done, err
+		}
+
+		if err := d.updateVRGsVolSyncMover(); err != nil {
+			return !done, err
+		}
+
 		// Make sure VolRep 'Data' and VolSync 'setup' conditions are ready
 		ready := d.checkReadinessAfterFailover(d.instance.Spec.FailoverCluster)
 		if !ready {
@@ -412,6 +464,18 @@ func (d *DRPCInstance) checkClusterFenced(cluster string, drClusters []rmn.DRClu
 	return false, fmt.Errorf("failed to get the fencing status for the cluster %s", cluster)
 }
 
+// isClusterAvailable returns false if the DRCluster of the given cluster reports its
+// managed cluster as not available
+func (d *DRPCInstance) isClusterAvailable(cluster string) bool {
+	for i := range d.drClusters {
+		if d.drClusters[i].Name == cluster {
+			return drClusterAvailable(&d.drClusters[i])
+		}
+	}
+
+	return true
+}
+
 func (d *DRPCInstance) switchToFailoverCluster() (bool, error) {
 	const done = true
 	// Make sure we record the state that we are failing over
@@ -439,7 +503,28 @@ func (d *DRPCInstance) switchToFailoverCluster() (bool, error) {
 		return done, err
 	}
 
-	if isMetroAction(d.drPolicy, d.drClusters, curHomeCluster, d.instance.Spec.FailoverCluster) {
+	if reason := drClusterOutOfServiceReason(d.drClusters, d.instance.Spec.FailoverCluster); reason != "" {
+		err := fmt.Errorf("failover cluster %s is %s, refusing to fail over to it",
+			d.instance.Spec.FailoverCluster, reason)
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonSwitchFailed, err.Error())
+
+		return !done, err
+	}
+
+	if !d.isClusterAvailable(d.instance.Spec.FailoverCluster) {
+		err := fmt.Errorf("failover cluster %s is reported unavailable by its DRCluster",
+			d.instance.Spec.FailoverCluster)
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonSwitchFailed, err.Error())
+
+		return !done, err
+	}
+
+	// Fencing is skipped for a cluster under maintenance, whose DRCluster asserts it no longer writes to the
+	// replicated volumes
+	if isMetroAction(d.drPolicy, d.drClusters, curHomeCluster, d.instance.Spec.FailoverCluster) &&
+		!drClusterUnderMaintenance(d.drClusters, curHomeCluster) {
 		fenced, err := d.checkClusterFenced(curHomeCluster, d.drClusters)
 		if err != nil {
 			return !done, err
@@ -450,6 +535,20 @@ func (d *DRPCInstance) switchToFailoverCluster() (bool, error) {
 		}
 	}
 
+	if err := d.validateVolSyncFailoverCluster(); err != nil {
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonSwitchFailed, err.Error())
+
+		return !done, err
+	}
+
+	if err := d.validateFailoverRecoveryPointTime(); err != nil {
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonSwitchFailed, err.Error())
+
+		return !done, err
+	}
+
 	newHomeCluster := d.instance.Spec.FailoverCluster
 
 	const restorePVs = true
@@ -511,6 +610,10 @@ func (d *DRPCInstance) RunRelocate() (bool, error) { //nolint:gocognit,cyclop
 	d.log.Info("Entering RunRelocate", "state", d.getLastDRState(), "progression", d.getProgression())
 
 	if !d.isRelocatingOrRelocated() {
+		if err := d.ensureActionNotSuspended(rmn.ActionRelocate); err != nil {
+			return false, err
+		}
+
 		d.instance.Status.ActionStartTime = &metav1.Time{Time: time.Now()}
 		d.instance.Status.ActionDuration = nil
 		d.setDRState(rmn.Initiating)
@@ -543,6 +646,26 @@ func (d *DRPCInstance) RunRelocate() (bool, error) { //nolint:gocognit,cyclop
 			return !done, err
 		}
 
+		err = d.updateVRGSuspended(preferredCluster)
+		if err != nil {
+			return !done, err
+		}
+
+		err = d.clearVRGFinalSync(preferredCluster)
+		if err != nil {
+			return !done, err
+		}
+
+		err = d.updatePeerVRGsWarmStandby(preferredCluster)
+		if err != nil {
+			return !done, err
+		}
+
+		err = d.updateVRGsVolSyncMover()
+		if err != nil {
+			return !done, err
+		}
+
 		d.setProgression("Competed")
 
 		if d.instance.Status.ActionDuration == nil {
@@ -555,6 +678,30 @@ func (d *DRPCInstance) RunRelocate() (bool, error) { //nolint:gocognit,cyclop
 		return done, nil
 	}
 
+	// Refuse to relocate to a cluster under maintenance, being drained or upgrading
+	if reason := drClusterOutOfServiceReason(d.drClusters, preferredCluster); reason != "" {
+		err := fmt.Errorf("preferred cluster %s is %s, refusing to relocate to it", preferredCluster, reason)
+		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonSwitchFailed, err.Error())
+
+		return !done, err
+	}
+
+	// Relocation is paused while the current primary upgrades its dr-cluster operator
+	if curHomeCluster != "" && curHomeCluster != preferredCluster &&
+		drClusterUpgrading(d.drClusters, curHomeCluster) {
+		err := fmt.Errorf("current home cluster %s is upgrading its dr-cluster operator, "+
+			"refusing to relocate from it", curHomeCluster)
+		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+			d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
+		rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonSwitchFailed, err.Error())
+
+		return !done, err
+	}
+
 	// Check if current primary (that is not the preferred cluster), is ready to switch over
 	if curHomeCluster != "" && curHomeCluster != preferredCluster &&
 		!d.readyToSwitchOver(curHomeCluster, preferredCluster) {
@@ -801,6 +948,12 @@ func (d *DRPCInstance) readyToSwitchOver(homeCluster string, preferredCluster st
 			return false
 		}
 	}
+	if !d.isClusterAvailable(preferredCluster) {
+		d.log.Info(fmt.Sprintf("Cluster %s is reported unavailable", preferredCluster))
+
+		return false
+	}
+
 	// Allow switch over when PV data is protected and the cluster data is protected
 	return d.isVRGConditionMet(homeCluster, VRGConditionTypeDataReady) &&
 		d.isVRGConditionMet(homeCluster, VRGConditionTypeClusterDataProtected)
@@ -969,7 +1122,7 @@ func (d *DRPCInstance) createVRGManifestWorkAsPrimary(targetCluster string) (boo
 		if vrg.Spec.ReplicationState == rmn.Primary {
 			d.log.Info("VRG MW already Primary on this cluster", "name", vrg.Name, "cluster", targetCluster)
 
-			return false, nil
+			return false, d.updateVRGPVRestorePolicy(targetCluster, vrg)
 		}
 
 		_, err := d.updateVRGState(targetCluster, rmn.Primary)
@@ -990,6 +1143,158 @@ func (d *DRPCInstance) createVRGManifestWorkAsPrimary(targetCluster string) (boo
 	return true, nil
 }
 
+// updateVRGPVRestorePolicy updates the PV restore policy of the primary VRG
+// to that of the DRPC, letting a PV restore stalled by conflicts or errors
+// complete once the policy is changed
+func (d *DRPCInstance) updateVRGPVRestorePolicy(clusterName string, vrg *rmn.VolumeReplicationGroup) error {
+	if vrg.Spec.PVRestore == d.instance.Spec.PVRestore {
+		return nil
+	}
+
+	d.log.Info("Updating VRG PV restore policy", "cluster", clusterName, "policy", d.instance.Spec.PVRestore)
+
+	vrg.Spec.PVRestore = d.instance.Spec.PVRestore
+
+	return d.updateManifestWork(clusterName, vrg)
+}
+
+// updateVRGSuspended suspends or resumes the replication of the primary VRG,
+// as set in the DRPC. A VRG without a ManifestWork is left as it is.
+func (d *DRPCInstance) updateVRGSuspended(clusterName string) error {
+	vrg, err := d.getVRGFromManifestWork(clusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to update VRG suspended state. ClusterName %s (%w)", clusterName, err)
+	}
+
+	if vrg.Spec.Suspended == d.instance.Spec.Suspended {
+		return nil
+	}
+
+	d.log.Info("Updating VRG suspended state", "cluster", clusterName, "suspended", d.instance.Spec.Suspended)
+
+	vrg.Spec.Suspended = d.instance.Spec.Suspended
+
+	return d.updateManifestWork(clusterName, vrg)
+}
+
+// clearVRGFinalSync turns off the final sync flags of the primary VRG, left
+// set by a relocation that was aborted by failing over or relocating back to
+// its cluster, so that the VRG restores the workloads it scaled down for the
+// final sync. A VRG without a ManifestWork is left as it is.
+func (d *DRPCInstance) clearVRGFinalSync(clusterName string) error {
+	vrg, err := d.getVRGFromManifestWork(clusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to clear VRG final sync. ClusterName %s (%w)", clusterName, err)
+	}
+
+	if !vrg.Spec.PrepareForFinalSync && !vrg.Spec.RunFinalSync {
+		return nil
+	}
+
+	d.log.Info("Clearing VRG final sync of an aborted relocation", "cluster", clusterName)
+
+	vrg.Spec.PrepareForFinalSync = false
+	vrg.Spec.RunFinalSync = false
+
+	return d.updateManifestWork(clusterName, vrg)
+}
+
+// updatePeerVRGsWarmStandby passes the warm standby setting of the DRPC in to
+// the VRGs of the clusters other than the home cluster
+func (d *DRPCInstance) updatePeerVRGsWarmStandby(homeCluster string) error {
+	for _, clusterName := range rmnutil.DrpolicyClusterNames(d.drPolicy) {
+		if clusterName == homeCluster {
+			continue
+		}
+
+		vrg, err := d.getVRGFromManifestWork(clusterName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return fmt.Errorf("failed to update VRG warm standby. ClusterName %s (%w)", clusterName, err)
+		}
+
+		if vrg.Spec.WarmStandby == d.instance.Spec.WarmStandby {
+			continue
+		}
+
+		d.log.Info("Updating VRG warm standby", "cluster", clusterName, "warmStandby", d.instance.Spec.WarmStandby)
+
+		vrg.Spec.WarmStandby = d.instance.Spec.WarmStandby
+
+		if err := d.updateManifestWork(clusterName, vrg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateVRGsVolSyncMover passes the VolSync mover and profile of the DRPolicy
+// in to the VRGs of all its clusters, so that existing VRGs replicate with the
+// mover, and connect the clusters as per the profile, the DRPolicy is changed
+// to
+func (d *DRPCInstance) updateVRGsVolSyncMover() error {
+	for _, clusterName := range rmnutil.DrpolicyClusterNames(d.drPolicy) {
+		vrg, err := d.getVRGFromManifestWork(clusterName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return fmt.Errorf("failed to update VRG VolSync mover. ClusterName %s (%w)", clusterName, err)
+		}
+
+		if vrg.Spec.VolSync.Mover == d.drPolicy.Spec.VolSyncMover &&
+			reflect.DeepEqual(vrg.Spec.VolSync.Profile, d.drPolicy.Spec.VolSyncProfile) {
+			continue
+		}
+
+		d.log.Info("Updating VRG VolSync mover", "cluster", clusterName, "mover", d.drPolicy.Spec.VolSyncMover)
+
+		vrg.Spec.VolSync.Mover = d.drPolicy.Spec.VolSyncMover
+		vrg.Spec.VolSync.Profile = d.drPolicy.Spec.VolSyncProfile
+
+		if err := d.updateManifestWork(clusterName, vrg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureActionNotSuspended refuses to start a failover or relocate while the
+// replication is suspended, unless that action is forced
+func (d *DRPCInstance) ensureActionNotSuspended(action rmn.DRAction) error {
+	if !d.instance.Spec.Suspended {
+		return nil
+	}
+
+	if d.instance.Spec.ForceAction == action {
+		d.log.Info("Replication is suspended, forcing action", "action", action)
+
+		return nil
+	}
+
+	err := fmt.Errorf("replication is suspended, not starting %s unless forceAction is set to it", action)
+	d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionAvailable, d.instance.Generation,
+		d.getConditionStatusForTypeAvailable(), string(d.instance.Status.Phase), err.Error())
+	rmnutil.ReportIfNotPresent(d.reconciler.eventRecorder, d.instance, corev1.EventTypeWarning,
+		rmnutil.EventReasonSwitchFailed, err.Error())
+
+	return err
+}
+
 func (d *DRPCInstance) getVRGFromManifestWork(clusterName string) (*rmn.VolumeReplicationGroup, error) {
 	vrgMWName := d.mwu.BuildManifestWorkName(rmnutil.MWTypeVRG)
 
@@ -1053,6 +1358,11 @@ func (d *DRPCInstance) moveVRGToSecondaryOnPeers(clusterToSkip string) error {
 			continue
 		}
 
+		// Peers being drained have their VRG removed instead, see EnsureCleanup
+		if drClusterDraining(d.drClusters, clusterName) {
+			continue
+		}
+
 		_, err := d.updateVRGState(clusterName, rmn.Secondary)
 		if err != nil {
 			d.log.Info(fmt.Sprintf("Failed to update VRG to secondary on cluster %s. Err (%v)", clusterName, err))
@@ -1109,11 +1419,13 @@ func (d *DRPCInstance) cleanupSecondaries(skipCluster string) (bool, error) {
 			return false, err
 		}
 
-		mcvNameNS := BuildManagedClusterViewName(d.instance.Name, d.instance.Namespace, rmnutil.MWTypeNS)
-		// MCV for Namespace is no longer needed
-		err = d.reconciler.deleteManagedClusterView(clusterName, mcvNameNS)
-		if err != nil {
-			return false, err
+		for _, namespace := range d.protectedNamespaces() {
+			mcvNameNS := BuildManagedClusterViewName(d.instance.Name, namespace, rmnutil.MWTypeNS)
+			// MCV for Namespace is no longer needed
+			err = d.reconciler.deleteManagedClusterView(clusterName, mcvNameNS)
+			if err != nil {
+				return false, err
+			}
 		}
 	}
 
@@ -1162,7 +1474,7 @@ func (d *DRPCInstance) createVRGManifestWork(homeCluster string) error {
 	d.log.Info("Creating VRG ManifestWork",
 		"Last State:", d.getLastDRState(), "cluster", homeCluster)
 
-	vrg := d.generateVRG(rmn.Primary)
+	vrg := d.generateVRG(homeCluster, rmn.Primary)
 	vrg.Spec.VolSync.Disabled = d.volSyncDisabled
 
 	if err := d.mwu.CreateOrUpdateVRGManifestWork(
@@ -1176,17 +1488,42 @@ func (d *DRPCInstance) createVRGManifestWork(homeCluster string) error {
 	return nil
 }
 
-func (d *DRPCInstance) generateVRG(repState rmn.ReplicationState) rmn.VolumeReplicationGroup {
+func (d *DRPCInstance) generateVRG(dstCluster string, repState rmn.ReplicationState) rmn.VolumeReplicationGroup {
 	vrg := rmn.VolumeReplicationGroup{
-		TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
-		ObjectMeta: metav1.ObjectMeta{Name: d.instance.Name, Namespace: d.instance.Namespace},
+		TypeMeta: metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.instance.Name,
+			Namespace: d.instance.Namespace,
+			Annotations: map[string]string{
+				rmnutil.DRPCNameAnnotation:      d.instance.Name,
+				rmnutil.DRPCNamespaceAnnotation: d.instance.Namespace,
+			},
+		},
 		Spec: rmn.VolumeReplicationGroupSpec{
-			PVCSelector:      d.instance.Spec.PVCSelector,
-			ReplicationState: repState,
-			S3Profiles:       rmnutil.DRPolicyS3Profiles(d.drPolicy, d.drClusters).List(),
+			PVCSelector:                d.instance.Spec.PVCSelector,
+			ProtectedNamespaces:        d.instance.Spec.ProtectedNamespaces,
+			ProtectedNamespaceSelector: d.instance.Spec.ProtectedNamespaceSelector,
+			ReplicationState:           repState,
+			S3Profiles:                 rmnutil.DRPolicyS3Profiles(d.drPolicy, d.drClusters).List(),
+			StorageClassMappings:       rmnutil.DRPolicyStorageClassMappings(d.drPolicy, dstCluster),
+			PVRestore:                  d.instance.Spec.PVRestore,
+			Suspended:                  d.instance.Spec.Suspended,
+			WarmStandby:                d.instance.Spec.WarmStandby,
+			VolSync: rmn.VolSyncSpec{
+				Mover:                  d.drPolicy.Spec.VolSyncMover,
+				Profile:                d.drPolicy.Spec.VolSyncProfile,
+				SSHSecretName:          volsync.GetVolSyncSSHSecretName(d.instance.Name, d.getVolSyncSSHSecretGeneration()),
+				RecoveryPointRetention: d.drPolicy.Spec.VolSyncRecoveryPointRetention,
+				DestinationName:        d.volSyncDestinationName(dstCluster),
+				ScaleDownWorkloads:     d.instance.Spec.ScaleDownWorkloads,
+			},
 		},
 	}
 
+	if repState == rmn.Primary {
+		vrg.Spec.VolSync.Destinations = d.volSyncDestinations(dstCluster)
+	}
+
 	vrg.Spec.Async = d.generateVRGSpecAsync()
 	vrg.Spec.Sync = d.generateVRGSpecSync()
 
@@ -1200,6 +1537,7 @@ func (d *DRPCInstance) generateVRGSpecAsync() rmn.VRGAsyncSpec {
 			VolumeSnapshotClassSelector: d.drPolicy.Spec.VolumeSnapshotClassSelector,
 			SchedulingInterval:          d.drPolicy.Spec.SchedulingInterval,
 			Mode:                        rmn.AsyncModeEnabled,
+			VolumeGroupReplication:      d.instance.Spec.VolumeGroupReplication,
 		}
 	}
 
@@ -1281,20 +1619,32 @@ func drClusterRegion(drClusters []rmn.DRCluster, cluster string) (region rmn.Reg
 	return
 }
 
+// ensureNamespaceExistsOnManagedCluster ensures every namespace protected by
+// the DRPC exists on the cluster
 func (d *DRPCInstance) ensureNamespaceExistsOnManagedCluster(homeCluster string) error {
+	for _, namespace := range d.protectedNamespaces() {
+		if err := d.ensureNamespaceExistsOnManagedClusterHelper(homeCluster, namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *DRPCInstance) ensureNamespaceExistsOnManagedClusterHelper(homeCluster, namespace string) error {
 	// verify namespace exists on target cluster
-	namespaceExists, err := d.namespaceExistsOnManagedCluster(homeCluster)
+	namespaceExists, err := d.namespaceExistsOnManagedCluster(homeCluster, namespace)
 
 	d.log.Info(fmt.Sprintf("createVRGManifestWork: namespace '%s' exists on cluster %s: %t",
-		d.instance.Namespace, homeCluster, namespaceExists))
+		namespace, homeCluster, namespaceExists))
 
 	if !namespaceExists { // attempt to create it
-		err := d.mwu.CreateOrUpdateNamespaceManifest(d.instance.Name, d.instance.Namespace, homeCluster)
+		err := d.mwu.CreateOrUpdateNamespaceManifest(d.instance.Name, namespace, homeCluster)
 		if err != nil {
-			return fmt.Errorf("failed to create namespace '%s' on cluster %s: %w", d.instance.Namespace, homeCluster, err)
+			return fmt.Errorf("failed to create namespace '%s' on cluster %s: %w", namespace, homeCluster, err)
 		}
 
-		d.log.Info(fmt.Sprintf("Created Namespace '%s' on cluster %s", d.instance.Namespace, homeCluster))
+		d.log.Info(fmt.Sprintf("Created Namespace '%s' on cluster %s", namespace, homeCluster))
 
 		return nil // created namespace
 	}
@@ -1302,12 +1652,29 @@ func (d *DRPCInstance) ensureNamespaceExistsOnManagedCluster(homeCluster string)
 	// namespace exists already
 	if err != nil {
 		return fmt.Errorf("failed to verify if namespace '%s' on cluster %s exists: %w",
-			d.instance.Namespace, homeCluster, err)
+			namespace, homeCluster, err)
 	}
 
 	return nil
 }
 
+// protectedNamespaces returns the sorted namespaces protected by the DRPC: its
+// own namespace, the namespaces listed in its spec, and the namespaces that the
+// VRGs on the managed clusters report as protected, which include those
+// matching its namespace selector
+func (d *DRPCInstance) protectedNamespaces() []string {
+	namespaces := sets.NewString(d.instance.Namespace)
+	namespaces.Insert(d.instance.Spec.ProtectedNamespaces...)
+
+	for _, vrg := range d.vrgs {
+		for _, protectedNamespace := range vrg.Status.ProtectedNamespaces {
+			namespaces.Insert(protectedNamespace.Name)
+		}
+	}
+
+	return namespaces.List()
+}
+
 func (d *DRPCInstance) isVRGPrimary(vrg *rmn.VolumeReplicationGroup) bool {
 	return (vrg.Spec.ReplicationState == rmn.Primary)
 }
@@ -1386,6 +1753,22 @@ func (d *DRPCInstance) EnsureCleanup(clusterToSkip string) error {
 				continue
 			}
 
+			// A peer being drained must not retain a VRG, so remove it rather than wait for it
+			if drClusterDraining(d.drClusters, clusterName) {
+				deleted, err := d.ensureVRGManifestWorkOnClusterDeleted(clusterName)
+				if err != nil {
+					return fmt.Errorf("failed to delete VRG on draining peer %s (%w)", clusterName, err)
+				}
+
+				if !deleted {
+					peersReady = false
+
+					break
+				}
+
+				continue
+			}
+
 			if !d.isVRGConditionMet(clusterName, VRGConditionTypeDataReady) {
 				peersReady = false
 
@@ -1394,9 +1777,14 @@ func (d *DRPCInstance) EnsureCleanup(clusterToSkip string) error {
 		}
 
 		if !peersReady {
+			d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionPeerReady, d.instance.Generation,
+				metav1.ConditionFalse, rmn.ReasonProgressing,
+				d.peerResyncMessage("waiting for peer to be ready", clusterToSkip))
+
 			return fmt.Errorf("still waiting for peer to be ready")
 		}
 
+		d.instance.Status.PeerResync = nil
 		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionPeerReady, d.instance.Generation,
 			metav1.ConditionTrue, rmn.ReasonSuccess, "Ready")
 
@@ -1412,27 +1800,136 @@ func (d *DRPCInstance) EnsureCleanup(clusterToSkip string) error {
 	}
 
 	if !clean {
-		msg := "cleaning secondaries"
+		msg := d.peerResyncMessage("cleaning secondaries", clusterToSkip)
 		d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionPeerReady, d.instance.Generation,
 			metav1.ConditionFalse, rmn.ReasonCleaning, msg)
 
 		return fmt.Errorf("waiting to clean secondaries")
 	}
 
+	d.instance.Status.PeerResync = nil
 	d.setDRPCCondition(&d.instance.Status.Conditions, rmn.ConditionPeerReady, d.instance.Generation,
 		metav1.ConditionTrue, rmn.ReasonSuccess, "Cleaned")
 
 	return nil
 }
 
-func (d *DRPCInstance) namespaceExistsOnManagedCluster(cluster string) (bool, error) {
+// peerResyncMessage aggregates the resync progress of the PVCs protected by the
+// VRGs on the peers of the cluster to skip into the DRPC status, and returns
+// the message, extended with the progress of the peer still resyncing, if any
+func (d *DRPCInstance) peerResyncMessage(msg, clusterToSkip string) string {
+	d.instance.Status.PeerResync = nil
+
+	for _, clusterName := range rmnutil.DrpolicyClusterNames(d.drPolicy) {
+		if clusterToSkip == clusterName {
+			continue
+		}
+
+		peerResync := vrgPeerResyncStatus(clusterName, d.vrgs[clusterName])
+		if peerResync == nil {
+			continue
+		}
+
+		d.instance.Status.PeerResync = peerResync
+
+		msg = fmt.Sprintf("%s, cluster %s is resyncing %d of %d PVCs", msg, clusterName,
+			peerResync.ResyncingPVCs, peerResync.TotalPVCs)
+		if peerResync.EstimatedCompletionTime != nil {
+			msg = fmt.Sprintf("%s, estimated to complete at %s", msg,
+				peerResync.EstimatedCompletionTime.UTC().Format(time.RFC3339))
+		}
+
+		break
+	}
+
+	return msg
+}
+
+// vrgPeerResyncStatus returns the resync progress of the PVCs protected by the
+// VRG on a peer cluster, or nil if none of them is resyncing. The estimated
+// completion time is the latest one of the resyncing PVCs, and is unknown if
+// any of them is unknown.
+func vrgPeerResyncStatus(clusterName string, vrg *rmn.VolumeReplicationGroup) *rmn.PeerResyncStatus {
+	if vrg == nil {
+		return nil
+	}
+
+	peerResync := &rmn.PeerResyncStatus{
+		ClusterName: clusterName,
+		TotalPVCs:   len(vrg.Status.ProtectedPVCs),
+	}
+	etaKnown := true
+
+	for idx := range vrg.Status.ProtectedPVCs {
+		syncStatus := vrg.Status.ProtectedPVCs[idx].SyncStatus
+		if syncStatus == nil || syncStatus.State != rmn.PVCSyncStateResyncing {
+			continue
+		}
+
+		peerResync.ResyncingPVCs++
+
+		eta := syncStatus.EstimatedCompletionTime
+		if eta == nil {
+			etaKnown = false
+
+			continue
+		}
+
+		if peerResync.EstimatedCompletionTime == nil || peerResync.EstimatedCompletionTime.Before(eta) {
+			peerResync.EstimatedCompletionTime = eta.DeepCopy()
+		}
+	}
+
+	if peerResync.ResyncingPVCs == 0 {
+		return nil
+	}
+
+	if !etaKnown {
+		peerResync.EstimatedCompletionTime = nil
+	}
+
+	return peerResync
+}
+
+// recordFailoverDataLossWindow records, as a failover starts, the last time
+// the data of all protected PVCs was replicated, and the resulting data loss
+// window. The last group sync time last reported by the primary VRG is used,
+// and the one of the failover cluster VRG when the primary one is unknown.
+func (d *DRPCInstance) recordFailoverDataLossWindow() {
+	d.instance.Status.FailoverLastGroupSyncTime = nil
+	d.instance.Status.FailoverDataLossWindow = nil
+
+	lastGroupSyncTime := d.instance.Status.LastGroupSyncTime
+	if vrg := d.vrgs[d.instance.Spec.FailoverCluster]; lastGroupSyncTime == nil && vrg != nil {
+		lastGroupSyncTime = vrg.Status.LastGroupSyncTime
+	}
+
+	if lastGroupSyncTime == nil {
+		d.log.Info("Last group sync time unknown, failover data loss window not known")
+
+		return
+	}
+
+	d.instance.Status.FailoverLastGroupSyncTime = lastGroupSyncTime.DeepCopy()
+
+	if d.instance.Status.ActionStartTime != nil {
+		d.instance.Status.FailoverDataLossWindow = &metav1.Duration{
+			Duration: d.instance.Status.ActionStartTime.Sub(lastGroupSyncTime.Time),
+		}
+	}
+
+	d.log.Info("Failover data loss window", "lastGroupSyncTime", lastGroupSyncTime,
+		"window", d.instance.Status.FailoverDataLossWindow)
+}
+
+func (d *DRPCInstance) namespaceExistsOnManagedCluster(cluster, namespace string) (bool, error) {
 	exists := true
 
 	// create ManagedClusterView to check if namespace exists
-	_, err := d.reconciler.MCVGetter.GetNamespaceFromManagedCluster(d.instance.Name, cluster, d.instance.Namespace)
+	_, err := d.reconciler.MCVGetter.GetNamespaceFromManagedCluster(d.instance.Name, cluster, namespace)
 	if err != nil {
 		if errors.IsNotFound(err) { // successfully detected that Namespace is not found by ManagedClusterView
-			d.log.Info(fmt.Sprintf("Namespace '%s' not found on cluster %s", d.instance.Namespace, cluster))
+			d.log.Info(fmt.Sprintf("Namespace '%s' not found on cluster %s", namespace, cluster))
 
 			return !exists, nil
 		}
@@ -1543,7 +2040,7 @@ func (d *DRPCInstance) ensureVRGIsSecondaryOnCluster(clusterName string) bool {
 // secondary by now.
 func (d *DRPCInstance) ensureDataProtected(targetCluster string) bool {
 	for _, clusterName := range rmnutil.DrpolicyClusterNames(d.drPolicy) {
-		if targetCluster == clusterName {
+		if targetCluster == clusterName || d.isVolSyncDestinationOtherThan(clusterName, targetCluster) {
 			continue
 		}
 
@@ -1642,10 +2139,24 @@ func (d *DRPCInstance) updateVRGState(clusterName string, state rmn.ReplicationS
 	}
 
 	vrg.Spec.ReplicationState = state
+	vrg.Spec.WarmStandby = d.instance.Spec.WarmStandby
+
+	if state == rmn.Primary {
+		// PVs are restored to the cluster as it becomes primary, using the
+		// current StorageClass mappings of the DRPolicy and PV restore policy
+		// of the DRPC
+		vrg.Spec.StorageClassMappings = rmnutil.DRPolicyStorageClassMappings(d.drPolicy, clusterName)
+		vrg.Spec.PVRestore = d.instance.Spec.PVRestore
+		vrg.Spec.Suspended = d.instance.Spec.Suspended
+		vrg.Spec.VolSync.RecoveryPointTime = d.failoverRecoveryPointTime()
+		vrg.Spec.VolSync.Destinations = d.volSyncDestinations(clusterName)
+	}
+
 	if state == rmn.Secondary {
 		// Turn off the final sync flags
 		vrg.Spec.PrepareForFinalSync = false
 		vrg.Spec.RunFinalSync = false
+		vrg.Spec.VolSync.RecoveryPointTime = nil
 	}
 
 	err = d.updateManifestWork(clusterName, vrg)
@@ -1676,6 +2187,8 @@ func (d *DRPCInstance) updateVRGToPrepareForFinalSync(clusterName string) error
 
 	vrg.Spec.PrepareForFinalSync = true
 	vrg.Spec.RunFinalSync = false
+	vrg.Spec.VolSync.ScaleDownWorkloads = d.instance.Spec.ScaleDownWorkloads
+	vrg.Spec.VolSync.Destinations = d.volSyncFinalSyncDestinations(clusterName)
 
 	err = d.updateManifestWork(clusterName, vrg)
 	if err != nil {
@@ -1706,6 +2219,8 @@ func (d *DRPCInstance) updateVRGToRunFinalSync(clusterName string) error {
 
 	vrg.Spec.RunFinalSync = true
 	vrg.Spec.PrepareForFinalSync = false
+	vrg.Spec.VolSync.ScaleDownWorkloads = d.instance.Spec.ScaleDownWorkloads
+	vrg.Spec.VolSync.Destinations = d.volSyncFinalSyncDestinations(clusterName)
 
 	err = d.updateManifestWork(clusterName, vrg)
 	if err != nil {
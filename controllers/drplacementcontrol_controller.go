@@ -24,15 +24,18 @@ import (
 
 	"github.com/go-logr/logr"
 	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	errorswrapper "github.com/pkg/errors"
 	viewv1beta1 "github.com/stolostron/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -72,6 +75,15 @@ type ManagedClusterViewGetter interface {
 		resourceName, resourceNamespace, managedCluster string) (*rmn.VolumeReplicationGroup, error)
 
 	GetNamespaceFromManagedCluster(resourceName, resourceNamespace, managedCluster string) (*corev1.Namespace, error)
+
+	GetSubscriptionFromManagedCluster(
+		resourceName, resourceNamespace, managedCluster string) (*operatorsv1alpha1.Subscription, error)
+
+	GetClusterServiceVersionFromManagedCluster(
+		resourceName, resourceNamespace, managedCluster string) (*operatorsv1alpha1.ClusterServiceVersion, error)
+
+	GetStorageClassFromManagedCluster(
+		drpolicyName, storageClassName, managedCluster string) (*storagev1.StorageClass, error)
 }
 
 type ManagedClusterViewGetterImpl struct {
@@ -126,6 +138,84 @@ func (m ManagedClusterViewGetterImpl) GetNamespaceFromManagedCluster(
 	return namespace, err
 }
 
+func (m ManagedClusterViewGetterImpl) GetSubscriptionFromManagedCluster(
+	resourceName, resourceNamespace, managedCluster string) (*operatorsv1alpha1.Subscription, error) {
+	logger := ctrl.Log.WithName("MCV").WithValues("resouceName", resourceName)
+
+	// get OLM Subscription and verify status through ManagedClusterView
+	mcvMeta := metav1.ObjectMeta{
+		Name:      BuildManagedClusterViewName(resourceName, resourceNamespace, mcvTypeSubscription),
+		Namespace: managedCluster,
+		Labels:    map[string]string{drClusterOperatorViewLabel: ""},
+	}
+
+	mcvViewscope := viewv1beta1.ViewScope{
+		Group:     operatorsv1alpha1.GroupName,
+		Version:   operatorsv1alpha1.GroupVersion,
+		Kind:      operatorsv1alpha1.SubscriptionKind,
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+
+	subscription := &operatorsv1alpha1.Subscription{}
+
+	err := m.getManagedClusterResource(mcvMeta, mcvViewscope, subscription, logger)
+
+	return subscription, err
+}
+
+func (m ManagedClusterViewGetterImpl) GetClusterServiceVersionFromManagedCluster(
+	resourceName, resourceNamespace, managedCluster string) (*operatorsv1alpha1.ClusterServiceVersion, error) {
+	logger := ctrl.Log.WithName("MCV").WithValues("resouceName", resourceName)
+
+	// get ClusterServiceVersion and verify status through ManagedClusterView
+	mcvMeta := metav1.ObjectMeta{
+		Name:      BuildManagedClusterViewName(resourceName, resourceNamespace, mcvTypeClusterServiceVersion),
+		Namespace: managedCluster,
+		Labels:    map[string]string{drClusterOperatorViewLabel: ""},
+	}
+
+	mcvViewscope := viewv1beta1.ViewScope{
+		Group:     operatorsv1alpha1.GroupName,
+		Version:   operatorsv1alpha1.GroupVersion,
+		Kind:      operatorsv1alpha1.ClusterServiceVersionKind,
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+
+	csv := &operatorsv1alpha1.ClusterServiceVersion{}
+
+	err := m.getManagedClusterResource(mcvMeta, mcvViewscope, csv, logger)
+
+	return csv, err
+}
+
+func (m ManagedClusterViewGetterImpl) GetStorageClassFromManagedCluster(
+	drpolicyName, storageClassName, managedCluster string) (*storagev1.StorageClass, error) {
+	logger := ctrl.Log.WithName("MCV").WithValues("resouceName", storageClassName)
+
+	// get StorageClass and verify its provisioner through ManagedClusterView
+	mcvMeta := metav1.ObjectMeta{
+		Name:        BuildManagedClusterViewName(drpolicyName, storageClassName, mcvTypeStorageClass),
+		Namespace:   managedCluster,
+		Labels:      map[string]string{drPolicyStorageClassViewLabel: ""},
+		Annotations: map[string]string{drPolicyNameAnnotation: drpolicyName},
+	}
+
+	mcvViewscope := viewv1beta1.ViewScope{
+		Group:   storagev1.GroupName,
+		Version: storagev1.SchemeGroupVersion.Version,
+		Kind:    "StorageClass",
+		Name:    storageClassName,
+	}
+
+	storageClass := &storagev1.StorageClass{}
+
+	err := m.getManagedClusterResource(mcvMeta, mcvViewscope, storageClass, logger)
+
+	return storageClass, err
+}
+
 /*
 Description: queries a managed cluster for a resource type, and populates a variable with the results.
 Requires:
@@ -362,6 +452,33 @@ func filterUsrPlRule(usrPlRule *plrv1.PlacementRule) []ctrl.Request {
 	}
 }
 
+// filterDRPolicy returns a request for each DRPC of the DRPolicy, so that the
+// VRGs of the DRPCs follow the changes of the DRPolicy settings passed in to
+// them
+func (r *DRPlacementControlReconciler) filterDRPolicy(drpolicy *rmn.DRPolicy) []ctrl.Request {
+	drpcList := &rmn.DRPlacementControlList{}
+	if err := r.Client.List(context.TODO(), drpcList); err != nil {
+		ctrl.Log.Info(fmt.Sprintf("Failed to list DRPCs of DRPolicy %s (%v)", drpolicy.Name, err))
+
+		return []ctrl.Request{}
+	}
+
+	requests := []ctrl.Request{}
+
+	for idx := range drpcList.Items {
+		drpc := &drpcList.Items[idx]
+		if drpc.Spec.DRPolicyRef.Name != drpolicy.Name {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: drpc.Name, Namespace: drpc.Namespace},
+		})
+	}
+
+	return requests
+}
+
 func SetDRPCStatusCondition(conditions *[]metav1.Condition, condType string,
 	observedGeneration int64, status metav1.ConditionStatus, reason, msg string) bool {
 	newCondition := metav1.Condition{
@@ -428,6 +545,17 @@ func (r *DRPlacementControlReconciler) SetupWithManager(mgr ctrl.Manager) error
 		return filterUsrPlRule(usrPlRule)
 	}))
 
+	drpolicyMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(func(obj client.Object) []reconcile.Request {
+		drpolicy, ok := obj.(*rmn.DRPolicy)
+		if !ok {
+			return []reconcile.Request{}
+		}
+
+		ctrl.Log.Info(fmt.Sprintf("Filtering DRPolicy (%s)", drpolicy.Name))
+
+		return r.filterDRPolicy(drpolicy)
+	}))
+
 	r.eventRecorder = rmnutil.NewEventReporter(mgr.GetEventRecorderFor("controller_DRPlacementControl"))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -436,6 +564,8 @@ func (r *DRPlacementControlReconciler) SetupWithManager(mgr ctrl.Manager) error
 		Watches(&source.Kind{Type: &ocmworkv1.ManifestWork{}}, mwMapFun, builder.WithPredicates(mwPred)).
 		Watches(&source.Kind{Type: &viewv1beta1.ManagedClusterView{}}, mcvMapFun, builder.WithPredicates(mcvPred)).
 		Watches(&source.Kind{Type: &plrv1.PlacementRule{}}, usrPlRuleMapFun, builder.WithPredicates(usrPlRulePred)).
+		Watches(&source.Kind{Type: &rmn.DRPolicy{}}, drpolicyMapFun,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
 
@@ -582,16 +712,17 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(ctx context.Context,
 	}
 
 	d := &DRPCInstance{
-		reconciler:        r,
-		ctx:               ctx,
-		log:               r.Log,
-		instance:          drpc,
-		userPlacementRule: usrPlRule,
-		drpcPlacementRule: drpcPlRule,
-		drPolicy:          drPolicy,
-		drClusters:        drClusters,
-		vrgs:              vrgs,
-		volSyncDisabled:   ramenConfig.VolSync.Disabled,
+		reconciler:         r,
+		ctx:                ctx,
+		log:                r.Log,
+		instance:           drpc,
+		userPlacementRule:  usrPlRule,
+		drpcPlacementRule:  drpcPlRule,
+		drPolicy:           drPolicy,
+		drClusters:         drClusters,
+		vrgs:               vrgs,
+		volSyncDisabled:    ramenConfig.VolSync.Disabled,
+		volSyncKeyRotation: getVolSyncSecretRotationInterval(ramenConfig),
 		mwu: rmnutil.MWUtil{
 			Client:        r.Client,
 			Ctx:           ctx,
@@ -760,6 +891,8 @@ func (r *DRPlacementControlReconciler) finalizeDRPC(ctx context.Context, drpc *r
 		return fmt.Errorf("failed to get DRPolicy while finalizing DRPC (%w)", err)
 	}
 
+	namespaces := r.finalizeDRPCProtectedNamespaces(drpc, drPolicy)
+
 	// delete manifestworks (VRG)
 	for _, drClusterName := range rmnutil.DrpolicyClusterNames(drPolicy) {
 		err := mwu.DeleteManifestWorksForCluster(drClusterName)
@@ -774,11 +907,13 @@ func (r *DRPlacementControlReconciler) finalizeDRPC(ctx context.Context, drpc *r
 			return err
 		}
 
-		mcvName = BuildManagedClusterViewName(drpc.Name, drpc.Namespace, rmnutil.MWTypeNS)
-		// Delete MCV for Namespace
-		err = r.deleteManagedClusterView(drClusterName, mcvName)
-		if err != nil {
-			return err
+		for _, namespace := range namespaces {
+			mcvName = BuildManagedClusterViewName(drpc.Name, namespace, rmnutil.MWTypeNS)
+			// Delete MCV for Namespace
+			err = r.deleteManagedClusterView(drClusterName, mcvName)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -790,6 +925,34 @@ func (r *DRPlacementControlReconciler) finalizeDRPC(ctx context.Context, drpc *r
 	return nil
 }
 
+// finalizeDRPCProtectedNamespaces returns the namespaces for which a
+// ManagedClusterView may have been created for the DRPC: its own namespace, the
+// namespaces listed in its spec and status, and those that the VRGs still
+// viewable on the managed clusters report as protected, which include the
+// namespaces matching its namespace selector
+func (r *DRPlacementControlReconciler) finalizeDRPCProtectedNamespaces(drpc *rmn.DRPlacementControl,
+	drPolicy *rmn.DRPolicy,
+) []string {
+	namespaces := sets.NewString(drpc.Namespace)
+	namespaces.Insert(drpc.Spec.ProtectedNamespaces...)
+	namespaces.Insert(drpc.Status.ResourceConditions.ResourceMeta.ProtectedNamespaces...)
+
+	for _, drClusterName := range rmnutil.DrpolicyClusterNames(drPolicy) {
+		vrg, err := r.MCVGetter.GetVRGFromManagedCluster(drpc.Name, drpc.Namespace, drClusterName)
+		if err != nil {
+			r.Log.Info("VRG not viewable while finalizing DRPC", "cluster", drClusterName, "error", err.Error())
+
+			continue
+		}
+
+		for _, protectedNamespace := range vrg.Status.ProtectedNamespaces {
+			namespaces.Insert(protectedNamespace.Name)
+		}
+	}
+
+	return namespaces.List()
+}
+
 func (r *DRPlacementControlReconciler) deleteManagedClusterView(clusterName, mcvName string) error {
 	r.Log.Info("Delete ManagedClusterView from", "namespace", clusterName, "name", mcvName)
 
@@ -1119,6 +1282,14 @@ func (r *DRPlacementControlReconciler) updateDRPCStatus(
 			}
 
 			drpc.Status.ResourceConditions.ResourceMeta.ProtectedPVCs = protectedPVCs
+
+			protectedNamespaces := []string{}
+			for _, protectedNamespace := range vrg.Status.ProtectedNamespaces {
+				protectedNamespaces = append(protectedNamespaces, protectedNamespace.Name)
+			}
+
+			drpc.Status.ResourceConditions.ResourceMeta.ProtectedNamespaces = protectedNamespaces
+			drpc.Status.LastGroupSyncTime = vrg.Status.LastGroupSyncTime
 		}
 	}
 
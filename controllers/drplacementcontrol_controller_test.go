@@ -21,6 +21,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/ghodss/yaml"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -35,6 +36,8 @@ import (
 
 	spokeClusterV1 "github.com/open-cluster-management/api/cluster/v1"
 	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	"github.com/operator-framework/api/pkg/lib/version"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 
 	dto "github.com/prometheus/client_model/go"
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
@@ -43,6 +46,7 @@ import (
 	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 )
 
 const (
@@ -180,6 +184,36 @@ func (f FakeMCVGetter) GetNamespaceFromManagedCluster(
 	return appNamespaceObj, errorswrapper.Wrap(err, "failed to get Namespace from managedcluster")
 }
 
+const fakeDRClusterOperatorCSVName = "ramen-dr-cluster-operator.v0.0.1"
+
+func (f FakeMCVGetter) GetSubscriptionFromManagedCluster(
+	resourceName, resourceNamespace, managedCluster string) (*operatorsv1alpha1.Subscription, error) {
+	return &operatorsv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: resourceNamespace},
+		Status:     operatorsv1alpha1.SubscriptionStatus{InstalledCSV: fakeDRClusterOperatorCSVName},
+	}, nil
+}
+
+func (f FakeMCVGetter) GetClusterServiceVersionFromManagedCluster(
+	resourceName, resourceNamespace, managedCluster string) (*operatorsv1alpha1.ClusterServiceVersion, error) {
+	return &operatorsv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: resourceNamespace},
+		Spec: operatorsv1alpha1.ClusterServiceVersionSpec{
+			Version: version.OperatorVersion{Version: semver.MustParse("0.0.1")},
+		},
+		Status: operatorsv1alpha1.ClusterServiceVersionStatus{Phase: operatorsv1alpha1.CSVPhaseSucceeded},
+	}, nil
+}
+
+func (f FakeMCVGetter) GetStorageClassFromManagedCluster(
+	drpolicyName, storageClassName, managedCluster string) (*storagev1.StorageClass, error) {
+	storageClass := &storagev1.StorageClass{}
+
+	err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, storageClass)
+
+	return storageClass, errorswrapper.Wrap(err, "failed to get StorageClass from managedcluster")
+}
+
 var baseVRG = &rmn.VolumeReplicationGroup{
 	TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
 	ObjectMeta: metav1.ObjectMeta{Name: DRPCName, Namespace: DRPCNamespaceName},
@@ -474,6 +508,50 @@ func setDRPCSpecExpectationTo(action rmn.DRAction, preferredCluster, failoverClu
 	}, timeout, interval).Should(BeTrue(), "failed to update DRPC DR action on time")
 }
 
+func setDRPCSuspended(suspended bool) {
+	Eventually(func() error {
+		latestDRPC := getLatestDRPC()
+		latestDRPC.Spec.Suspended = suspended
+
+		return k8sClient.Update(context.TODO(), latestDRPC)
+	}, timeout, interval).Should(Succeed(), "failed to update DRPC suspended state")
+}
+
+func setDRPCForceAction(action rmn.DRAction) {
+	Eventually(func() error {
+		latestDRPC := getLatestDRPC()
+		latestDRPC.Spec.ForceAction = action
+
+		return k8sClient.Update(context.TODO(), latestDRPC)
+	}, timeout, interval).Should(Succeed(), "failed to update DRPC force action")
+}
+
+func setDRPolicyVolSyncMover(mover rmn.VolSyncMoverType) {
+	Eventually(func() error {
+		drpolicy := &rmn.DRPolicy{}
+		if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: asyncDRPolicy.Name}, drpolicy); err != nil {
+			return err
+		}
+
+		drpolicy.Spec.VolSyncMover = mover
+
+		return k8sClient.Update(context.TODO(), drpolicy)
+	}, timeout, interval).Should(Succeed(), "failed to update DRPolicy VolSync mover")
+}
+
+func setDRPolicyVolSyncProfile(profile *rmn.VolSyncProfile) {
+	Eventually(func() error {
+		drpolicy := &rmn.DRPolicy{}
+		if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: asyncDRPolicy.Name}, drpolicy); err != nil {
+			return err
+		}
+
+		drpolicy.Spec.VolSyncProfile = profile
+
+		return k8sClient.Update(context.TODO(), drpolicy)
+	}, timeout, interval).Should(Succeed(), "failed to update DRPolicy VolSync profile")
+}
+
 func getLatestDRPC() *rmn.DRPlacementControl {
 	drpcLookupKey := types.NamespacedName{
 		Name:      DRPCName,
@@ -1350,6 +1428,70 @@ var _ = Describe("DRPlacementControl Reconciler", func() {
 				clearDRActionAfterRelocate(userPlacementRule, East1ManagedCluster, West1ManagedCluster)
 			})
 		})
+		When("Replication is suspended after relocation", func() {
+			It("Should suspend the VRG on Primary (East1ManagedCluster)", func() {
+				setDRPCSuspended(true)
+				Eventually(func() bool {
+					vrg, err := getVRGFromManifestWork(East1ManagedCluster)
+
+					return err == nil && vrg.Spec.Suspended
+				}, timeout, interval).Should(BeTrue())
+			})
+			It("Should not failover to Secondary (West1ManagedCluster) unless forced", func() {
+				setDRPCSpecExpectationTo(rmn.ActionFailover, East1ManagedCluster, West1ManagedCluster)
+				verifyUserPlacementRuleDecisionUnchanged(userPlacementRule.Name, userPlacementRule.Namespace,
+					East1ManagedCluster)
+				_, condition := getDRPCCondition(&getLatestDRPC().Status, rmn.ConditionAvailable)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Message).To(ContainSubstring("replication is suspended"))
+				setDRPCSpecExpectationTo("", East1ManagedCluster, West1ManagedCluster)
+			})
+			It("Should not failover to Secondary (West1ManagedCluster) when only a relocate is forced", func() {
+				setDRPCForceAction(rmn.ActionRelocate)
+				setDRPCSpecExpectationTo(rmn.ActionFailover, East1ManagedCluster, West1ManagedCluster)
+				verifyUserPlacementRuleDecisionUnchanged(userPlacementRule.Name, userPlacementRule.Namespace,
+					East1ManagedCluster)
+				_, condition := getDRPCCondition(&getLatestDRPC().Status, rmn.ConditionAvailable)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Message).To(ContainSubstring("replication is suspended"))
+				setDRPCSpecExpectationTo("", East1ManagedCluster, West1ManagedCluster)
+				setDRPCForceAction("")
+			})
+			It("Should resume the VRG on Primary (East1ManagedCluster)", func() {
+				setDRPCSuspended(false)
+				Eventually(func() bool {
+					vrg, err := getVRGFromManifestWork(East1ManagedCluster)
+
+					return err == nil && !vrg.Spec.Suspended
+				}, timeout, interval).Should(BeTrue())
+			})
+		})
+		When("The VolSync mover of the DRPolicy is changed after relocation", func() {
+			It("Should switch the VRG on Primary (East1ManagedCluster) to the mover", func() {
+				for _, mover := range []rmn.VolSyncMoverType{rmn.VolSyncMoverRestic, ""} {
+					setDRPolicyVolSyncMover(mover)
+					Eventually(func() bool {
+						vrg, err := getVRGFromManifestWork(East1ManagedCluster)
+
+						return err == nil && vrg.Spec.VolSync.Mover == mover
+					}, timeout, interval).Should(BeTrue(), "mover %q", mover)
+				}
+			})
+			It("Should reconnect the VRG on Primary (East1ManagedCluster) as per the VolSync profile", func() {
+				serviceType := corev1.ServiceTypeLoadBalancer
+				for _, profile := range []*rmn.VolSyncProfile{{ServiceType: &serviceType}, nil} {
+					setDRPolicyVolSyncProfile(profile)
+					Eventually(func() (*rmn.VolSyncProfile, error) {
+						vrg, err := getVRGFromManifestWork(East1ManagedCluster)
+						if err != nil {
+							return nil, err
+						}
+
+						return vrg.Spec.VolSync.Profile, nil
+					}, timeout, interval).Should(Equal(profile))
+				}
+			})
+		})
 		When("DRAction is changed to Failover after relocation", func() {
 			It("Should failover again to Secondary (West1ManagedCluster)", func() {
 				// ----------------------------- FAILOVER TO SECONDARY --------------------------------------
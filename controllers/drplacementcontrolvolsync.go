@@ -2,13 +2,22 @@ package controllers
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"time"
 
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	rmnutil "github.com/ramendr/ramen/controllers/util"
 	"github.com/ramendr/ramen/controllers/volsync"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// VolSyncSecretRotationAnnotation on a DRPC requests a rotation of the ssh keys of the VolSync rsync mover. Setting
+// it to a value other than the one the keys were last rotated for, such as the current time, rotates the keys.
+const VolSyncSecretRotationAnnotation = "drplacementcontrol.ramendr.openshift.io/volsync-secret-rotation"
+
 func (d *DRPCInstance) EnsureVolSyncReplicationSetup(homeCluster string) error {
 	d.log.Info(fmt.Sprintf("Ensure VolSync replication has been setup for cluster %s", homeCluster))
 
@@ -41,9 +50,18 @@ func (d *DRPCInstance) ensureVolSyncReplicationCommon(srcCluster string) error {
 	// Make sure we have Source and Destination VRGs - Source should already have been created at this point
 	d.setProgression("EnsuringVolSyncSetup")
 
-	const maxNumberOfVRGs = 2
-	if len(d.vrgs) != maxNumberOfVRGs {
-		// Create the destination VRG
+	dstVRGsFound := true
+
+	for _, dstCluster := range d.volSyncDestinationClusters(srcCluster) {
+		if _, found := d.vrgs[dstCluster]; !found {
+			dstVRGsFound = false
+
+			break
+		}
+	}
+
+	if !dstVRGsFound {
+		// Create the destination VRGs, on all the destination clusters
 		err := d.createVolSyncDestManifestWork(srcCluster)
 		if err != nil {
 			return err
@@ -59,30 +77,243 @@ func (d *DRPCInstance) ensureVolSyncReplicationCommon(srcCluster string) error {
 
 	// Now we should have a source and destination VRG created
 	// Since we will use VolSync - create/ensure & propagate a shared ssh rsync secret to both the src and dst clusters
-	sshSecretNameHub := fmt.Sprintf("%s-vs-secret-hub", d.instance.GetName())
+	clustersToPropagateSecret := []string{}
+	for clusterName := range d.vrgs {
+		clustersToPropagateSecret = append(clustersToPropagateSecret, clusterName)
+	}
+
+	if err := d.ensureVolSyncSSHSecret(clustersToPropagateSecret); err != nil {
+		return err
+	}
+
+	if d.drPolicy.Spec.VolSyncMover == rmn.VolSyncMoverRestic {
+		return d.ensureVolSyncResticSecret(clustersToPropagateSecret)
+	}
+
+	return nil
+}
+
+// ensureVolSyncSSHSecret creates the ssh keys of the rsync mover on the hub, and propagates them to the clusters.
+// The keys are rotated when the rotation interval elapses, and on demand. Rotating propagates the keys of the next
+// generation in a secret of their own, and once they are on all clusters, switches the Secondary VRGs over to them
+// first, and then, once the ReplicationDestinations use them, the Primary VRG. The keys of the previous generation
+// are deleted only once both VRGs use the new keys, and no rotation starts before. A synchronization that starts
+// in between fails to authenticate, and is retried by VolSync, so that no synchronization is missed.
+func (d *DRPCInstance) ensureVolSyncSSHSecret(clusters []string) error {
+	generation := d.getVolSyncSSHSecretGeneration()
+
+	sshSecretHub, err := d.propagateVolSyncSSHSecret(generation, clusters)
+	if err != nil {
+		return err
+	}
+
+	switched, err := d.ensureVRGsUseVolSyncSSHSecret(volsync.GetVolSyncSSHSecretName(d.instance.GetName(), generation))
+	if err != nil || !switched {
+		return err
+	}
+
+	if generation > 0 {
+		// Both VRGs use the keys of the current generation, and no longer need the keys of the previous one
+		previousSecretNameCluster := volsync.GetVolSyncSSHSecretName(d.instance.GetName(), generation-1)
+
+		if err := d.deleteVolSyncSSHSecret(previousSecretNameCluster); err != nil {
+			return err
+		}
+	}
+
+	if !d.volSyncSSHSecretRotationDue(sshSecretHub) {
+		return nil
+	}
+
+	rotated, err := d.rotateVolSyncSSHSecret(generation+1, clusters)
+	if err != nil || !rotated {
+		return err
+	}
+
+	_, err = d.ensureVRGsUseVolSyncSSHSecret(volsync.GetVolSyncSSHSecretName(d.instance.GetName(), generation+1))
+
+	return err
+}
+
+func (d *DRPCInstance) getVolSyncSSHSecretGeneration() int64 {
+	if d.instance.Status.VolSyncSecret == nil {
+		return 0
+	}
+
+	return d.instance.Status.VolSyncSecret.Generation
+}
+
+// propagateVolSyncSSHSecret creates the ssh keys of the generation on the hub, if they do not exist yet, and
+// propagates them to the clusters. Returns the secret with the keys on the hub.
+func (d *DRPCInstance) propagateVolSyncSSHSecret(generation int64, clusters []string) (*corev1.Secret, error) {
+	// Note that the VRG name == DRPC name
+	sshSecretNameCluster := volsync.GetVolSyncSSHSecretName(d.instance.GetName(), generation)
 
 	// Ensure/Create the secret on the hub
 	sshSecretHub, err := volsync.ReconcileVolSyncReplicationSecret(d.ctx, d.reconciler.Client, d.instance,
-		sshSecretNameHub, d.instance.GetNamespace(), d.log)
+		getVolSyncSSHSecretNameHub(sshSecretNameCluster), d.instance.GetNamespace(), d.log)
 	if err != nil {
 		d.log.Error(err, "Unable to create ssh secret on hub for VolSync")
 
-		return fmt.Errorf("%w", err)
+		return nil, fmt.Errorf("%w", err)
 	}
 
 	// Propagate the secret to all clusters (to be named sshSecretNameCluster on the clusters)
-	// Note that VRG spec will not contain the ssh secret name, we're going to name based on the VRG name itself
-	sshSecretNameCluster := volsync.GetVolSyncSSHSecretNameFromVRGName(d.instance.GetName()) // VRG name == DRPC name
+	err = volsync.PropagateSecretToClusters(d.ctx, d.reconciler.Client, sshSecretHub,
+		d.instance, clusters, sshSecretNameCluster, d.instance.GetNamespace(), d.log)
+	if err != nil {
+		d.log.Error(err, "Error propagating secret to clusters", "clustersToPropagateSecret", clusters)
 
-	clustersToPropagateSecret := []string{}
-	for clusterName := range d.vrgs {
-		clustersToPropagateSecret = append(clustersToPropagateSecret, clusterName)
+		return nil, fmt.Errorf("%w", err)
 	}
 
-	err = volsync.PropagateSecretToClusters(d.ctx, d.reconciler.Client, sshSecretHub,
-		d.instance, clustersToPropagateSecret, sshSecretNameCluster, d.instance.GetNamespace(), d.log)
+	return sshSecretHub, nil
+}
+
+// volSyncSSHSecretRotationDue returns true if the rotation interval elapsed since the keys were last rotated, or
+// created if they were never rotated, or if the rotation request annotation of the DRPC changed since the keys were
+// last rotated on demand
+func (d *DRPCInstance) volSyncSSHSecretRotationDue(sshSecretHub *corev1.Secret) bool {
+	status := d.instance.Status.VolSyncSecret
+	request := d.instance.GetAnnotations()[VolSyncSecretRotationAnnotation]
+
+	if request != "" && (status == nil || status.LastRotationRequest != request) {
+		d.log.Info("VolSync ssh secret rotation requested", "request", request)
+
+		return true
+	}
+
+	lastRotationTime := sshSecretHub.GetCreationTimestamp()
+	if status != nil && status.LastRotationTime != nil {
+		lastRotationTime = *status.LastRotationTime
+	}
+
+	return time.Since(lastRotationTime.Time) >= d.volSyncKeyRotation
+}
+
+// rotateVolSyncSSHSecret propagates the keys of the next generation to the clusters, and records the rotation in
+// the status of the DRPC once the keys are on all clusters. Returns true if the keys were rotated.
+func (d *DRPCInstance) rotateVolSyncSSHSecret(nextGeneration int64, clusters []string) (bool, error) {
+	if _, err := d.propagateVolSyncSSHSecret(nextGeneration, clusters); err != nil {
+		return false, err
+	}
+
+	nextSecretNameCluster := volsync.GetVolSyncSSHSecretName(d.instance.GetName(), nextGeneration)
+
+	propagated, err := volsync.IsSecretPropagated(d.ctx, d.reconciler.Client, nextSecretNameCluster,
+		d.instance.GetNamespace(), clusters)
 	if err != nil {
-		d.log.Error(err, "Error propagating secret to clusters", "clustersToPropagateSecret", clustersToPropagateSecret)
+		return false, fmt.Errorf("%w", err)
+	}
+
+	if !propagated {
+		d.log.Info("Waiting for the rotated VolSync ssh secret to propagate", "secretName", nextSecretNameCluster)
+
+		return false, nil
+	}
+
+	now := metav1.Now()
+
+	d.instance.Status.VolSyncSecret = &rmn.VolSyncSecretStatus{
+		Generation:          nextGeneration,
+		LastRotationTime:    &now,
+		LastRotationRequest: d.instance.GetAnnotations()[VolSyncSecretRotationAnnotation],
+	}
+
+	d.log.Info("Rotated VolSync ssh secret", "secretName", nextSecretNameCluster)
+
+	return true, nil
+}
+
+// deleteVolSyncSSHSecret deletes the ssh keys of a previous generation on the hub, and stops propagating them. The
+// VRGs delete the keys propagated to the clusters, once they no longer use them.
+func (d *DRPCInstance) deleteVolSyncSSHSecret(sshSecretNameCluster string) error {
+	err := volsync.DeleteSecretPropagation(d.ctx, d.reconciler.Client, sshSecretNameCluster,
+		d.instance.GetNamespace())
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	sshSecretHub := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getVolSyncSSHSecretNameHub(sshSecretNameCluster),
+			Namespace: d.instance.GetNamespace(),
+		},
+	}
+
+	if err := d.reconciler.Delete(d.ctx, sshSecretHub); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ssh secret %s on hub (%w)", sshSecretHub.GetName(), err)
+	}
+
+	return nil
+}
+
+// ensureVRGsUseVolSyncSSHSecret switches the ReplicationDestinations of the Secondary VRGs over to the secret,
+// and once they all report to use it, the ReplicationSources of the Primary VRG. Returns true once all VRGs report
+// to use the secret.
+func (d *DRPCInstance) ensureVRGsUseVolSyncSSHSecret(sshSecretNameCluster string) (bool, error) {
+	for _, replicationState := range []rmn.ReplicationState{rmn.Secondary, rmn.Primary} {
+		switched := true
+
+		for clusterName, vrg := range d.vrgs {
+			updated := false
+
+			err := d.updateVRGManifestWork(clusterName, func(vrg *rmn.VolumeReplicationGroup) (bool, error) {
+				if vrg.Spec.ReplicationState != replicationState ||
+					vrg.Spec.VolSync.SSHSecretName == sshSecretNameCluster {
+					return false, nil
+				}
+
+				vrg.Spec.VolSync.SSHSecretName = sshSecretNameCluster
+				updated = true
+
+				return true, nil
+			})
+			if err != nil {
+				return false, fmt.Errorf("failed to update VolSync ssh secret of VRG on cluster %s - %w", clusterName, err)
+			}
+
+			if vrg.Spec.ReplicationState == replicationState &&
+				(updated || vrg.Status.VolSyncSSHSecretName != sshSecretNameCluster) {
+				switched = false
+			}
+		}
+
+		if !switched {
+			d.log.Info("Waiting for the VRGs to use the VolSync ssh secret", "secretName", sshSecretNameCluster,
+				"replicationState", replicationState)
+
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func getVolSyncSSHSecretNameHub(sshSecretNameCluster string) string {
+	return sshSecretNameCluster + "-hub"
+}
+
+// ensureVolSyncResticSecret creates the password of the restic repositories of
+// the VolSync PVCs on the hub, and propagates it to the clusters, which back
+// up to and restore from the same repositories
+func (d *DRPCInstance) ensureVolSyncResticSecret(clusters []string) error {
+	resticSecretNameHub := fmt.Sprintf("%s-vs-restic-secret-hub", d.instance.GetName())
+
+	resticSecretHub, err := volsync.ReconcileVolSyncResticSecret(d.ctx, d.reconciler.Client, d.instance,
+		resticSecretNameHub, d.instance.GetNamespace(), d.log)
+	if err != nil {
+		d.log.Error(err, "Unable to create restic secret on hub for VolSync")
+
+		return fmt.Errorf("%w", err)
+	}
+
+	resticSecretNameCluster := volsync.GetVolSyncResticSecretNameFromVRGName(d.instance.GetName())
+
+	err = volsync.PropagateSecretToClusters(d.ctx, d.reconciler.Client, resticSecretHub,
+		d.instance, clusters, resticSecretNameCluster, d.instance.GetNamespace(), d.log)
+	if err != nil {
+		d.log.Error(err, "Error propagating restic secret to clusters", "clusters", clusters)
 
 		return fmt.Errorf("%w", err)
 	}
@@ -106,31 +337,115 @@ func (d *DRPCInstance) ensureVolSyncReplicationDestination(srcCluster string) er
 		return WaitForSourceCluster
 	}
 
-	for dstCluster, dstVRG := range d.vrgs {
-		if dstCluster == srcCluster {
-			continue
-		}
+	dstVRGs := map[string]*rmn.VolumeReplicationGroup{}
 
+	for _, dstCluster := range d.volSyncDestinationClusters(srcCluster) {
+		dstVRG := d.vrgs[dstCluster]
 		if dstVRG == nil {
-			return fmt.Errorf("invalid VolSync VRG entry")
+			return fmt.Errorf("failed to find destination VolSync VRG in cluster %s. VRGs %v", dstCluster, d.vrgs)
 		}
 
 		volSyncPVCCount := d.getVolSyncPVCCount(srcCluster)
-		if len(dstVRG.Spec.VolSync.RDSpec) != volSyncPVCCount || d.containsMismatchVolSyncPVCs(srcVRG, dstVRG) {
+		if len(dstVRG.Spec.VolSync.RDSpec) != volSyncPVCCount || d.containsMismatchVolSyncPVCs(srcVRG, dstVRG) ||
+			dstVRG.Spec.VolSync.DestinationName != d.volSyncDestinationName(dstCluster) {
 			err := d.updateDestinationVRG(dstCluster, srcVRG, dstVRG)
 			if err != nil {
 				return fmt.Errorf("failed to update dst VRG on cluster %s - %w", dstCluster, err)
 			}
 		}
 
+		dstVRGs[dstCluster] = dstVRG
+
 		d.log.Info(fmt.Sprintf("Ensured VolSync replication destination for cluster %s", dstCluster))
-		// TODO: Should we handle more than one dstVRG? For now, just settle for one.
-		break
+	}
+
+	if err := d.ensureVolSyncReplicationSources(srcCluster, srcVRG, dstVRGs); err != nil {
+		return fmt.Errorf("failed to update src VRG on cluster %s - %w", srcCluster, err)
 	}
 
 	return nil
 }
 
+// volSyncDestinationClusters returns the clusters of the DRPolicy that the
+// source cluster replicates the PVCs protected by VolSync to, leaving out the
+// clusters being drained, as they must not retain a VRG
+func (d *DRPCInstance) volSyncDestinationClusters(srcCluster string) []string {
+	var dstClusters []string
+
+	for _, clusterName := range rmnutil.DrpolicyClusterNames(d.drPolicy) {
+		if clusterName == srcCluster || drClusterDraining(d.drClusters, clusterName) {
+			continue
+		}
+
+		dstClusters = append(dstClusters, clusterName)
+	}
+
+	return dstClusters
+}
+
+// volSyncDestinations returns the destinations that the source cluster
+// replicates the PVCs protected by VolSync to, with the scheduling intervals of
+// the DRPolicy for them, when the DRPolicy has more than two clusters
+func (d *DRPCInstance) volSyncDestinations(srcCluster string) []rmn.VolSyncDestination {
+	var destinations []rmn.VolSyncDestination
+
+	for _, dstCluster := range d.volSyncDestinationClusters(srcCluster) {
+		destination := d.volSyncDestinationName(dstCluster)
+		if destination == "" {
+			continue
+		}
+
+		destinations = append(destinations, rmn.VolSyncDestination{
+			Name:               destination,
+			SchedulingInterval: d.drPolicy.Spec.VolSyncSchedulingIntervals[dstCluster],
+		})
+	}
+
+	return destinations
+}
+
+// volSyncDestinationName returns the name of the cluster among the destinations
+// of the primary, when the DRPolicy has more than two clusters. The
+// ReplicationDestinations on the cluster, and the ReplicationSources on the
+// primary replicating to them, are named after it, so that the services of the
+// ReplicationDestinations of all the destinations can be told apart.
+func (d *DRPCInstance) volSyncDestinationName(clusterName string) string {
+	const maxClustersOfSingleDestination = 2
+
+	if len(rmnutil.DrpolicyClusterNames(d.drPolicy)) <= maxClustersOfSingleDestination {
+		return ""
+	}
+
+	return clusterName
+}
+
+// volSyncFinalSyncDestinations returns the destinations of the source cluster
+// for the final sync of a relocation, narrowed to the preferred cluster the
+// PVCs are relocated to, so that the final sync completes once they are
+// synchronized to it, whether or not the other destinations are reachable
+func (d *DRPCInstance) volSyncFinalSyncDestinations(srcCluster string) []rmn.VolSyncDestination {
+	destinations := d.volSyncDestinations(srcCluster)
+	target := d.volSyncDestinationName(d.instance.Spec.PreferredCluster)
+
+	for _, destination := range destinations {
+		if destination.Name == target {
+			return []rmn.VolSyncDestination{destination}
+		}
+	}
+
+	return destinations
+}
+
+// isVolSyncDestinationOtherThan returns true if the cluster is one of more
+// than one VolSync destination, other than the target cluster of a relocation,
+// whose data protection the relocation does not wait for
+func (d *DRPCInstance) isVolSyncDestinationOtherThan(clusterName, targetCluster string) bool {
+	vrg := d.vrgs[clusterName]
+
+	return clusterName != targetCluster && d.volSyncDestinationName(clusterName) != "" &&
+		vrg != nil && len(vrg.Spec.VolSync.RDSpec) != 0
+}
+
 func (d *DRPCInstance) containsMismatchVolSyncPVCs(srcVRG *rmn.VolumeReplicationGroup,
 	dstVRG *rmn.VolumeReplicationGroup) bool {
 	for _, protectedPVC := range srcVRG.Status.ProtectedPVCs {
@@ -177,6 +492,109 @@ func (d *DRPCInstance) updateDestinationVRG(clusterName string, srcVRG *rmn.Volu
 	return d.updateVRGSpec(clusterName, dstVRG)
 }
 
+// ensureVolSyncReplicationSources passes the destinations to the source VRG,
+// when more than one, with the scheduling intervals of the replication to them,
+// and the addresses of the ReplicationDestinations published by the
+// destination VRGs, for clusters that are not connected by multicluster
+// service discovery
+func (d *DRPCInstance) ensureVolSyncReplicationSources(srcCluster string,
+	srcVRG *rmn.VolumeReplicationGroup, dstVRGs map[string]*rmn.VolumeReplicationGroup,
+) error {
+	var rsSpecs []rmn.VolSyncReplicationSourceSpec
+
+	destinations := d.volSyncDestinations(srcCluster)
+
+	for _, dstCluster := range d.volSyncDestinationClusters(srcCluster) {
+		destination := d.volSyncDestinationName(dstCluster)
+
+		for _, protectedPVC := range dstVRGs[dstCluster].Status.ProtectedPVCs {
+			if !protectedPVC.ProtectedByVolSync || protectedPVC.RsyncAddress == nil {
+				continue
+			}
+
+			rsSpecs = append(rsSpecs, rmn.VolSyncReplicationSourceSpec{
+				ProtectedPVC: rmn.ProtectedPVC{
+					Name:               protectedPVC.Name,
+					ProtectedByVolSync: true,
+				},
+				RsyncAddress: protectedPVC.RsyncAddress,
+				Destination:  destination,
+			})
+		}
+	}
+
+	if reflect.DeepEqual(srcVRG.Spec.VolSync.RSSpec, rsSpecs) &&
+		reflect.DeepEqual(srcVRG.Spec.VolSync.Destinations, destinations) {
+		return nil
+	}
+
+	d.log.Info(fmt.Sprintf("Updating VolSync RSSpec and destinations of VRG for cluster %s", srcCluster))
+
+	return d.updateVRGManifestWork(srcCluster, func(vrg *rmn.VolumeReplicationGroup) (bool, error) {
+		if vrg.Spec.ReplicationState != rmn.Primary {
+			return false, fmt.Errorf("failed to update MW due to wrong VRG state (%v) for the request",
+				vrg.Spec.ReplicationState)
+		}
+
+		if reflect.DeepEqual(vrg.Spec.VolSync.RSSpec, rsSpecs) &&
+			reflect.DeepEqual(vrg.Spec.VolSync.Destinations, destinations) {
+			return false, nil
+		}
+
+		vrg.Spec.VolSync.RSSpec = rsSpecs
+		vrg.Spec.VolSync.Destinations = destinations
+
+		return true, nil
+	})
+}
+
+// updateVRGManifestWork updates the VRG in the ManifestWork of the cluster with the update function, which returns
+// false if the VRG is up to date
+func (d *DRPCInstance) updateVRGManifestWork(clusterName string,
+	update func(*rmn.VolumeReplicationGroup) (bool, error),
+) error {
+	vrgMWName := d.mwu.BuildManifestWorkName(rmnutil.MWTypeVRG)
+
+	mw, err := d.mwu.FindManifestWork(vrgMWName, clusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to update VRG %s, in namespace %s (%w)", vrgMWName, clusterName, err)
+	}
+
+	vrg, err := d.extractVRGFromManifestWork(mw)
+	if err != nil {
+		d.log.Error(err, "failed to extract VRG state")
+
+		return err
+	}
+
+	updated, err := update(vrg)
+	if err != nil || !updated {
+		return err
+	}
+
+	vrgClientManifest, err := d.mwu.GenerateManifest(vrg)
+	if err != nil {
+		d.log.Error(err, "failed to generate manifest")
+
+		return fmt.Errorf("failed to generate VRG manifest (%w)", err)
+	}
+
+	mw.Spec.Workload.Manifests[0] = *vrgClientManifest
+
+	err = d.reconciler.Update(d.ctx, mw)
+	if err != nil {
+		return fmt.Errorf("failed to update MW (%w)", err)
+	}
+
+	d.log.Info(fmt.Sprintf("Updated VRG ownedby MW %s running in cluster %s", vrgMWName, clusterName))
+
+	return nil
+}
+
 func (d *DRPCInstance) IsVolSyncReplicationRequired(homeCluster string) (bool, error) {
 	if d.volSyncDisabled {
 		d.log.Info("VolSync is disabled")
@@ -260,6 +678,11 @@ func (d *DRPCInstance) updateVRGSpec(clusterName string, tgtVRG *rmn.VolumeRepli
 	}
 
 	vrg.Spec.VolSync.RDSpec = tgtVRG.Spec.VolSync.RDSpec
+	vrg.Spec.VolSync.RecoveryPointRetention = d.drPolicy.Spec.VolSyncRecoveryPointRetention
+	vrg.Spec.VolSync.DestinationName = d.volSyncDestinationName(clusterName)
+	// The addresses were published by the peer clusters for the previous primary
+	vrg.Spec.VolSync.RSSpec = nil
+	vrg.Spec.VolSync.Destinations = nil
 
 	vrgClientManifest, err := d.mwu.GenerateManifest(vrg)
 	if err != nil {
@@ -286,19 +709,14 @@ func (d *DRPCInstance) createVolSyncDestManifestWork(srcCluster string) error {
 		"Last State:", d.getLastDRState(), "homeCluster", srcCluster)
 
 	// Create or update ManifestWork for all the peers
-	for _, dstCluster := range rmnutil.DrpolicyClusterNames(d.drPolicy) {
-		if dstCluster == srcCluster {
-			// skip source cluster
-			continue
-		}
-
+	for _, dstCluster := range d.volSyncDestinationClusters(srcCluster) {
 		err := d.ensureNamespaceExistsOnManagedCluster(dstCluster)
 		if err != nil {
 			return fmt.Errorf("creating ManifestWork couldn't ensure namespace '%s' on cluster %s exists",
 				d.instance.Namespace, dstCluster)
 		}
 
-		vrg := d.generateVRG(rmn.Secondary)
+		vrg := d.generateVRG(dstCluster, rmn.Secondary)
 
 		if err := d.mwu.CreateOrUpdateVRGManifestWork(
 			d.instance.Name, d.instance.Namespace,
@@ -307,9 +725,6 @@ func (d *DRPCInstance) createVolSyncDestManifestWork(srcCluster string) error {
 
 			return fmt.Errorf("failed to create or update VolumeReplicationGroup manifest in namespace %s (%w)", dstCluster, err)
 		}
-
-		// For now, assume only a pair of clusters in the DRClusterSet
-		break
 	}
 
 	return nil
@@ -376,3 +791,167 @@ func (d *DRPCInstance) ResetVolSyncRDOnPrimary(clusterName string) error {
 
 	return nil
 }
+
+// updateVolSyncRecoveryPoints reports in the DRPC status the times that the
+// PVCs protected by VolSync can be failed over to, from the recovery points
+// retained by the secondary VRG of the failover cluster, if any, or else of
+// the first secondary VRG
+func (d *DRPCInstance) updateVolSyncRecoveryPoints() {
+	d.instance.Status.VolSyncRecoveryPoints = nil
+
+	clusterNames := rmnutil.DrpolicyClusterNames(d.drPolicy)
+	if d.instance.Spec.FailoverCluster != "" {
+		clusterNames = append([]string{d.instance.Spec.FailoverCluster}, clusterNames...)
+	}
+
+	for _, clusterName := range clusterNames {
+		vrg := d.vrgs[clusterName]
+		if vrg == nil || !d.isVRGSecondary(vrg) {
+			continue
+		}
+
+		d.instance.Status.VolSyncRecoveryPoints = vrgVolSyncRecoveryPointTimes(vrg)
+
+		return
+	}
+}
+
+// vrgVolSyncRecoveryPointTimes returns the times, newest first, of the
+// recovery points of the PVCs protected by VolSync by the VRG, that each of
+// the PVCs has a recovery point at or before
+func vrgVolSyncRecoveryPointTimes(vrg *rmn.VolumeReplicationGroup) []metav1.Time {
+	var (
+		times    []metav1.Time
+		earliest metav1.Time // the latest of the oldest recovery points of the PVCs
+	)
+
+	for idx := range vrg.Status.ProtectedPVCs {
+		protectedPVC := &vrg.Status.ProtectedPVCs[idx]
+		if !protectedPVC.ProtectedByVolSync {
+			continue
+		}
+
+		if len(protectedPVC.RecoveryPoints) == 0 {
+			return nil
+		}
+
+		oldest := protectedPVC.RecoveryPoints[0].Time
+
+		for _, recoveryPoint := range protectedPVC.RecoveryPoints {
+			times = append(times, recoveryPoint.Time)
+
+			if recoveryPoint.Time.Before(&oldest) {
+				oldest = recoveryPoint.Time
+			}
+		}
+
+		if earliest.Before(&oldest) {
+			earliest = oldest
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[j].Before(&times[i]) })
+
+	recoveryPointTimes := []metav1.Time{}
+
+	for _, recoveryPointTime := range times {
+		if recoveryPointTime.Before(&earliest) {
+			break
+		}
+
+		if len(recoveryPointTimes) != 0 && recoveryPointTimes[len(recoveryPointTimes)-1].Equal(&recoveryPointTime) {
+			continue
+		}
+
+		recoveryPointTimes = append(recoveryPointTimes, recoveryPointTime)
+	}
+
+	if len(recoveryPointTimes) == 0 {
+		return nil
+	}
+
+	return recoveryPointTimes
+}
+
+// validateFailoverRecoveryPointTime returns an error if the failover cluster
+// VRG, while it is still secondary, does not retain a recovery point at or
+// before the recovery point time of the DRPC for each PVC protected by VolSync
+func (d *DRPCInstance) validateFailoverRecoveryPointTime() error {
+	recoveryPointTime := d.instance.Spec.RecoveryPointTime
+	if recoveryPointTime == nil {
+		return nil
+	}
+
+	vrg := d.vrgs[d.instance.Spec.FailoverCluster]
+	if vrg == nil {
+		return fmt.Errorf("VRG on failover cluster %s unknown, unable to fail over to recovery point time %s",
+			d.instance.Spec.FailoverCluster, recoveryPointTime.UTC().Format(time.RFC3339))
+	}
+
+	if !d.isVRGSecondary(vrg) {
+		// The PVCs are being restored from the recovery points already
+		return nil
+	}
+
+	for idx := range vrg.Status.ProtectedPVCs {
+		protectedPVC := &vrg.Status.ProtectedPVCs[idx]
+		if !protectedPVC.ProtectedByVolSync || hasRecoveryPointAtOrBefore(protectedPVC, recoveryPointTime) {
+			continue
+		}
+
+		return fmt.Errorf("pvc %s has no recovery point at or before %s on failover cluster %s",
+			protectedPVC.Name, recoveryPointTime.UTC().Format(time.RFC3339), d.instance.Spec.FailoverCluster)
+	}
+
+	return nil
+}
+
+// validateVolSyncFailoverCluster ensures that the failover cluster is a
+// destination of the PVCs protected by VolSync, that their data is replicated
+// to, unless it has become primary already
+func (d *DRPCInstance) validateVolSyncFailoverCluster() error {
+	if d.volSyncDisabled {
+		return nil
+	}
+
+	failoverVRG := d.vrgs[d.instance.Spec.FailoverCluster]
+	if failoverVRG != nil && (!d.isVRGSecondary(failoverVRG) || len(failoverVRG.Spec.VolSync.RDSpec) != 0) {
+		return nil
+	}
+
+	for clusterName, vrg := range d.vrgs {
+		if clusterName == d.instance.Spec.FailoverCluster {
+			continue
+		}
+
+		for idx := range vrg.Status.ProtectedPVCs {
+			if vrg.Status.ProtectedPVCs[idx].ProtectedByVolSync {
+				return fmt.Errorf("failover cluster %s is not a VolSync destination of pvc %s yet",
+					d.instance.Spec.FailoverCluster, vrg.Status.ProtectedPVCs[idx].Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func hasRecoveryPointAtOrBefore(protectedPVC *rmn.ProtectedPVC, recoveryPointTime *metav1.Time) bool {
+	for _, recoveryPoint := range protectedPVC.RecoveryPoints {
+		if !recoveryPoint.Time.After(recoveryPointTime.Time) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// failoverRecoveryPointTime returns the time the VolSync PVCs are to be
+// restored to, as the VRG on the failover cluster becomes primary, or nil for
+// their latest images
+func (d *DRPCInstance) failoverRecoveryPointTime() *metav1.Time {
+	if d.instance.Spec.Action != rmn.ActionFailover {
+		return nil
+	}
+
+	return d.instance.Spec.RecoveryPointTime.DeepCopy()
+}
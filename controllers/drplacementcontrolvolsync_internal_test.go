@@ -0,0 +1,289 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	gppv1 "github.com/stolostron/governance-policy-propagator/api/v1"
+	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+	"github.com/ramendr/ramen/controllers/volsync"
+)
+
+var _ = Describe("VolSync ssh secret rotation", func() {
+	const (
+		drpcName         = "vs-rotation"
+		drpcNamespace    = "vs-rotation-ns"
+		primaryCluster   = "east"
+		secondaryCluster = "west"
+	)
+
+	var (
+		d         *DRPCInstance
+		k8sClient client.Client
+		clusters  []string
+	)
+
+	secretNameCluster := func(generation int64) string {
+		return volsync.GetVolSyncSSHSecretName(drpcName, generation)
+	}
+
+	// vrgSSHSecretName returns the ssh secret name of the VRG in the ManifestWork of the cluster
+	vrgSSHSecretName := func(clusterName string) string {
+		mw, err := d.mwu.FindManifestWork(d.mwu.BuildManifestWorkName(rmnutil.MWTypeVRG), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		vrg, err := d.extractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+
+		return vrg.Spec.VolSync.SSHSecretName
+	}
+
+	// reportVRGs fakes the VRGs of the clusters, as per their ManifestWorks, reporting the ssh secrets in use
+	reportVRGs := func(primarySecretName, secondarySecretName string) {
+		for clusterName, secretName := range map[string]string{
+			primaryCluster:   primarySecretName,
+			secondaryCluster: secondarySecretName,
+		} {
+			mw, err := d.mwu.FindManifestWork(d.mwu.BuildManifestWorkName(rmnutil.MWTypeVRG), clusterName)
+			Expect(err).NotTo(HaveOccurred())
+
+			vrg, err := d.extractVRGFromManifestWork(mw)
+			Expect(err).NotTo(HaveOccurred())
+
+			vrg.Status.VolSyncSSHSecretName = secretName
+			d.vrgs[clusterName] = vrg
+		}
+	}
+
+	setSecretPropagated := func(generation int64) {
+		policy := &gppv1.Policy{}
+		Expect(k8sClient.Get(context.TODO(), types.NamespacedName{
+			Name: secretNameCluster(generation), Namespace: drpcNamespace,
+		}, policy)).To(Succeed())
+
+		for _, clusterName := range []string{primaryCluster, secondaryCluster} {
+			policy.Status.Status = append(policy.Status.Status, &gppv1.CompliancePerClusterStatus{
+				ClusterName:     clusterName,
+				ComplianceState: gppv1.Compliant,
+			})
+		}
+
+		Expect(k8sClient.Update(context.TODO(), policy)).To(Succeed())
+	}
+
+	isSecretOnHub := func(generation int64) bool {
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{
+			Name: getVolSyncSSHSecretNameHub(secretNameCluster(generation)), Namespace: drpcNamespace,
+		}, &corev1.Secret{})
+		if errors.IsNotFound(err) {
+			return false
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+
+		return true
+	}
+
+	isSecretPropagationPolicy := func(generation int64) bool {
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{
+			Name: secretNameCluster(generation), Namespace: drpcNamespace,
+		}, &gppv1.Policy{})
+		if errors.IsNotFound(err) {
+			return false
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+
+		return true
+	}
+
+	requestRotation := func(request string) {
+		d.instance.SetAnnotations(map[string]string{VolSyncSecretRotationAnnotation: request})
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(rmn.AddToScheme(scheme)).To(Succeed())
+		Expect(ocmworkv1.AddToScheme(scheme)).To(Succeed())
+		Expect(plrv1.AddToScheme(scheme)).To(Succeed())
+		Expect(gppv1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		now := metav1.Now()
+		drpc := &rmn.DRPlacementControl{
+			ObjectMeta: metav1.ObjectMeta{Name: drpcName, Namespace: drpcNamespace, UID: "vs-rotation-uid"},
+			Status: rmn.DRPlacementControlStatus{
+				VolSyncSecret: &rmn.VolSyncSecretStatus{LastRotationTime: &now},
+			},
+		}
+
+		d = &DRPCInstance{
+			reconciler:         &DRPlacementControlReconciler{Client: k8sClient, Scheme: scheme},
+			ctx:                context.TODO(),
+			log:                logr.Discard(),
+			instance:           drpc,
+			volSyncKeyRotation: time.Hour,
+			vrgs:               map[string]*rmn.VolumeReplicationGroup{},
+			mwu: rmnutil.MWUtil{
+				Client:        k8sClient,
+				Ctx:           context.TODO(),
+				Log:           logr.Discard(),
+				InstName:      drpcName,
+				InstNamespace: drpcNamespace,
+			},
+		}
+
+		for clusterName, replicationState := range map[string]rmn.ReplicationState{
+			primaryCluster:   rmn.Primary,
+			secondaryCluster: rmn.Secondary,
+		} {
+			vrg := rmn.VolumeReplicationGroup{
+				TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+				ObjectMeta: metav1.ObjectMeta{Name: drpcName, Namespace: drpcNamespace},
+				Spec: rmn.VolumeReplicationGroupSpec{
+					ReplicationState: replicationState,
+					VolSync:          rmn.VolSyncSpec{SSHSecretName: secretNameCluster(0)},
+				},
+			}
+			Expect(d.mwu.CreateOrUpdateVRGManifestWork(drpcName, drpcNamespace, clusterName, vrg)).To(Succeed())
+		}
+
+		clusters = []string{primaryCluster, secondaryCluster}
+
+		reportVRGs(secretNameCluster(0), secretNameCluster(0))
+		Expect(d.ensureVolSyncSSHSecret(clusters)).To(Succeed())
+		Expect(isSecretOnHub(0)).To(BeTrue())
+		Expect(d.getVolSyncSSHSecretGeneration()).To(Equal(int64(0)))
+	})
+
+	It("Should not rotate the ssh secret before the rotation is due", func() {
+		Expect(d.ensureVolSyncSSHSecret(clusters)).To(Succeed())
+		Expect(isSecretPropagationPolicy(1)).To(BeFalse())
+		Expect(vrgSSHSecretName(primaryCluster)).To(Equal(secretNameCluster(0)))
+		Expect(vrgSSHSecretName(secondaryCluster)).To(Equal(secretNameCluster(0)))
+	})
+
+	It("Should switch the VRGs over to the rotated ssh secret in turn, and then delete the previous one", func() {
+		By("Propagating the secret of the next generation on request")
+		requestRotation("1")
+		Expect(d.ensureVolSyncSSHSecret(clusters)).To(Succeed())
+		Expect(isSecretPropagationPolicy(1)).To(BeTrue())
+		Expect(d.getVolSyncSSHSecretGeneration()).To(Equal(int64(0)))
+		Expect(vrgSSHSecretName(secondaryCluster)).To(Equal(secretNameCluster(0)))
+
+		By("Switching the secondary VRG over once the secret is on all clusters")
+		setSecretPropagated(1)
+		Expect(d.ensureVolSyncSSHSecret(clusters)).To(Succeed())
+		Expect(d.getVolSyncSSHSecretGeneration()).To(Equal(int64(1)))
+		Expect(vrgSSHSecretName(secondaryCluster)).To(Equal(secretNameCluster(1)))
+		Expect(vrgSSHSecretName(primaryCluster)).To(Equal(secretNameCluster(0)))
+
+		By("Waiting for the ReplicationDestinations to use the secret, without rotating again")
+		requestRotation("2")
+		reportVRGs(secretNameCluster(0), secretNameCluster(0))
+		Expect(d.ensureVolSyncSSHSecret(clusters)).To(Succeed())
+		Expect(vrgSSHSecretName(primaryCluster)).To(Equal(secretNameCluster(0)))
+		Expect(d.getVolSyncSSHSecretGeneration()).To(Equal(int64(1)))
+		Expect(isSecretPropagationPolicy(2)).To(BeFalse())
+
+		By("Switching the primary VRG over once the ReplicationDestinations use the secret")
+		reportVRGs(secretNameCluster(0), secretNameCluster(1))
+		Expect(d.ensureVolSyncSSHSecret(clusters)).To(Succeed())
+		Expect(vrgSSHSecretName(primaryCluster)).To(Equal(secretNameCluster(1)))
+		Expect(isSecretOnHub(0)).To(BeTrue())
+		Expect(isSecretPropagationPolicy(0)).To(BeTrue())
+
+		By("Keeping the previous secret until the ReplicationSources use the secret")
+		Expect(d.ensureVolSyncSSHSecret(clusters)).To(Succeed())
+		Expect(isSecretOnHub(0)).To(BeTrue())
+		Expect(isSecretPropagationPolicy(2)).To(BeFalse())
+
+		By("Deleting the previous secret once both VRGs use the secret, and rotating again")
+		reportVRGs(secretNameCluster(1), secretNameCluster(1))
+		Expect(d.ensureVolSyncSSHSecret(clusters)).To(Succeed())
+		Expect(isSecretOnHub(0)).To(BeFalse())
+		Expect(isSecretPropagationPolicy(0)).To(BeFalse())
+		Expect(isSecretOnHub(1)).To(BeTrue())
+		Expect(isSecretPropagationPolicy(2)).To(BeTrue())
+		Expect(d.getVolSyncSSHSecretGeneration()).To(Equal(int64(1)))
+	})
+})
+
+var _ = Describe("VolSync relocation to one of several destinations", func() {
+	var d *DRPCInstance
+
+	BeforeEach(func() {
+		d = &DRPCInstance{
+			instance: &rmn.DRPlacementControl{
+				Spec: rmn.DRPlacementControlSpec{PreferredCluster: "west"},
+			},
+			drPolicy: &rmn.DRPolicy{
+				Spec: rmn.DRPolicySpec{DRClusters: []string{"east", "west", "south"}},
+			},
+			vrgs: map[string]*rmn.VolumeReplicationGroup{},
+		}
+
+		for _, clusterName := range []string{"west", "south"} {
+			d.vrgs[clusterName] = &rmn.VolumeReplicationGroup{
+				Spec: rmn.VolumeReplicationGroupSpec{
+					VolSync: rmn.VolSyncSpec{RDSpec: []rmn.VolSyncReplicationDestinationSpec{{}}},
+				},
+			}
+		}
+	})
+
+	It("Should run the final sync to the preferred cluster only", func() {
+		destinations := d.volSyncFinalSyncDestinations("east")
+		Expect(destinations).To(HaveLen(1))
+		Expect(destinations[0].Name).To(Equal("west"))
+	})
+
+	It("Should run the final sync to all the destinations, if the preferred cluster is none of them", func() {
+		d.instance.Spec.PreferredCluster = "east"
+		Expect(d.volSyncFinalSyncDestinations("east")).To(HaveLen(2))
+	})
+
+	It("Should not wait for the data protection of destinations other than the target", func() {
+		Expect(d.isVolSyncDestinationOtherThan("south", "west")).To(BeTrue())
+		Expect(d.isVolSyncDestinationOtherThan("west", "west")).To(BeFalse())
+		Expect(d.isVolSyncDestinationOtherThan("east", "west")).To(BeFalse())
+	})
+
+	It("Should wait for the data protection of the destination of a DRPolicy of two clusters", func() {
+		d.drPolicy.Spec.DRClusters = []string{"east", "south"}
+		Expect(d.isVolSyncDestinationOtherThan("south", "west")).To(BeFalse())
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	viewv1beta1 "github.com/stolostron/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -44,11 +45,22 @@ type DRPolicyReconciler struct {
 	APIReader         client.Reader
 	Scheme            *runtime.Scheme
 	ObjectStoreGetter ObjectStoreGetter
+	MCVGetter         ManagedClusterViewGetter
 }
 
 // ReasonValidationFailed is set when the DRPolicy could not be validated or is not valid
 const ReasonValidationFailed = "ValidationFailed"
 
+const (
+	mcvTypeStorageClass = "sc"
+
+	// drPolicyStorageClassViewLabel labels the ManagedClusterViews used to read
+	// the StorageClasses of the storage class mappings from a managed cluster,
+	// annotated with the name of their DRPolicy
+	drPolicyStorageClassViewLabel = "ramendr.openshift.io/drpolicy-storageclass-view"
+	drPolicyNameAnnotation        = "drpolicy.ramendr.openshift.io/drpolicy-name"
+)
+
 //nolint:lll
 //+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ramendr.openshift.io,resources=drpolicies/status,verbs=get;update;patch
@@ -61,6 +73,7 @@ const ReasonValidationFailed = "ValidationFailed"
 // +kubebuilder:rbac:groups="",namespace=system,resources=secrets,verbs=get;update
 // +kubebuilder:rbac:groups="policy.open-cluster-management.io",namespace=system,resources=placementbindings,verbs=get;create;update;delete
 // +kubebuilder:rbac:groups="policy.open-cluster-management.io",namespace=system,resources=policies,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -104,11 +117,15 @@ func (r *DRPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	log.Info("create/update")
 
-	reason, err := validateDRPolicy(ctx, drpolicy, drclusters, r.APIReader)
+	reason, err := validateDRPolicy(ctx, drpolicy, drclusters, r.APIReader, r.MCVGetter)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("validate: %w", u.validatedSetFalse(reason, err))
 	}
 
+	if err := u.storageClassViewsPrune(storageClassViewNames(drpolicy)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("storage class views prune: %w", err)
+	}
+
 	if err := u.addLabelsAndFinalizers(); err != nil {
 		return ctrl.Result{}, fmt.Errorf("finalizer add update: %w", u.validatedSetFalse("FinalizerAddFailed", err))
 	}
@@ -123,7 +140,8 @@ func (r *DRPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 func validateDRPolicy(ctx context.Context,
 	drpolicy *ramen.DRPolicy,
 	drclusters *ramen.DRClusterList,
-	apiReader client.Reader) (string, error) {
+	apiReader client.Reader,
+	mcvGetter ManagedClusterViewGetter) (string, error) {
 	// TODO: Ensure DRClusters exist and are validated? Also ensure they are not in a deleted state!?
 	// If new DRPolicy and clusters are deleted, then fail reconciliation?
 	found := 0
@@ -141,6 +159,18 @@ func validateDRPolicy(ctx context.Context,
 			drpolicy.Spec.DRClusters)
 	}
 
+	if _, err := util.ParseSchedulingInterval(drpolicy.Spec.SchedulingInterval); err != nil {
+		return ReasonValidationFailed, err
+	}
+
+	if err := validateStorageClassMappings(drpolicy, mcvGetter); err != nil {
+		return ReasonValidationFailed, err
+	}
+
+	if err := validateVolSyncSchedulingIntervals(drpolicy); err != nil {
+		return ReasonValidationFailed, err
+	}
+
 	err := validatePolicyConflicts(ctx, apiReader, drpolicy, drclusters)
 	if err != nil {
 		return ReasonValidationFailed, err
@@ -149,6 +179,134 @@ func validateDRPolicy(ctx context.Context,
 	return "", nil
 }
 
+// validateVolSyncSchedulingIntervals ensures that the VolSync scheduling
+// intervals are of clusters of the policy, and are valid intervals
+func validateVolSyncSchedulingIntervals(drpolicy *ramen.DRPolicy) error {
+	clusterNames := sets.NewString(util.DrpolicyClusterNames(drpolicy)...)
+
+	for clusterName, schedulingInterval := range drpolicy.Spec.VolSyncSchedulingIntervals {
+		if !clusterNames.Has(clusterName) {
+			return fmt.Errorf("volsync scheduling interval of cluster %s that is not in the policy", clusterName)
+		}
+
+		if _, err := util.ParseSchedulingInterval(schedulingInterval); err != nil {
+			return fmt.Errorf("volsync scheduling interval of cluster %s: %w", clusterName, err)
+		}
+	}
+
+	return nil
+}
+
+// validateStorageClassMappings ensures that each StorageClass mapping names
+// StorageClasses on at least two clusters of the policy, that a volume
+// restored to a cluster maps to a single StorageClass, and that the
+// StorageClasses exist on their clusters with the expected CSI drivers
+func validateStorageClassMappings(drpolicy *ramen.DRPolicy, mcvGetter ManagedClusterViewGetter) error {
+	clusterNames := sets.NewString(util.DrpolicyClusterNames(drpolicy)...)
+	mappedStorageClasses := sets.NewString()
+
+	for i := range drpolicy.Spec.StorageClassMappings {
+		mapping := &drpolicy.Spec.StorageClassMappings[i]
+
+		if len(mapping.StorageClassNames) < 2 {
+			return fmt.Errorf("storage class mapping %d names storage classes on less than two clusters", i)
+		}
+
+		for clusterName, storageClassName := range mapping.StorageClassNames {
+			if !clusterNames.Has(clusterName) {
+				return fmt.Errorf("storage class mapping %d names cluster %s that is not in the policy", i, clusterName)
+			}
+
+			if storageClassName == "" {
+				return fmt.Errorf("storage class mapping %d has an empty storage class name for cluster %s",
+					i, clusterName)
+			}
+
+			mappedStorageClass := clusterName + "/" + storageClassName
+			if mappedStorageClasses.Has(mappedStorageClass) {
+				return fmt.Errorf("storage class %s of cluster %s is in more than one storage class mapping",
+					storageClassName, clusterName)
+			}
+
+			mappedStorageClasses.Insert(mappedStorageClass)
+		}
+
+		for clusterName := range mapping.Provisioners {
+			if _, found := mapping.StorageClassNames[clusterName]; !found {
+				return fmt.Errorf("storage class mapping %d has a provisioner for cluster %s without a storage class",
+					i, clusterName)
+			}
+		}
+
+		for clusterName := range mapping.VolumeAttributes {
+			if _, found := mapping.StorageClassNames[clusterName]; !found {
+				return fmt.Errorf("storage class mapping %d has volume attributes for cluster %s without a storage class",
+					i, clusterName)
+			}
+		}
+	}
+
+	for _, clusterName := range util.DrpolicyClusterNames(drpolicy) {
+		mappings := util.DRPolicyStorageClassMappings(drpolicy, clusterName)
+
+		for i := 1; i < len(mappings); i++ {
+			if mappings[i].PeerStorageClassName == mappings[i-1].PeerStorageClassName &&
+				mappings[i].PeerProvisioner == mappings[i-1].PeerProvisioner {
+				return fmt.Errorf("storage class %s of peer clusters maps to more than one storage class on cluster %s",
+					mappings[i].PeerStorageClassName, clusterName)
+			}
+		}
+	}
+
+	return validateMappedStorageClasses(drpolicy, mcvGetter)
+}
+
+// validateMappedStorageClasses ensures that each StorageClass of a mapping
+// exists on its cluster, as viewed from the hub, and is provisioned by the CSI
+// driver that the mapping names for the cluster, if any
+func validateMappedStorageClasses(drpolicy *ramen.DRPolicy, mcvGetter ManagedClusterViewGetter) error {
+	for i := range drpolicy.Spec.StorageClassMappings {
+		mapping := &drpolicy.Spec.StorageClassMappings[i]
+
+		for _, clusterName := range sets.StringKeySet(mapping.StorageClassNames).List() {
+			storageClassName := mapping.StorageClassNames[clusterName]
+
+			storageClass, err := mcvGetter.GetStorageClassFromManagedCluster(drpolicy.Name, storageClassName,
+				clusterName)
+			if err != nil {
+				return fmt.Errorf("storage class mapping %d storage class %s of cluster %s get: %w",
+					i, storageClassName, clusterName, err)
+			}
+
+			if provisioner := mapping.Provisioners[clusterName]; provisioner != "" &&
+				storageClass.Provisioner != provisioner {
+				return fmt.Errorf("storage class mapping %d storage class %s of cluster %s provisioner is %s, not %s",
+					i, storageClassName, clusterName, storageClass.Provisioner, provisioner)
+			}
+		}
+	}
+
+	return nil
+}
+
+// storageClassViewNames returns the names of the ManagedClusterViews of the
+// StorageClasses mapped by the DRPolicy, keyed by cluster name
+func storageClassViewNames(drpolicy *ramen.DRPolicy) map[string]sets.String {
+	names := map[string]sets.String{}
+
+	for i := range drpolicy.Spec.StorageClassMappings {
+		for clusterName, storageClassName := range drpolicy.Spec.StorageClassMappings[i].StorageClassNames {
+			if names[clusterName] == nil {
+				names[clusterName] = sets.NewString()
+			}
+
+			names[clusterName].Insert(BuildManagedClusterViewName(drpolicy.Name, storageClassName, mcvTypeStorageClass))
+		}
+	}
+
+	return names
+}
+
 func validatePolicyConflicts(ctx context.Context,
 	apiReader client.Reader,
 	drpolicy *ramen.DRPolicy,
@@ -258,6 +416,10 @@ func (u *drpolicyUpdater) deleteDRPolicy(drclusters *ramen.DRClusterList,
 		return fmt.Errorf("drpolicy undeploy: %w", err)
 	}
 
+	if err := u.storageClassViewsPrune(nil); err != nil {
+		return fmt.Errorf("storage class views delete: %w", err)
+	}
+
 	if err := u.finalizerRemove(); err != nil {
 		return fmt.Errorf("finalizer remove update: %w", err)
 	}
@@ -265,6 +427,34 @@ func (u *drpolicyUpdater) deleteDRPolicy(drclusters *ramen.DRClusterList,
 	return nil
 }
 
+// storageClassViewsPrune deletes the ManagedClusterViews of the StorageClasses
+// of the DRPolicy, other than those to keep
+func (u *drpolicyUpdater) storageClassViewsPrune(keep map[string]sets.String) error {
+	for _, clusterName := range util.DrpolicyClusterNames(u.object) {
+		mcvs := &viewv1beta1.ManagedClusterViewList{}
+		if err := u.client.List(u.ctx, mcvs, client.InNamespace(clusterName),
+			client.HasLabels{drPolicyStorageClassViewLabel}); err != nil {
+			return fmt.Errorf("managedclusterviews list: %w", err)
+		}
+
+		for i := range mcvs.Items {
+			mcv := &mcvs.Items[i]
+
+			if mcv.GetAnnotations()[drPolicyNameAnnotation] != u.object.Name || keep[clusterName].Has(mcv.Name) {
+				continue
+			}
+
+			u.log.Info("Deleting ManagedClusterView", "name", mcv.Name, "namespace", mcv.Namespace)
+
+			if err := u.client.Delete(u.ctx, mcv); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("managedclusterview %s/%s delete: %w", mcv.Namespace, mcv.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (u *drpolicyUpdater) validatedSetTrue(reason, message string) error {
 	return u.statusConditionSet(ramen.DRPolicyValidated, metav1.ConditionTrue, reason, message)
 }
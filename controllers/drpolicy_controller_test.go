@@ -26,6 +26,7 @@ import (
 	"github.com/ramendr/ramen/controllers/util"
 	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -193,11 +194,11 @@ var _ = Describe("DrpolicyController", func() {
 	drpolicies := [...]ramen.DRPolicy{
 		{
 			ObjectMeta: metav1.ObjectMeta{Name: "drpolicy0"},
-			Spec:       ramen.DRPolicySpec{DRClusters: clusters[0:2], SchedulingInterval: `00m`},
+			Spec:       ramen.DRPolicySpec{DRClusters: clusters[0:2], SchedulingInterval: `5m`},
 		},
 		{
 			ObjectMeta: metav1.ObjectMeta{Name: "drpolicy1"},
-			Spec:       ramen.DRPolicySpec{DRClusters: clusters[1:3], SchedulingInterval: `9999999d`},
+			Spec:       ramen.DRPolicySpec{DRClusters: clusters[1:3], SchedulingInterval: `1d`},
 		},
 	}
 	var drpolicyObjectMetas [len(drpolicies)]metav1.ObjectMeta
@@ -242,6 +243,92 @@ var _ = Describe("DrpolicyController", func() {
 	Specify("a drpolicy", func() {
 		drpolicyObjectMetaReset(drpolicyNumber)
 	})
+	When("a drpolicy is created with a storage class mapping naming a cluster not in the policy", func() {
+		It("should set its validated status condition's status to false", func() {
+			drp := drpolicy.DeepCopy()
+			drp.Spec.StorageClassMappings = []ramen.StorageClassMapping{{
+				StorageClassNames: map[string]string{"drp-cluster0": "sc-east", "drp-cluster2": "sc-west"},
+			}}
+			Expect(k8sClient.Create(context.TODO(), drp)).To(Succeed())
+			validatedConditionExpect(drp, metav1.ConditionFalse, ContainSubstring("not in the policy"))
+		})
+	})
+	Specify("drpolicy delete", func() {
+		drpolicyDeleteAndConfirm(drpolicy)
+	})
+	Specify("a drpolicy", func() {
+		drpolicyObjectMetaReset(drpolicyNumber)
+	})
+	When("a drpolicy is created with a storage class mapping naming a storage class missing on its cluster", func() {
+		It("should set its validated status condition's status to false", func() {
+			drp := drpolicy.DeepCopy()
+			drp.Spec.StorageClassMappings = []ramen.StorageClassMapping{{
+				StorageClassNames: map[string]string{drp.Spec.DRClusters[0]: "sc-missing-east",
+					drp.Spec.DRClusters[1]: "sc-missing-west"},
+			}}
+			Expect(k8sClient.Create(context.TODO(), drp)).To(Succeed())
+			validatedConditionExpect(drp, metav1.ConditionFalse, ContainSubstring("sc-missing-east"))
+		})
+	})
+	Specify("drpolicy delete", func() {
+		drpolicyDeleteAndConfirm(drpolicy)
+	})
+	Specify("a drpolicy", func() {
+		drpolicyObjectMetaReset(drpolicyNumber)
+	})
+	When("a drpolicy is created with a storage class mapping naming a provisioner other than its storage class's", func() {
+		It("should set its validated status condition's status to false", func() {
+			storageClass := &storagev1.StorageClass{
+				ObjectMeta:  metav1.ObjectMeta{Name: "sc-drpolicy-mapping"},
+				Provisioner: "east.csi.example.com",
+			}
+			Expect(k8sClient.Create(context.TODO(), storageClass)).To(Succeed())
+			drp := drpolicy.DeepCopy()
+			drp.Spec.StorageClassMappings = []ramen.StorageClassMapping{{
+				StorageClassNames: map[string]string{drp.Spec.DRClusters[0]: storageClass.Name,
+					drp.Spec.DRClusters[1]: storageClass.Name},
+				Provisioners: map[string]string{drp.Spec.DRClusters[0]: storageClass.Provisioner,
+					drp.Spec.DRClusters[1]: "west.csi.example.com"},
+			}}
+			Expect(k8sClient.Create(context.TODO(), drp)).To(Succeed())
+			validatedConditionExpect(drp, metav1.ConditionFalse, ContainSubstring("not west.csi.example.com"))
+			Expect(k8sClient.Delete(context.TODO(), storageClass)).To(Succeed())
+		})
+	})
+	Specify("drpolicy delete", func() {
+		drpolicyDeleteAndConfirm(drpolicy)
+	})
+	Specify("a drpolicy", func() {
+		drpolicyObjectMetaReset(drpolicyNumber)
+	})
+	When("a drpolicy is created with a volsync scheduling interval of a cluster not in the policy", func() {
+		It("should set its validated status condition's status to false", func() {
+			drp := drpolicy.DeepCopy()
+			drp.Spec.VolSyncSchedulingIntervals = map[string]string{"drp-cluster0": "10m", "drp-cluster2": "1h"}
+			Expect(k8sClient.Create(context.TODO(), drp)).To(Succeed())
+			validatedConditionExpect(drp, metav1.ConditionFalse, ContainSubstring("not in the policy"))
+		})
+	})
+	Specify("drpolicy delete", func() {
+		drpolicyDeleteAndConfirm(drpolicy)
+	})
+	Specify("a drpolicy", func() {
+		drpolicyObjectMetaReset(drpolicyNumber)
+	})
+	When("a drpolicy is created with a scheduling interval longer than a year", func() {
+		It("should set its validated status condition's status to false", func() {
+			drp := drpolicy.DeepCopy()
+			drp.Spec.SchedulingInterval = `366d`
+			Expect(k8sClient.Create(context.TODO(), drp)).To(Succeed())
+			validatedConditionExpect(drp, metav1.ConditionFalse, ContainSubstring("scheduling interval"))
+		})
+	})
+	Specify("drpolicy delete", func() {
+		drpolicyDeleteAndConfirm(drpolicy)
+	})
+	Specify("a drpolicy", func() {
+		drpolicyObjectMetaReset(drpolicyNumber)
+	})
 	When("a 1st drpolicy is created", func() {
 		It("should create a drcluster manifest work for each cluster specified in a 1st drpolicy", func() {
 			drpolicyCreate(drpolicy)
@@ -294,7 +381,7 @@ var _ = Describe("DrpolicyController", func() {
 							validationErrors.FailedPattern(
 								path.String(),
 								`body`,
-								`^\d+[mhd]$`,
+								`^[1-9]\d{0,5}[mhd]$`,
 								value,
 							).Error(),
 						),
@@ -305,6 +392,10 @@ var _ = Describe("DrpolicyController", func() {
 			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(MatchError(err))
 			drpolicy.Spec.SchedulingInterval = `0`
 			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(MatchError(err))
+			drpolicy.Spec.SchedulingInterval = `00m`
+			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(MatchError(err))
+			drpolicy.Spec.SchedulingInterval = `9999999d`
+			Expect(k8sClient.Create(context.TODO(), drpolicy)).To(MatchError(err))
 		})
 	})
 })
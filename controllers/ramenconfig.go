@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/go-logr/logr"
@@ -52,6 +53,7 @@ const (
 	drClusterOperatorChannelNameDefault               = "alpha"
 	drClusterOperatorCatalogSourceNameDefault         = "ramen-catalog"
 	drClusterOperatorClusterServiceVersionNameDefault = drClusterOperatorPackageNameDefault + ".v0.0.1"
+	drClusterOperatorSubscriptionName                 = operatorNamePrefix + drClusterName + "-subscription"
 )
 
 // FIXME
@@ -200,6 +202,31 @@ func getMaxConcurrentReconciles(log logr.Logger) int {
 	return ramenConfig.MaxConcurrentReconciles
 }
 
+func getMaxConcurrentPVCs(log logr.Logger) int {
+	const defaultMaxConcurrentPVCs = 1
+
+	ramenConfig, err := ReadRamenConfigFile(log)
+	if err != nil {
+		return defaultMaxConcurrentPVCs
+	}
+
+	if ramenConfig.MaxConcurrentPVCs <= 0 {
+		return defaultMaxConcurrentPVCs
+	}
+
+	return ramenConfig.MaxConcurrentPVCs
+}
+
+func getVolSyncSecretRotationInterval(ramenConfig *ramendrv1alpha1.RamenConfig) time.Duration {
+	const defaultVolSyncSecretRotationInterval = 90 * 24 * time.Hour
+
+	if ramenConfig.VolSync.SecretRotationInterval.Duration <= 0 {
+		return defaultVolSyncSecretRotationInterval
+	}
+
+	return ramenConfig.VolSync.SecretRotationInterval.Duration
+}
+
 func ConfigMapNew(
 	namespaceName string,
 	name string,
@@ -95,6 +95,10 @@ type ObjectStorer interface {
 		pv corev1.PersistentVolume) error
 	DownloadPVs(pvKeyPrefix string) (
 		pvList []corev1.PersistentVolume, err error)
+	UploadPVC(pvcKeyPrefix, pvcKeySuffix string,
+		pvc corev1.PersistentVolumeClaim) error
+	DownloadPVCs(pvcKeyPrefix string) (
+		pvcList []corev1.PersistentVolumeClaim, err error)
 	ListKeys(keyPrefix string) (keys []string, err error)
 	DeleteObjects(keyPrefix string) error
 	GetName() string
@@ -325,6 +329,15 @@ func (s *s3ObjectStore) UploadPV(pvKeyPrefix, pvKeySuffix string,
 	return s.UploadTypedObject(pvKeyPrefix, pvKeySuffix, pv)
 }
 
+// UploadPVC uploads the given PVC to the bucket with a key of
+// "<pvcKeyPrefix><v1.PersistentVolumeClaim/><pvcKeySuffix>".
+// - pvcKeyPrefix should have any required delimiters like '/'
+// - OK to call UploadPVC() concurrently from multiple goroutines safely.
+func (s *s3ObjectStore) UploadPVC(pvcKeyPrefix, pvcKeySuffix string,
+	pvc corev1.PersistentVolumeClaim) error {
+	return s.UploadTypedObject(pvcKeyPrefix, pvcKeySuffix, pvc)
+}
+
 // UploadTypedObject uploads to the bucket the given uploadContent with a
 // key of <keyPrefix><objectType/>keySuffix>, where objectType is the type of the
 // uploadContent parameter. OK to call UploadTypedObject() concurrently from
@@ -420,6 +433,27 @@ func (s *s3ObjectStore) DownloadPVs(pvKeyPrefix string) (
 	return pvList, nil
 }
 
+// DownloadPVCs downloads all PVCs in the bucket.
+// - Downloads PVCs with the given key prefix.
+// - If bucket doesn't exists, will return ErrCodeNoSuchBucket "NoSuchBucket"
+func (s *s3ObjectStore) DownloadPVCs(pvcKeyPrefix string) (
+	pvcList []corev1.PersistentVolumeClaim, err error) {
+	objectType := reflect.TypeOf(corev1.PersistentVolumeClaim{})
+	bucket := s.s3Bucket
+
+	result, err := s.DownloadTypedObjects(pvcKeyPrefix, objectType)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download: %s, %w", bucket, err)
+	}
+
+	pvcList, ok := result.([]corev1.PersistentVolumeClaim)
+	if !ok {
+		return nil, fmt.Errorf("unable to download PVC type: got %T", result)
+	}
+
+	return pvcList, nil
+}
+
 // DownloadTypedObjects downloads all objects of the given objectType that have
 // the given key prefix followed by the given object's objectType keyInfix.
 // - Example key prefix:  namespace/vrgName/
@@ -90,6 +90,14 @@ func (fakeObjectStorer) DownloadPVs(pvKeyPrefix string) ([]corev1.PersistentVolu
 	return []corev1.PersistentVolume{}, nil
 }
 
+func (fakeObjectStorer) UploadPVC(pvcKeyPrefix, pvcKeySuffix string, pvc corev1.PersistentVolumeClaim) error {
+	return nil
+}
+
+func (fakeObjectStorer) DownloadPVCs(pvcKeyPrefix string) ([]corev1.PersistentVolumeClaim, error) {
+	return []corev1.PersistentVolumeClaim{}, nil
+}
+
 func (f fakeObjectStorer) ListKeys(keyPrefix string) ([]string, error) {
 	if f.bucketName == bucketListFail {
 		return nil, fmt.Errorf("Failing bucket listing")
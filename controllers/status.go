@@ -53,6 +53,27 @@ const (
 	VRGConditionTypeVolSyncFinalSyncInProgress = "FinalSyncInProgress"
 	VRGConditionTypeVolSyncRepDestinationSetup = "ReplicationDestinationSetup"
 	VRGConditionTypeVolSyncPVsRestored         = "PVsRestored"
+
+	// The method VolSync copies a PVC with, reported as the reason of the
+	// condition of the protected PVC
+	VRGConditionTypeVolSyncCopyMethod = "CopyMethod"
+
+	// The pods of the VolSync movers of the PVC are scheduled
+	VRGConditionTypeVolSyncMoverScheduled = "MoverScheduled"
+
+	// Replication is suspended. The replication of the PVCs protected using
+	// VolSync is paused, while their protection is kept. The PVCs protected
+	// using VolumeReplication, which cannot be paused, report it false with
+	// the SuspendNotSupported reason.
+	VRGConditionTypeSuspended = "Suspended"
+
+	// Warm standby volumes of the PVCs are ready on the secondary cluster, to
+	// be bound as it becomes primary
+	VRGConditionTypeStandbyReady = "StandbyReady"
+
+	// The workloads using the VolSync PVCs are scaled down for their final
+	// sync
+	VRGConditionTypeWorkloadsScaledDown = "WorkloadsScaledDown"
 )
 
 // VRG condition reasons
@@ -74,6 +95,14 @@ const (
 	VRGConditionReasonVolSyncPVsRestored         = "Restored"
 	VRGConditionReasonVolSyncFinalSyncInProgress = "Syncing"
 	VRGConditionReasonVolSyncFinalSyncComplete   = "Synced"
+	VRGConditionReasonVolSyncMoverScheduled      = "Scheduled"
+	VRGConditionReasonVolSyncMoverUnschedulable  = "Unschedulable"
+	VRGConditionReasonSuspended                  = "Suspended"
+	VRGConditionReasonResumed                    = "Resumed"
+	VRGConditionReasonSuspendNotSupported        = "SuspendNotSupported"
+	VRGConditionReasonStandbyDisabled            = "Disabled"
+	VRGConditionReasonWorkloadsScaledDown        = "ScaledDown"
+	VRGConditionReasonWorkloadsRestored          = "Restored"
 )
 
 // Just when VRG has been picked up for reconciliation when nothing has been
@@ -361,3 +390,178 @@ func setVRGConditionTypeVolSyncPVRestoreError(conditions *[]metav1.Condition, ob
 		Message:            message,
 	})
 }
+
+// sets conditions with the method VolSync copies the PVC with
+func setVRGConditionTypeVolSyncCopyMethod(conditions *[]metav1.Condition, observedGeneration int64,
+	copyMethod, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeVolSyncCopyMethod,
+		Reason:             copyMethod,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+	})
+}
+
+// sets conditions when the method VolSync copies the PVC with is invalid
+func setVRGConditionTypeVolSyncCopyMethodError(conditions *[]metav1.Condition, observedGeneration int64,
+	message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeVolSyncCopyMethod,
+		Reason:             VRGConditionReasonError,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the pods of the VolSync movers of the PVC are scheduled
+func setVRGConditionTypeVolSyncMoverScheduled(conditions *[]metav1.Condition, observedGeneration int64,
+	message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeVolSyncMoverScheduled,
+		Reason:             VRGConditionReasonVolSyncMoverScheduled,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+	})
+}
+
+// sets conditions when a pod of the VolSync movers of the PVC fails to schedule
+func setVRGConditionTypeVolSyncMoverUnschedulable(conditions *[]metav1.Condition, observedGeneration int64,
+	message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeVolSyncMoverScheduled,
+		Reason:             VRGConditionReasonVolSyncMoverUnschedulable,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the replication is suspended
+func setVRGSuspendedCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeSuspended,
+		Reason:             VRGConditionReasonSuspended,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+	})
+}
+
+// sets conditions when the replication is to be suspended, but cannot be
+func setVRGSuspendNotSupportedCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeSuspended,
+		Reason:             VRGConditionReasonSuspendNotSupported,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the replication is resumed
+func setVRGResumedCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeSuspended,
+		Reason:             VRGConditionReasonResumed,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the warm standby volumes are ready
+func setVRGStandbyReadyCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeStandbyReady,
+		Reason:             VRGConditionReasonReady,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+	})
+}
+
+// sets conditions when the warm standby volumes are being prepared
+func setVRGStandbyProgressingCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeStandbyReady,
+		Reason:             VRGConditionReasonProgressing,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the warm standby volumes fail to be prepared
+func setVRGStandbyErrorCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeStandbyReady,
+		Reason:             VRGConditionReasonError,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the warm standby is disabled, or the VRG is primary
+func setVRGStandbyDisabledCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeStandbyReady,
+		Reason:             VRGConditionReasonStandbyDisabled,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the workloads are scaled down, and no longer use the
+// VolSync PVCs
+func setVRGWorkloadsScaledDownCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeWorkloadsScaledDown,
+		Reason:             VRGConditionReasonWorkloadsScaledDown,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+	})
+}
+
+// sets conditions when the workloads are being scaled down
+func setVRGWorkloadsScalingDownCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeWorkloadsScaledDown,
+		Reason:             VRGConditionReasonProgressing,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the workloads fail to be scaled down or restored, or
+// pods using the VolSync PVCs have no workload to scale down
+func setVRGWorkloadsScaleDownErrorCondition(conditions *[]metav1.Condition, observedGeneration int64,
+	message string,
+) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeWorkloadsScaledDown,
+		Reason:             VRGConditionReasonError,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
+
+// sets conditions when the workloads are restored to their replicas
+func setVRGWorkloadsRestoredCondition(conditions *[]metav1.Condition, observedGeneration int64, message string) {
+	setStatusCondition(conditions, metav1.Condition{
+		Type:               VRGConditionTypeWorkloadsScaledDown,
+		Reason:             VRGConditionReasonWorkloadsRestored,
+		ObservedGeneration: observedGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            message,
+	})
+}
@@ -268,6 +268,7 @@ var _ = BeforeSuite(func() {
 		APIReader:         k8sManager.GetAPIReader(),
 		Scheme:            k8sManager.GetScheme(),
 		ObjectStoreGetter: fakeObjectStoreGetter{},
+		MCVGetter:         FakeMCVGetter{},
 	}).SetupWithManager(k8sManager)).To(Succeed())
 
 	Expect((&ramencontrollers.DRPolicyReconciler{
@@ -275,6 +276,7 @@ var _ = BeforeSuite(func() {
 		APIReader:         k8sManager.GetAPIReader(),
 		Scheme:            k8sManager.GetScheme(),
 		ObjectStoreGetter: fakeObjectStoreGetter{},
+		MCVGetter:         FakeMCVGetter{},
 	}).SetupWithManager(k8sManager)).To(Succeed())
 
 	err = (&ramencontrollers.VolumeReplicationGroupReconciler{
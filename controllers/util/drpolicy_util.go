@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -96,3 +97,61 @@ func DRPolicyS3Profiles(drpolicy *rmn.DRPolicy, drclusters []rmn.DRCluster) sets
 
 	return mustHaveS3Profiles
 }
+
+// DRPolicyStorageClassMappings returns the StorageClass mappings of the
+// DRPolicy that apply to volumes restored to the named cluster, sorted by the
+// StorageClass and CSI driver names on the peer clusters
+func DRPolicyStorageClassMappings(drpolicy *rmn.DRPolicy, clusterName string) []rmn.VRGStorageClassMapping {
+	mappings := []rmn.VRGStorageClassMapping{}
+
+	for i := range drpolicy.Spec.StorageClassMappings {
+		mapping := &drpolicy.Spec.StorageClassMappings[i]
+
+		storageClassName, found := mapping.StorageClassNames[clusterName]
+		if !found {
+			continue
+		}
+
+		// Peers sharing a StorageClass and CSI driver map to a single entry
+		peers := sets.NewString()
+
+		for peerClusterName, peerStorageClassName := range mapping.StorageClassNames {
+			peer := peerStorageClassName + "/" + mapping.Provisioners[peerClusterName]
+			if peerClusterName == clusterName || peers.Has(peer) {
+				continue
+			}
+
+			peers.Insert(peer)
+
+			vrgMapping := rmn.VRGStorageClassMapping{
+				PeerStorageClassName: peerStorageClassName,
+				PeerProvisioner:      mapping.Provisioners[peerClusterName],
+				StorageClassName:     storageClassName,
+				Provisioner:          mapping.Provisioners[clusterName],
+			}
+
+			if volumeAttributes := mapping.VolumeAttributes[clusterName]; len(volumeAttributes) != 0 {
+				vrgMapping.VolumeAttributes = make(map[string]string, len(volumeAttributes))
+				for key, value := range volumeAttributes {
+					vrgMapping.VolumeAttributes[key] = value
+				}
+			}
+
+			mappings = append(mappings, vrgMapping)
+		}
+	}
+
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].PeerStorageClassName != mappings[j].PeerStorageClassName {
+			return mappings[i].PeerStorageClassName < mappings[j].PeerStorageClassName
+		}
+
+		return mappings[i].PeerProvisioner < mappings[j].PeerProvisioner
+	})
+
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	return mappings
+}
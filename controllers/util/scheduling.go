@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSchedulingInterval is the longest scheduling interval of a DRPolicy
+const MaxSchedulingInterval = 365 * 24 * time.Hour
+
+// ParseSchedulingInterval returns the duration of a scheduling interval in the
+// format <num><m,h,d>, where 'm' means minutes, 'h' means hours and 'd' stands
+// for days. The interval must be at least a minute, and at most
+// MaxSchedulingInterval.
+func ParseSchedulingInterval(schedulingInterval string) (time.Duration, error) {
+	const minLength = 2
+
+	if len(schedulingInterval) < minLength {
+		return 0, fmt.Errorf("scheduling interval %q is invalid", schedulingInterval)
+	}
+
+	num := schedulingInterval[:len(schedulingInterval)-1]
+
+	numInt, err := strconv.ParseUint(num, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("scheduling interval prefix %q cannot be converted to an int value", num)
+	}
+
+	var unit time.Duration
+
+	switch strings.ToLower(schedulingInterval[len(schedulingInterval)-1:]) {
+	case "m":
+		unit = time.Minute
+	case "h":
+		unit = time.Hour
+	case "d":
+		unit = 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("scheduling interval %q is invalid. Unable to parse m/h/d", schedulingInterval)
+	}
+
+	if numInt == 0 || numInt > uint64(MaxSchedulingInterval/unit) {
+		return 0, fmt.Errorf("scheduling interval %q is not between 1m and %s", schedulingInterval,
+			MaxSchedulingInterval)
+	}
+
+	return time.Duration(numInt) * unit, nil
+}
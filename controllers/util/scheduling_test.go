@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("ParseSchedulingInterval", func() {
+	It("should parse intervals in minutes, hours and days", func() {
+		for schedulingInterval, expected := range map[string]time.Duration{
+			"1m":   time.Minute,
+			"90m":  90 * time.Minute,
+			"25H":  25 * time.Hour,
+			"365d": util.MaxSchedulingInterval,
+		} {
+			interval, err := util.ParseSchedulingInterval(schedulingInterval)
+			Expect(err).ToNot(HaveOccurred(), schedulingInterval)
+			Expect(interval).To(Equal(expected), schedulingInterval)
+		}
+	})
+	It("should reject malformed and out of range intervals", func() {
+		for _, schedulingInterval := range []string{"", "m", "10", "10s", "-1h", "0m", "8761h", "366d", "4294967296m"} {
+			_, err := util.ParseSchedulingInterval(schedulingInterval)
+			Expect(err).To(HaveOccurred(), schedulingInterval)
+		}
+	})
+})
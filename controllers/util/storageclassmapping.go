@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// StorageClassMappingFind returns the mapping of the named peer StorageClass,
+// or nil if the StorageClass is not mapped. A mapping naming the peer CSI
+// driver is preferred over one that does not, and is skipped if a peer CSI
+// driver is known and differs.
+func StorageClassMappingFind(mappings []rmn.VRGStorageClassMapping, peerStorageClassName, peerProvisioner string,
+) *rmn.VRGStorageClassMapping {
+	var found *rmn.VRGStorageClassMapping
+
+	for i := range mappings {
+		mapping := &mappings[i]
+
+		if mapping.PeerStorageClassName != peerStorageClassName {
+			continue
+		}
+
+		if peerProvisioner != "" && mapping.PeerProvisioner == peerProvisioner {
+			return mapping
+		}
+
+		if peerProvisioner != "" && mapping.PeerProvisioner != "" {
+			continue
+		}
+
+		if found == nil {
+			found = mapping
+		}
+	}
+
+	return found
+}
+
+// StorageClassMappingApplyToPV rewrites the StorageClass, CSI driver and CSI
+// volume attributes of a PV protected on a peer cluster as the mapping
+// declares them on this cluster
+func StorageClassMappingApplyToPV(mapping *rmn.VRGStorageClassMapping, pv *corev1.PersistentVolume) {
+	pv.Spec.StorageClassName = mapping.StorageClassName
+
+	if pv.Spec.CSI == nil {
+		return
+	}
+
+	if mapping.PeerProvisioner != "" && mapping.Provisioner != "" {
+		pv.Spec.CSI.Driver = mapping.Provisioner
+	}
+
+	if len(mapping.VolumeAttributes) != 0 && pv.Spec.CSI.VolumeAttributes == nil {
+		pv.Spec.CSI.VolumeAttributes = map[string]string{}
+	}
+
+	for key, value := range mapping.VolumeAttributes {
+		pv.Spec.CSI.VolumeAttributes[key] = value
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("StorageClassMappings", func() {
+	drpolicy := &rmn.DRPolicy{Spec: rmn.DRPolicySpec{
+		DRClusters: []string{"east", "west", "north"},
+		StorageClassMappings: []rmn.StorageClassMapping{
+			{
+				StorageClassNames: map[string]string{"east": "gold", "west": "fast", "north": "gold"},
+				Provisioners:      map[string]string{"east": "a.csi", "west": "b.csi", "north": "c.csi"},
+				VolumeAttributes:  map[string]map[string]string{"west": {"pool": "replicated"}},
+			},
+			{
+				StorageClassNames: map[string]string{"east": "silver", "west": "slow"},
+			},
+		},
+	}}
+
+	It("should map the StorageClasses of the peer clusters to those of a cluster", func() {
+		for _, test := range []struct {
+			clusterName string
+			expected    []rmn.VRGStorageClassMapping
+		}{
+			{"west", []rmn.VRGStorageClassMapping{
+				{
+					PeerStorageClassName: "gold", PeerProvisioner: "a.csi",
+					StorageClassName: "fast", Provisioner: "b.csi",
+					VolumeAttributes: map[string]string{"pool": "replicated"},
+				},
+				{
+					PeerStorageClassName: "gold", PeerProvisioner: "c.csi",
+					StorageClassName: "fast", Provisioner: "b.csi",
+					VolumeAttributes: map[string]string{"pool": "replicated"},
+				},
+				{PeerStorageClassName: "silver", StorageClassName: "slow"},
+			}},
+			{"north", []rmn.VRGStorageClassMapping{
+				{
+					PeerStorageClassName: "fast", PeerProvisioner: "b.csi",
+					StorageClassName: "gold", Provisioner: "c.csi",
+				},
+				{
+					PeerStorageClassName: "gold", PeerProvisioner: "a.csi",
+					StorageClassName: "gold", Provisioner: "c.csi",
+				},
+			}},
+			{"south", nil},
+		} {
+			Expect(util.DRPolicyStorageClassMappings(drpolicy, test.clusterName)).To(Equal(test.expected),
+				test.clusterName)
+		}
+	})
+	It("should find the mapping of a peer StorageClass and CSI driver", func() {
+		mappings := []rmn.VRGStorageClassMapping{
+			{PeerStorageClassName: "gold", StorageClassName: "any"},
+			{PeerStorageClassName: "gold", PeerProvisioner: "a.csi", StorageClassName: "fast"},
+			{PeerStorageClassName: "gold", PeerProvisioner: "c.csi", StorageClassName: "faster"},
+			{PeerStorageClassName: "silver", PeerProvisioner: "a.csi", StorageClassName: "slow"},
+		}
+
+		for _, test := range []struct {
+			peerStorageClassName string
+			peerProvisioner      string
+			expected             string
+		}{
+			{"gold", "a.csi", "fast"},
+			{"gold", "c.csi", "faster"},
+			{"gold", "d.csi", "any"},
+			{"gold", "", "any"},
+			{"silver", "", "slow"},
+			{"silver", "d.csi", ""},
+			{"bronze", "a.csi", ""},
+		} {
+			storageClassName := ""
+			if mapping := util.StorageClassMappingFind(mappings, test.peerStorageClassName,
+				test.peerProvisioner); mapping != nil {
+				storageClassName = mapping.StorageClassName
+			}
+
+			Expect(storageClassName).To(Equal(test.expected), test.peerStorageClassName+"/"+test.peerProvisioner)
+		}
+	})
+	It("should rewrite the StorageClass, CSI driver and volume attributes of a PV", func() {
+		for _, test := range []struct {
+			name     string
+			mapping  rmn.VRGStorageClassMapping
+			pv       corev1.PersistentVolumeSpec
+			expected corev1.PersistentVolumeSpec
+		}{
+			{
+				"StorageClass only",
+				rmn.VRGStorageClassMapping{PeerStorageClassName: "gold", StorageClassName: "fast", Provisioner: "b.csi"},
+				csiPVSpec("gold", "a.csi", nil),
+				csiPVSpec("fast", "a.csi", nil),
+			},
+			{
+				"CSI driver and volume attributes",
+				rmn.VRGStorageClassMapping{
+					PeerStorageClassName: "gold", PeerProvisioner: "a.csi",
+					StorageClassName: "fast", Provisioner: "b.csi",
+					VolumeAttributes: map[string]string{"pool": "replicated"},
+				},
+				csiPVSpec("gold", "a.csi", map[string]string{"pool": "local", "size": "1"}),
+				csiPVSpec("fast", "b.csi", map[string]string{"pool": "replicated", "size": "1"}),
+			},
+			{
+				"volume attributes of a PV without",
+				rmn.VRGStorageClassMapping{
+					PeerStorageClassName: "gold", StorageClassName: "fast",
+					VolumeAttributes: map[string]string{"pool": "replicated"},
+				},
+				csiPVSpec("gold", "a.csi", nil),
+				csiPVSpec("fast", "a.csi", map[string]string{"pool": "replicated"}),
+			},
+			{
+				"non-CSI PV",
+				rmn.VRGStorageClassMapping{
+					PeerStorageClassName: "gold", PeerProvisioner: "a.csi",
+					StorageClassName: "fast", Provisioner: "b.csi",
+				},
+				corev1.PersistentVolumeSpec{StorageClassName: "gold"},
+				corev1.PersistentVolumeSpec{StorageClassName: "fast"},
+			},
+		} {
+			mapping := test.mapping
+			pv := &corev1.PersistentVolume{Spec: test.pv}
+			util.StorageClassMappingApplyToPV(&mapping, pv)
+			Expect(pv.Spec).To(Equal(test.expected), test.name)
+		}
+	})
+})
+
+func csiPVSpec(storageClassName, driver string, volumeAttributes map[string]string) corev1.PersistentVolumeSpec {
+	return corev1.PersistentVolumeSpec{
+		StorageClassName: storageClassName,
+		PersistentVolumeSource: corev1.PersistentVolumeSource{
+			CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeAttributes: volumeAttributes},
+		},
+	}
+}
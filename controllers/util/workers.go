@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import "sync"
+
+// ForEachConcurrently calls work for each index in [0, count) with at most
+// workers calls running at the same time, and returns the error of each call
+// at the index of the call. Calls are made in index order with a single
+// worker. The caller is responsible for work not to share mutable state
+// across indices.
+func ForEachConcurrently(count, workers int, work func(idx int) error) []error {
+	errs := make([]error, count)
+
+	if workers <= 1 || count <= 1 {
+		for idx := 0; idx < count; idx++ {
+			errs[idx] = work(idx)
+		}
+
+		return errs
+	}
+
+	if workers > count {
+		workers = count
+	}
+
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	for worker := 0; worker < workers; worker++ {
+		go func() {
+			defer wg.Done()
+
+			for idx := range indices {
+				errs[idx] = work(idx)
+			}
+		}()
+	}
+
+	for idx := 0; idx < count; idx++ {
+		indices <- idx
+	}
+
+	close(indices)
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("ForEachConcurrently", func() {
+	const count = 20
+
+	work := func(running, maxRunning *int32, calls []int32) func(int) error {
+		return func(idx int) error {
+			current := atomic.AddInt32(running, 1)
+			defer atomic.AddInt32(running, -1)
+
+			for {
+				observed := atomic.LoadInt32(maxRunning)
+				if current <= observed || atomic.CompareAndSwapInt32(maxRunning, observed, current) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&calls[idx], 1)
+
+			if idx%3 == 0 {
+				return fmt.Errorf("error %d", idx)
+			}
+
+			return nil
+		}
+	}
+
+	for _, workers := range []int{0, 1, 4, count * 2} {
+		workers := workers
+
+		It(fmt.Sprintf("calls each index once with at most %d workers", workers), func() {
+			var running, maxRunning int32
+
+			calls := make([]int32, count)
+
+			errs := util.ForEachConcurrently(count, workers, work(&running, &maxRunning, calls))
+			Expect(errs).To(HaveLen(count))
+
+			for idx := 0; idx < count; idx++ {
+				Expect(calls[idx]).To(Equal(int32(1)))
+
+				if idx%3 == 0 {
+					Expect(errs[idx]).To(MatchError(fmt.Sprintf("error %d", idx)))
+				} else {
+					Expect(errs[idx]).ToNot(HaveOccurred())
+				}
+			}
+
+			if workers <= 1 {
+				Expect(maxRunning).To(Equal(int32(1)))
+			} else {
+				Expect(maxRunning).To(BeNumerically("<=", workers))
+			}
+		})
+	}
+})
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+// CopyMethodAnnotation on a StorageClass selects the method VolSync copies the
+// PVCs of the StorageClass with. When not set, the PVCs are copied with
+// snapshots, with the VolumeSnapshotClass that matches the provisioner of the
+// StorageClass.
+const CopyMethodAnnotation = "ramendr.openshift.io/volsync-copy-method"
+
+// CopyMethod is the method VolSync copies a PVC with, to replicate a point in
+// time copy of it
+type CopyMethod string
+
+const (
+	// CopyMethodSnapshot copies the PVC with a VolumeSnapshot
+	CopyMethodSnapshot CopyMethod = "Snapshot"
+
+	// CopyMethodClone copies the PVC with a clone of it, for StorageClasses of
+	// CSI drivers that clone volumes. The destination cluster replicates
+	// directly to its PVC, which is cloned on failover.
+	CopyMethodClone CopyMethod = "Clone"
+
+	// CopyMethodDirect replicates the PVC in use, with no copy of it, which
+	// suits filesystems that tolerate being read or written while in use. The
+	// PVC is to be ReadWriteMany, so that the mover mounts it along with the
+	// pods using it.
+	CopyMethodDirect CopyMethod = "Direct"
+)
+
+// copyMethodSpec is the copy method of the PVCs of a StorageClass, with the
+// VolumeSnapshotClass to copy them with, for the Snapshot copy method
+type copyMethodSpec struct {
+	method                  CopyMethod
+	volumeSnapshotClassName string
+}
+
+// GetCopyMethod returns the method VolSync copies a PVC of the StorageClass,
+// with the given access modes, with, as configured by the CopyMethodAnnotation
+// of the StorageClass, or with snapshots otherwise. Returns an error if the PVC
+// cannot be copied with the method.
+func (v *VSHandler) GetCopyMethod(storageClassName *string, accessModes []corev1.PersistentVolumeAccessMode,
+) (CopyMethod, error) {
+	spec, err := v.getCopyMethodSpec(storageClassName, accessModes)
+	if err != nil {
+		return "", err
+	}
+
+	return spec.method, nil
+}
+
+func (v *VSHandler) getCopyMethodSpec(storageClassName *string, accessModes []corev1.PersistentVolumeAccessMode,
+) (copyMethodSpec, error) {
+	storageClass, err := v.getStorageClass(storageClassName)
+	if err != nil {
+		return copyMethodSpec{}, err
+	}
+
+	method, configured := storageClass.GetAnnotations()[CopyMethodAnnotation]
+
+	switch CopyMethod(method) {
+	case CopyMethodClone:
+		return v.cloneCopyMethodSpec(storageClass)
+	case CopyMethodDirect:
+		return directCopyMethodSpec(storageClass, accessModes)
+	case CopyMethodSnapshot:
+	default:
+		if configured {
+			return copyMethodSpec{}, fmt.Errorf("invalid copy method %q of storage class %s, expected %s, %s or %s",
+				method, storageClass.GetName(), CopyMethodSnapshot, CopyMethodClone, CopyMethodDirect)
+		}
+	}
+
+	volumeSnapshotClassName, err := v.findVolumeSnapshotClassName(storageClass.Provisioner)
+	if err != nil {
+		return copyMethodSpec{}, err
+	}
+
+	if volumeSnapshotClassName != "" {
+		return copyMethodSpec{method: CopyMethodSnapshot, volumeSnapshotClassName: volumeSnapshotClassName}, nil
+	}
+
+	if configured {
+		return copyMethodSpec{}, fmt.Errorf("unable to find matching volumesnapshotclass for storage provisioner %s, "+
+			"required by the %s copy method of storage class %s",
+			storageClass.Provisioner, CopyMethodSnapshot, storageClass.GetName())
+	}
+
+	return copyMethodSpec{}, fmt.Errorf("unable to find matching volumesnapshotclass for storage provisioner %s "+
+		"of storage class %s, annotate the storage class with %s to copy its PVCs with the %s or %s copy method",
+		storageClass.Provisioner, storageClass.GetName(), CopyMethodAnnotation, CopyMethodClone, CopyMethodDirect)
+}
+
+// cloneCopyMethodSpec returns the Clone copy method of the PVCs of the
+// StorageClass, if its provisioner is a CSI driver, as only CSI drivers clone
+// volumes
+func (v *VSHandler) cloneCopyMethodSpec(storageClass *storagev1.StorageClass) (copyMethodSpec, error) {
+	csiDriver := &storagev1.CSIDriver{}
+
+	err := v.client.Get(v.ctx, types.NamespacedName{Name: storageClass.Provisioner}, csiDriver)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return copyMethodSpec{}, fmt.Errorf("storage provisioner %s of storage class %s is not a CSI driver, "+
+				"required by the %s copy method to clone its PVCs",
+				storageClass.Provisioner, storageClass.GetName(), CopyMethodClone)
+		}
+
+		return copyMethodSpec{}, fmt.Errorf("error getting CSI driver %s (%w)", storageClass.Provisioner, err)
+	}
+
+	return copyMethodSpec{method: CopyMethodClone}, nil
+}
+
+// directCopyMethodSpec returns the Direct copy method of a PVC of the
+// StorageClass, if the PVC is ReadWriteMany, so that the mover mounts the PVC
+// in use on any node
+func directCopyMethodSpec(storageClass *storagev1.StorageClass, accessModes []corev1.PersistentVolumeAccessMode,
+) (copyMethodSpec, error) {
+	for _, accessMode := range accessModes {
+		if accessMode == corev1.ReadWriteMany {
+			return copyMethodSpec{method: CopyMethodDirect}, nil
+		}
+	}
+
+	return copyMethodSpec{}, fmt.Errorf("%s copy method of storage class %s requires %s PVCs, the PVC is %v",
+		CopyMethodDirect, storageClass.GetName(), corev1.ReadWriteMany, accessModes)
+}
+
+// rsVolumeOptions returns the copy method options of a ReplicationSource
+func (s copyMethodSpec) rsVolumeOptions() volsyncv1alpha1.ReplicationSourceVolumeOptions {
+	switch s.method {
+	case CopyMethodClone:
+		return volsyncv1alpha1.ReplicationSourceVolumeOptions{CopyMethod: volsyncv1alpha1.CopyMethodClone}
+	case CopyMethodDirect:
+		return volsyncv1alpha1.ReplicationSourceVolumeOptions{CopyMethod: volsyncv1alpha1.CopyMethodNone}
+	}
+
+	volumeSnapshotClassName := s.volumeSnapshotClassName
+
+	return volsyncv1alpha1.ReplicationSourceVolumeOptions{
+		CopyMethod:              volsyncv1alpha1.CopyMethodSnapshot,
+		VolumeSnapshotClassName: &volumeSnapshotClassName,
+	}
+}
+
+// rdCopyMethod returns the copy method of a ReplicationDestination. Other
+// than with snapshots, the ReplicationDestination replicates directly to its
+// PVC, which is the latest image to restore the PVC from
+func (s copyMethodSpec) rdCopyMethod() (volsyncv1alpha1.CopyMethodType, *string) {
+	if s.method != CopyMethodSnapshot {
+		return volsyncv1alpha1.CopyMethodNone, nil
+	}
+
+	volumeSnapshotClassName := s.volumeSnapshotClassName
+
+	return volsyncv1alpha1.CopyMethodSnapshot, &volumeSnapshotClassName
+}
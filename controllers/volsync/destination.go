@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// SetDestinations sets the destinations that the ReplicationSources replicate
+// the PVCs to, with a ReplicationSource per PVC and destination. Nil replicates
+// to a single destination.
+func (v *VSHandler) SetDestinations(destinations []ramendrv1alpha1.VolSyncDestination) {
+	v.destinations = destinations
+}
+
+// SetDestinationName sets the name of the owner among the destinations of the
+// Primary, that the names of its ReplicationDestinations end with. Empty for a
+// single destination.
+func (v *VSHandler) SetDestinationName(destinationName string) {
+	v.destinationName = destinationName
+}
+
+// Destinations returns the names of the destinations that the PVCs are
+// replicated to, with a ReplicationSource per PVC and destination, and with the
+// restic mover a restic repository per PVC and destination. The name of a
+// single destination is empty.
+func (v *VSHandler) Destinations() []string {
+	if len(v.destinations) == 0 {
+		return []string{""}
+	}
+
+	names := make([]string, 0, len(v.destinations))
+
+	for _, destination := range v.destinations {
+		names = append(names, destination.Name)
+	}
+
+	return names
+}
+
+// getDestinationSchedulingInterval returns the scheduling interval of the
+// replication to the destination
+func (v *VSHandler) getDestinationSchedulingInterval(destination string) string {
+	for _, dst := range v.destinations {
+		if dst.Name == destination && dst.SchedulingInterval != "" {
+			return dst.SchedulingInterval
+		}
+	}
+
+	return v.schedulingInterval
+}
+
+// CleanupRSNotInDestinations deletes the ReplicationSources of the owner that
+// replicate to destinations that the PVCs are no longer replicated to
+func (v *VSHandler) CleanupRSNotInDestinations() error {
+	currentRSListByOwner, err := v.listRSByOwner()
+	if err != nil {
+		return err
+	}
+
+	destinations := v.Destinations()
+
+	for i := range currentRSListByOwner.Items {
+		rs := currentRSListByOwner.Items[i]
+
+		if rsNameInDestinations(rs.GetName(), rs.Spec.SourcePVC, destinations) {
+			continue
+		}
+
+		// Delete the ReplicationSource, log errors with cleanup but continue on
+		if err := v.client.Delete(v.ctx, &rs); err != nil {
+			v.log.Error(err, "Error cleaning up ReplicationSource", "name", rs.GetName())
+		} else {
+			v.log.Info("Deleted ReplicationSource of a destination no longer replicated to", "name", rs.GetName())
+		}
+	}
+
+	return nil
+}
+
+func rsNameInDestinations(rsName, pvcName string, destinations []string) bool {
+	for _, destination := range destinations {
+		if rsName == getReplicationSourceName(pvcName, destination) {
+			return true
+		}
+	}
+
+	return false
+}
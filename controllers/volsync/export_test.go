@@ -0,0 +1,45 @@
+package volsync
+
+import (
+	"time"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+type Schedule = schedule
+
+func NewSchedule(interval, offset time.Duration) Schedule {
+	return schedule{interval: interval, offset: offset}
+}
+
+func (s schedule) Offset() time.Duration {
+	return s.offset
+}
+
+func (s schedule) CronSpec() (string, bool) {
+	return s.cronSpec()
+}
+
+func (s schedule) CurrentIntervalStart(now time.Time) (time.Time, time.Time) {
+	return s.currentIntervalStart(now)
+}
+
+func (s schedule) ManualTrigger(now time.Time) string {
+	return s.manualTrigger(now)
+}
+
+func (v *VSHandler) GetSchedule(schedulingInterval string) (Schedule, error) {
+	return v.getSchedule(schedulingInterval)
+}
+
+func (v *VSHandler) GetRSTrigger(schedulingInterval string) (*volsyncv1alpha1.ReplicationSourceTriggerSpec, error) {
+	return v.getRSTrigger(schedulingInterval)
+}
+
+func GetReplicationSourceName(pvcName, destination string) string {
+	return getReplicationSourceName(pvcName, destination)
+}
+
+func GetReplicationDestinationName(pvcName, destinationName string) string {
+	return getReplicationDestinationName(pvcName, destinationName)
+}
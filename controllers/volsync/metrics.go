@@ -0,0 +1,399 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+const (
+	metricsKindReplicationSource      = "ReplicationSource"
+	metricsKindReplicationDestination = "ReplicationDestination"
+)
+
+// Labels of the metrics of a mover, with the identity of the VRG and of the
+// DRPC on the hub that the VRG is deployed by, to join them with hub metrics
+var volSyncMetricsLabels = []string{"vrg_namespace", "vrg", "drpc_namespace", "drpc", "pvc", "kind", "destination"}
+
+var (
+	volSyncLastSyncTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_volsync_last_sync_timestamp_seconds",
+			Help: "Time of the last synchronization of a PVC by a VolSync ReplicationSource or ReplicationDestination",
+		},
+		volSyncMetricsLabels,
+	)
+
+	volSyncLastSyncDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_volsync_last_sync_duration_seconds",
+			Help: "Duration of the last synchronization of a PVC by a VolSync ReplicationSource or ReplicationDestination",
+		},
+		volSyncMetricsLabels,
+	)
+
+	volSyncNextSyncTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_volsync_next_sync_timestamp_seconds",
+			Help: "Time of the next scheduled synchronization of a PVC by a VolSync ReplicationSource or " +
+				"ReplicationDestination",
+		},
+		volSyncMetricsLabels,
+	)
+
+	volSyncObservedMoverFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_volsync_observed_mover_failures",
+			Help: "Failed mover pods of a VolSync ReplicationSource or ReplicationDestination that this operator " +
+				"process observed since the last synchronization of a PVC. It is kept in memory, so that it starts " +
+				"over as the process restarts or loses the leadership, and misses failed pods that VolSync deletes " +
+				"before the process observes them",
+		},
+		volSyncMetricsLabels,
+	)
+
+	volSyncFinalSyncDuration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ramen_volsync_final_sync_duration_seconds",
+			Help: "Duration of the final synchronization of a PVC by a VolSync ReplicationSource, as it is relocated",
+		},
+		volSyncMetricsLabels,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		volSyncLastSyncTime,
+		volSyncLastSyncDuration,
+		volSyncNextSyncTime,
+		volSyncObservedMoverFailures,
+		volSyncFinalSyncDuration,
+	)
+}
+
+// moverMetrics tracks the metrics of the mover of a ReplicationSource or
+// ReplicationDestination, to count the failures of its mover since its last
+// synchronization, and to delete its metrics once it is deleted. Counts start
+// over as the process restarts or loses the leadership.
+type moverMetrics struct {
+	owner        types.NamespacedName
+	labels       prometheus.Labels
+	lastSyncTime *metav1.Time
+	jobFailures  map[types.UID]int // failed pods of each mover job since the last synchronization
+}
+
+var (
+	moverMetricsLock  sync.Mutex
+	moverMetricsByUID = map[types.UID]*moverMetrics{}
+)
+
+// moverSyncStatus is the synchronization status of a ReplicationSource or
+// ReplicationDestination that its metrics are derived from
+type moverSyncStatus struct {
+	lastSyncTime     *metav1.Time
+	lastSyncDuration *metav1.Duration
+	nextSyncTime     *time.Time
+}
+
+func (v *VSHandler) updateRSMetrics(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec,
+	rs *volsyncv1alpha1.ReplicationSource,
+) {
+	status := moverSyncStatus{}
+	manualTrigger := ""
+
+	if rs.Spec.Trigger != nil {
+		manualTrigger = rs.Spec.Trigger.Manual
+	}
+
+	var nextSyncTime *metav1.Time
+
+	if rs.Status != nil {
+		status.lastSyncTime = rs.Status.LastSyncTime
+		status.lastSyncDuration = rs.Status.LastSyncDuration
+		nextSyncTime = rs.Status.NextSyncTime
+	}
+
+	status.nextSyncTime = v.getNextSyncTime(nextSyncTime, manualTrigger,
+		v.getDestinationSchedulingInterval(rsSpec.Destination))
+
+	v.updateMoverMetrics(rs, v.getMetricsLabels(metricsKindReplicationSource, rsSpec.ProtectedPVC.Name,
+		rsSpec.Destination), v.getRSMoverJobName(rs.GetName()), status)
+}
+
+func (v *VSHandler) updateRDMetrics(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	rd *volsyncv1alpha1.ReplicationDestination,
+) {
+	status := moverSyncStatus{}
+	manualTrigger := ""
+
+	if rd.Spec.Trigger != nil {
+		manualTrigger = rd.Spec.Trigger.Manual
+	}
+
+	var nextSyncTime *metav1.Time
+
+	if rd.Status != nil {
+		status.lastSyncTime = rd.Status.LastSyncTime
+		status.lastSyncDuration = rd.Status.LastSyncDuration
+		nextSyncTime = rd.Status.NextSyncTime
+	}
+
+	if rd.Spec.Trigger != nil {
+		status.nextSyncTime = v.getNextSyncTime(nextSyncTime, manualTrigger, v.schedulingInterval)
+	}
+
+	v.updateMoverMetrics(rd, v.getMetricsLabels(metricsKindReplicationDestination, rdSpec.ProtectedPVC.Name,
+		v.destinationName), v.getRDMoverJobName(rd.GetName()), status)
+}
+
+// updateFinalSyncMetrics records the duration of the final synchronization of
+// the ReplicationSource, once complete
+func (v *VSHandler) updateFinalSyncMetrics(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec,
+	rs *volsyncv1alpha1.ReplicationSource,
+) {
+	if rs.Status == nil || rs.Status.LastSyncDuration == nil {
+		return
+	}
+
+	volSyncFinalSyncDuration.With(v.getMetricsLabels(metricsKindReplicationSource, rsSpec.ProtectedPVC.Name,
+		rsSpec.Destination)).Set(rs.Status.LastSyncDuration.Seconds())
+}
+
+// getMetricsLabels returns the labels of the metrics of a mover of the owner,
+// with the identity of the DRPC that deploys the owner, as annotated by the
+// DRPC, or else of the DRPC named after the owner
+func (v *VSHandler) getMetricsLabels(kind, pvcName, destination string) prometheus.Labels {
+	drpcName := v.owner.GetAnnotations()[rmnutil.DRPCNameAnnotation]
+	if drpcName == "" {
+		drpcName = v.owner.GetName()
+	}
+
+	drpcNamespace := v.owner.GetAnnotations()[rmnutil.DRPCNamespaceAnnotation]
+	if drpcNamespace == "" {
+		drpcNamespace = v.owner.GetNamespace()
+	}
+
+	return prometheus.Labels{
+		"vrg_namespace":  v.owner.GetNamespace(),
+		"vrg":            v.owner.GetName(),
+		"drpc_namespace": drpcNamespace,
+		"drpc":           drpcName,
+		"pvc":            pvcName,
+		"kind":           kind,
+		"destination":    destination,
+	}
+}
+
+// getNextSyncTime returns the time of the next synchronization, as scheduled
+// by VolSync with a cronspec, or else as triggered by the owner on the schedule
+// of the scheduling interval. Returns nil if none is scheduled, as for a final
+// synchronization.
+func (v *VSHandler) getNextSyncTime(nextSyncTime *metav1.Time, manualTrigger, schedulingInterval string,
+) *time.Time {
+	if nextSyncTime != nil {
+		return &nextSyncTime.Time
+	}
+
+	if !strings.HasPrefix(manualTrigger, ScheduledTriggerPrefix) || schedulingInterval == "" {
+		return nil
+	}
+
+	s, err := v.getSchedule(schedulingInterval)
+	if err != nil {
+		return nil
+	}
+
+	_, next := s.currentIntervalStart(time.Now())
+
+	return &next
+}
+
+func (v *VSHandler) updateMoverMetrics(obj client.Object, labels prometheus.Labels, jobName string,
+	status moverSyncStatus,
+) {
+	failures, err := v.countMoverFailures(obj, labels, jobName, status.lastSyncTime)
+	if err != nil {
+		v.log.Error(err, "Failed to count the failures of the VolSync mover", "name", obj.GetName())
+	} else {
+		volSyncObservedMoverFailures.With(labels).Set(float64(failures))
+	}
+
+	if status.lastSyncTime != nil {
+		volSyncLastSyncTime.With(labels).Set(float64(status.lastSyncTime.Unix()))
+	} else {
+		volSyncLastSyncTime.Delete(labels)
+	}
+
+	if status.lastSyncDuration != nil {
+		volSyncLastSyncDuration.With(labels).Set(status.lastSyncDuration.Seconds())
+	} else {
+		volSyncLastSyncDuration.Delete(labels)
+	}
+
+	if status.nextSyncTime != nil {
+		volSyncNextSyncTime.With(labels).Set(float64(status.nextSyncTime.Unix()))
+	} else {
+		volSyncNextSyncTime.Delete(labels)
+	}
+}
+
+// countMoverFailures returns the number of pods of the mover jobs of the
+// ReplicationSource or ReplicationDestination that failed since its last
+// synchronization. VolSync deletes a mover job as it reaches its backoff limit
+// and runs a new one, so the failures of the jobs are accumulated until the
+// next synchronization, in memory of the process, as VolSync reports no
+// failure count. The count is therefore only of the failures this process
+// observed: it starts over as the process restarts or loses the leadership,
+// and misses the pods of jobs deleted between reconciles.
+func (v *VSHandler) countMoverFailures(obj client.Object, labels prometheus.Labels, jobName string,
+	lastSyncTime *metav1.Time,
+) (int, error) {
+	pods, err := v.listMoverPods(jobName)
+	if err != nil {
+		return 0, err
+	}
+
+	jobFailures := map[types.UID]int{}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		if pod.Status.Phase != corev1.PodFailed {
+			continue
+		}
+
+		// Pods of the mover job that ran the last synchronization failed before it
+		if lastSyncTime != nil && !pod.GetCreationTimestamp().After(lastSyncTime.Time) {
+			continue
+		}
+
+		if job := metav1.GetControllerOf(pod); job != nil {
+			jobFailures[job.UID]++
+		}
+	}
+
+	moverMetricsLock.Lock()
+	defer moverMetricsLock.Unlock()
+
+	m, ok := moverMetricsByUID[obj.GetUID()]
+	if !ok {
+		m = &moverMetrics{
+			owner:  types.NamespacedName{Namespace: v.owner.GetNamespace(), Name: v.owner.GetName()},
+			labels: labels,
+		}
+		moverMetricsByUID[obj.GetUID()] = m
+	}
+
+	if !reflect.DeepEqual(m.labels, labels) {
+		deleteMoverMetrics(m.labels)
+		m.labels = labels
+	}
+
+	if m.jobFailures == nil || !m.lastSyncTime.Equal(lastSyncTime) {
+		m.lastSyncTime = lastSyncTime
+		m.jobFailures = map[types.UID]int{}
+	}
+
+	failures := 0
+
+	for jobUID, count := range jobFailures {
+		if count > m.jobFailures[jobUID] {
+			m.jobFailures[jobUID] = count
+		}
+	}
+
+	for _, count := range m.jobFailures {
+		failures += count
+	}
+
+	return failures, nil
+}
+
+// PruneMetrics deletes the metrics of the movers of the ReplicationSources and
+// ReplicationDestinations of the owner that no longer exist
+func (v *VSHandler) PruneMetrics() error {
+	rsList, err := v.listRSByOwner()
+	if err != nil {
+		return err
+	}
+
+	rdList, err := v.listRDByOwner()
+	if err != nil {
+		return err
+	}
+
+	uids := map[types.UID]struct{}{}
+
+	for i := range rsList.Items {
+		uids[rsList.Items[i].GetUID()] = struct{}{}
+	}
+
+	for i := range rdList.Items {
+		uids[rdList.Items[i].GetUID()] = struct{}{}
+	}
+
+	v.deleteOwnerMetrics(func(uid types.UID) bool {
+		_, exists := uids[uid]
+
+		return !exists
+	})
+
+	return nil
+}
+
+// DeleteMetrics deletes the metrics of all the movers of the owner
+func (v *VSHandler) DeleteMetrics() {
+	v.deleteOwnerMetrics(func(types.UID) bool { return true })
+}
+
+func (v *VSHandler) deleteOwnerMetrics(selected func(types.UID) bool) {
+	owner := types.NamespacedName{Namespace: v.owner.GetNamespace(), Name: v.owner.GetName()}
+
+	moverMetricsLock.Lock()
+	defer moverMetricsLock.Unlock()
+
+	for uid, m := range moverMetricsByUID {
+		if m.owner != owner || !selected(uid) {
+			continue
+		}
+
+		deleteMoverMetrics(m.labels)
+		delete(moverMetricsByUID, uid)
+	}
+}
+
+func deleteMoverMetrics(labels prometheus.Labels) {
+	volSyncLastSyncTime.Delete(labels)
+	volSyncLastSyncDuration.Delete(labels)
+	volSyncNextSyncTime.Delete(labels)
+	volSyncObservedMoverFailures.Delete(labels)
+	volSyncFinalSyncDuration.Delete(labels)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// jobNameLabel labels the pods of a job with the name of the job
+const jobNameLabel = "job-name"
+
+// GetRSMoverSchedulingFailure returns why a pod of the mover of the
+// ReplicationSource fails to schedule, or empty if its pods are scheduled
+func (v *VSHandler) GetRSMoverSchedulingFailure(rsName string) (string, error) {
+	return v.getMoverSchedulingFailure(v.getRSMoverJobName(rsName))
+}
+
+// GetRDMoverSchedulingFailure returns why a pod of the mover of the
+// ReplicationDestination fails to schedule, or empty if its pods are scheduled
+func (v *VSHandler) GetRDMoverSchedulingFailure(rdName string) (string, error) {
+	return v.getMoverSchedulingFailure(v.getRDMoverJobName(rdName))
+}
+
+// getRSMoverJobName returns the name of the job VolSync runs the mover of the
+// ReplicationSource in
+func (v *VSHandler) getRSMoverJobName(rsName string) string {
+	if v.resticRepository != nil {
+		return "volsync-src-" + rsName
+	}
+
+	return "volsync-rsync-src-" + rsName
+}
+
+// getRDMoverJobName returns the name of the job VolSync runs the mover of the
+// ReplicationDestination in
+func (v *VSHandler) getRDMoverJobName(rdName string) string {
+	if v.resticRepository != nil {
+		return "volsync-dst-" + rdName
+	}
+
+	return "volsync-rsync-dest-" + rdName
+}
+
+func (v *VSHandler) listMoverPods(jobName string) (*corev1.PodList, error) {
+	pods := &corev1.PodList{}
+
+	if err := v.client.List(v.ctx, pods, client.InNamespace(v.owner.GetNamespace()),
+		client.MatchingLabels{jobNameLabel: jobName}); err != nil {
+		return nil, fmt.Errorf("failed to list the pods of mover job %s (%w)", jobName, err)
+	}
+
+	return pods, nil
+}
+
+func (v *VSHandler) getMoverSchedulingFailure(jobName string) (string, error) {
+	pods, err := v.listMoverPods(jobName)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+				condition.Reason == corev1.PodReasonUnschedulable {
+				return fmt.Sprintf("mover pod %s unschedulable: %s", pod.GetName(), condition.Message), nil
+			}
+		}
+	}
+
+	return "", nil
+}
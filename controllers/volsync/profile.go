@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// SetVolSyncProfile selects how the ReplicationSources of the rsync mover reach the
+// ReplicationDestinations on the peer cluster. A nil profile exports ClusterIP
+// services through multicluster service discovery.
+func (v *VSHandler) SetVolSyncProfile(profile *ramendrv1alpha1.VolSyncProfile) {
+	v.profile = profile
+}
+
+func (v *VSHandler) getRsyncServiceType() *corev1.ServiceType {
+	if v.profile == nil || v.profile.ServiceType == nil {
+		return &DefaultRsyncServiceType
+	}
+
+	return v.profile.ServiceType
+}
+
+func (v *VSHandler) getRsyncPort() *int32 {
+	if v.profile == nil {
+		return nil
+	}
+
+	return v.profile.Port
+}
+
+// rsyncAddressPublished returns true if the ReplicationSources connect to the
+// address published by the Secondary VRG, instead of the name of the service
+// exported through multicluster service discovery
+func (v *VSHandler) rsyncAddressPublished() bool {
+	if v.resticRepository != nil || v.profile == nil {
+		return false
+	}
+
+	return *v.getRsyncServiceType() != corev1.ServiceTypeClusterIP || v.profile.Address != ""
+}
+
+// GetRsyncAddress returns the address that the ReplicationSource on the peer
+// cluster connects to, for the ready ReplicationDestination. Returns nil if the
+// address is not published, as the ReplicationSource connects to the service
+// exported through multicluster service discovery.
+func (v *VSHandler) GetRsyncAddress(rd *volsyncv1alpha1.ReplicationDestination,
+) (*ramendrv1alpha1.VolSyncRsyncAddress, error) {
+	if !v.rsyncAddressPublished() || rd.Status == nil || rd.Status.Rsync == nil {
+		return nil, nil
+	}
+
+	rsyncAddress := &ramendrv1alpha1.VolSyncRsyncAddress{
+		Address: v.profile.Address,
+		Port:    rd.Status.Rsync.Port,
+	}
+
+	if rsyncAddress.Address == "" {
+		if *v.getRsyncServiceType() == corev1.ServiceTypeNodePort {
+			return nil, fmt.Errorf("no address in the VolSync profile to reach NodePort service of %s", rd.GetName())
+		}
+
+		if rd.Status.Rsync.Address == nil {
+			return nil, nil
+		}
+
+		rsyncAddress.Address = *rd.Status.Rsync.Address
+	}
+
+	if *v.getRsyncServiceType() == corev1.ServiceTypeNodePort {
+		nodePort, err := v.getRsyncNodePort(rd)
+		if err != nil || nodePort == nil {
+			return nil, err
+		}
+
+		rsyncAddress.Port = nodePort
+	}
+
+	return rsyncAddress, nil
+}
+
+// getRsyncNodePort returns the port allocated on the nodes for the service of
+// the ReplicationDestination, or nil if the service has not been created yet
+func (v *VSHandler) getRsyncNodePort(rd *volsyncv1alpha1.ReplicationDestination) (*int32, error) {
+	svc := &corev1.Service{}
+
+	err := v.client.Get(v.ctx, types.NamespacedName{
+		Name:      getLocalServiceNameForRD(rd.GetName()),
+		Namespace: rd.GetNamespace(),
+	}, svc)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	for _, port := range svc.Spec.Ports {
+		if port.NodePort != 0 {
+			nodePort := port.NodePort
+
+			return &nodePort, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getRsyncAddressForRS returns the address of the ReplicationDestination of the
+// PVC that the ReplicationSource connects to. Returns nil if the address is yet
+// to be published by the Secondary VRG.
+func (v *VSHandler) getRsyncAddressForRS(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec,
+) *ramendrv1alpha1.VolSyncRsyncAddress {
+	if rsSpec.RsyncAddress != nil {
+		return rsSpec.RsyncAddress
+	}
+
+	if v.rsyncAddressPublished() {
+		return nil
+	}
+
+	// Remote service address created for the ReplicationDestination on the secondary
+	// The secondary namespace will be the same as primary namespace so use the vrg.Namespace
+	return &ramendrv1alpha1.VolSyncRsyncAddress{
+		Address: getRemoteServiceNameForRDFromPVCName(rsSpec.ProtectedPVC.Name, rsSpec.Destination,
+			v.owner.GetNamespace()),
+		Port: v.getRsyncPort(),
+	}
+}
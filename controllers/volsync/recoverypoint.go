@@ -0,0 +1,294 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RecoveryPointLabel labels the VolumeSnapshots of the images retained as
+// recovery points, with the name of the PVC they are images of
+const RecoveryPointLabel = "ramendr.openshift.io/volsync-recovery-point"
+
+// SetRecoveryPointRetention sets the retention of the images replicated to the
+// ReplicationDestinations as recovery points. Nil retains the latest images
+// only.
+func (v *VSHandler) SetRecoveryPointRetention(retention *ramendrv1alpha1.VolSyncRecoveryPointRetention) {
+	v.recoveryPointRetention = retention
+}
+
+// SetRecoveryPointTime sets the time that EnsurePVCfromRD restores the PVCs
+// to, from their latest recovery points at or before it. Nil restores the
+// PVCs from the latest images of their ReplicationDestinations.
+func (v *VSHandler) SetRecoveryPointTime(recoveryPointTime *metav1.Time) {
+	v.recoveryPointTime = recoveryPointTime
+}
+
+// ReconcileRecoveryPoints retains the latest image of the ReplicationDestination
+// of the PVC as a recovery point, when a retention is set, deletes the recovery
+// points of the PVC that are no longer retained, and returns the ones that are,
+// newest first. The latest image is never deleted, as VolSync still uses it.
+func (v *VSHandler) ReconcileRecoveryPoints(pvcName string) ([]ramendrv1alpha1.VolSyncRecoveryPoint, error) {
+	latestImage, err := v.getRDLatestImage(pvcName)
+	if err != nil {
+		return nil, err
+	}
+
+	latestImageName := ""
+
+	if isLatestImageReady(latestImage) && latestImage.Kind == VolumeSnapshotKind {
+		latestImageName = latestImage.Name
+
+		if v.recoveryPointRetention != nil {
+			if err := v.retainRecoveryPoint(pvcName, latestImageName); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	snapshots, err := v.listRecoveryPoints(client.MatchingLabels{
+		VRGOwnerLabel:      v.owner.GetName(),
+		RecoveryPointLabel: pvcName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryPoints []ramendrv1alpha1.VolSyncRecoveryPoint
+
+	for idx := range snapshots {
+		snap := &snapshots[idx]
+
+		if snap.GetName() != latestImageName && !v.retainsRecoveryPoint(len(recoveryPoints), snap) {
+			v.log.Info("Deleting recovery point no longer retained", "pvcName", pvcName,
+				"snapshotName", snap.GetName())
+
+			if err := v.client.Delete(v.ctx, snap); err != nil && !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("error deleting recovery point %s (%w)", snap.GetName(), err)
+			}
+
+			continue
+		}
+
+		recoveryPoints = append(recoveryPoints, ramendrv1alpha1.VolSyncRecoveryPoint{
+			SnapshotName: snap.GetName(),
+			Time:         recoveryPointTime(snap),
+		})
+	}
+
+	return recoveryPoints, nil
+}
+
+// retainRecoveryPoint labels the VolumeSnapshot of the latest image of the PVC
+// as a recovery point, owned by the owner, that VolSync is not to delete once
+// it replicates the next image
+func (v *VSHandler) retainRecoveryPoint(pvcName, snapshotName string) error {
+	snap := &snapv1.VolumeSnapshot{}
+
+	err := v.client.Get(v.ctx, types.NamespacedName{Name: snapshotName, Namespace: v.owner.GetNamespace()}, snap)
+	if err != nil {
+		return fmt.Errorf("error getting latest image %s of pvc %s (%w)", snapshotName, pvcName, err)
+	}
+
+	labelsUpdated := v.addLabel(snap, VolSyncDoNotDeleteLabel, VolSyncDoNotDeleteLabelVal)
+	labelsUpdated = v.addLabel(snap, RecoveryPointLabel, pvcName) || labelsUpdated
+	labelsUpdated = v.addLabel(snap, VRGOwnerLabel, v.owner.GetName()) || labelsUpdated
+
+	ownerRefUpdated, err := v.addOwnerReference(snap, v.owner)
+	if err != nil {
+		return err
+	}
+
+	if !labelsUpdated && !ownerRefUpdated {
+		return nil
+	}
+
+	if err := v.client.Update(v.ctx, snap); err != nil {
+		return fmt.Errorf("error retaining latest image %s of pvc %s as a recovery point (%w)",
+			snapshotName, pvcName, err)
+	}
+
+	v.log.Info("Retained latest image as a recovery point", "pvcName", pvcName, "snapshotName", snapshotName)
+
+	return nil
+}
+
+// retainsRecoveryPoint returns true if the retention retains the recovery
+// point, after retaining the given number of newer ones
+func (v *VSHandler) retainsRecoveryPoint(retained int, snap *snapv1.VolumeSnapshot) bool {
+	retention := v.recoveryPointRetention
+	if retention == nil {
+		return false
+	}
+
+	if retention.Count != nil && retained >= int(*retention.Count) {
+		return false
+	}
+
+	if retention.MaxAge != nil {
+		snapTime := recoveryPointTime(snap)
+		if time.Since(snapTime.Time) > retention.MaxAge.Duration {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getRecoveryPointImage returns the latest recovery point of the PVC at or
+// before the recovery point time
+func (v *VSHandler) getRecoveryPointImage(pvcName string) (*corev1.TypedLocalObjectReference, error) {
+	snapshots, err := v.listRecoveryPoints(client.MatchingLabels{
+		VRGOwnerLabel:      v.owner.GetName(),
+		RecoveryPointLabel: pvcName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range snapshots {
+		snapTime := recoveryPointTime(&snapshots[idx])
+		if snapTime.After(v.recoveryPointTime.Time) {
+			continue
+		}
+
+		return &corev1.TypedLocalObjectReference{
+			APIGroup: &snapv1.SchemeGroupVersion.Group,
+			Kind:     VolumeSnapshotKind,
+			Name:     snapshots[idx].GetName(),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recovery point of pvc %s at or before %s", pvcName,
+		v.recoveryPointTime.UTC().Format(time.RFC3339))
+}
+
+// CleanupRecoveryPointsNotInSpecList deletes the recovery points of the PVCs
+// that are not in the RDSpec list, except for the ones that PVCs are restored
+// from, which are deleted with the PVCs
+func (v *VSHandler) CleanupRecoveryPointsNotInSpecList(
+	rdSpecList []ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+) error {
+	snapshots, err := v.listRecoveryPoints(client.MatchingLabels{VRGOwnerLabel: v.owner.GetName()},
+		client.HasLabels{RecoveryPointLabel})
+	if err != nil {
+		return err
+	}
+
+	for idx := range snapshots {
+		snap := &snapshots[idx]
+		pvcName := snap.GetLabels()[RecoveryPointLabel]
+
+		if rdSpecListContainsPVC(rdSpecList, pvcName) {
+			continue
+		}
+
+		restored, err := v.isPVCRestoredFrom(pvcName, snap.GetName())
+		if err != nil {
+			return err
+		}
+
+		if restored {
+			continue
+		}
+
+		v.log.Info("Deleting recovery point of pvc no longer replicated", "pvcName", pvcName,
+			"snapshotName", snap.GetName())
+
+		if err := v.client.Delete(v.ctx, snap); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting recovery point %s (%w)", snap.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func rdSpecListContainsPVC(rdSpecList []ramendrv1alpha1.VolSyncReplicationDestinationSpec, pvcName string) bool {
+	for _, rdSpec := range rdSpecList {
+		if rdSpec.ProtectedPVC.Name == pvcName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *VSHandler) isPVCRestoredFrom(pvcName, snapshotName string) (bool, error) {
+	pvc, err := v.getPVC(pvcName)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	dataSource := pvc.Spec.DataSource
+
+	return dataSource != nil && dataSource.Kind == VolumeSnapshotKind && dataSource.Name == snapshotName, nil
+}
+
+// listRecoveryPoints lists the recovery points of the owner, newest first,
+// leaving out the ones being deleted
+func (v *VSHandler) listRecoveryPoints(opts ...client.ListOption) ([]snapv1.VolumeSnapshot, error) {
+	snapList := &snapv1.VolumeSnapshotList{}
+
+	opts = append(opts, client.InNamespace(v.owner.GetNamespace()))
+
+	if err := v.client.List(v.ctx, snapList, opts...); err != nil {
+		return nil, fmt.Errorf("error listing recovery points (%w)", err)
+	}
+
+	snapshots := make([]snapv1.VolumeSnapshot, 0, len(snapList.Items))
+
+	for idx := range snapList.Items {
+		if snapList.Items[idx].GetDeletionTimestamp().IsZero() {
+			snapshots = append(snapshots, snapList.Items[idx])
+		}
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		iTime, jTime := recoveryPointTime(&snapshots[i]), recoveryPointTime(&snapshots[j])
+		if iTime.Equal(&jTime) {
+			return snapshots[i].GetName() > snapshots[j].GetName()
+		}
+
+		return jTime.Before(&iTime)
+	})
+
+	return snapshots, nil
+}
+
+// recoveryPointTime returns the time the snapshot of the image was taken at,
+// or created at, until the snapshot is taken
+func recoveryPointTime(snap *snapv1.VolumeSnapshot) metav1.Time {
+	if snap.Status != nil && snap.Status.CreationTime != nil {
+		return *snap.Status.CreationTime
+	}
+
+	return snap.GetCreationTimestamp()
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+const (
+	// Restic keeps the snapshots taken within a day of the latest one, unless
+	// the recovery point retention says otherwise, and VolSync prunes the data
+	// of the others from the repository weekly
+	resticRetainWithin = "1d"
+
+	resticRepositoryKey      = "RESTIC_REPOSITORY"
+	resticAccessKeyIDKey     = "AWS_ACCESS_KEY_ID"
+	resticSecretAccessKeyKey = "AWS_SECRET_ACCESS_KEY"
+	resticRegionKey          = "AWS_DEFAULT_REGION"
+)
+
+// ResticRepository locates the restic repositories of the PVCs of the owner in
+// an S3 store. The data of each PVC is backed up to a repository of its own
+// per destination, at the name of its ReplicationSource under the key prefix,
+// that the ReplicationDestination of the same name on the destination restores
+// from.
+type ResticRepository struct {
+	S3CompatibleEndpoint string
+	S3Bucket             string
+	S3Region             string
+	KeyPrefix            string
+	AccessKeyID          []byte
+	SecretAccessKey      []byte
+}
+
+// SetResticRepository makes the ReplicationSources and ReplicationDestinations
+// replicate with the restic mover through the given repository, instead of
+// with the rsync mover
+func (v *VSHandler) SetResticRepository(repository *ResticRepository) {
+	v.resticRepository = repository
+}
+
+// repositoryURL returns the restic repository of the ReplicationSource or
+// ReplicationDestination, in the form restic expects for S3 compatible stores
+func (r *ResticRepository) repositoryURL(name string) string {
+	return fmt.Sprintf("s3:%s/%s/%s%s", strings.TrimSuffix(r.S3CompatibleEndpoint, "/"), r.S3Bucket,
+		r.KeyPrefix, name)
+}
+
+// reconcileMoverSecret returns the name of the secret the mover of the
+// ReplicationSource or ReplicationDestination of the given name authenticates
+// with: the shared ssh keys for rsync, or its repository for restic. Returns
+// false if the secret propagated from the hub does not exist yet.
+func (v *VSHandler) reconcileMoverSecret(name string) (string, bool, error) {
+	if v.resticRepository != nil {
+		return v.reconcileResticRepositorySecret(name)
+	}
+
+	// Pre-allocated shared secret - DRPC will generate and propagate this secret from hub to clusters,
+	// and propagate a secret with a new name each time it rotates the keys
+	sshKeysSecretName := v.GetSSHSecretName()
+
+	// Need to confirm this secret exists on the cluster before proceeding, otherwise volsync will generate it
+	secretExists, err := v.validateSecretAndAddVRGOwnerRef(sshKeysSecretName)
+
+	return sshKeysSecretName, secretExists, err
+}
+
+// reconcileResticRepositorySecret creates or updates the secret with the
+// restic repository of the ReplicationSource or ReplicationDestination of the
+// given name, the S3 store credentials, and the repository password propagated
+// from the hub. Both clusters derive the same repository from the same name,
+// so the ReplicationDestination restores what the ReplicationSource backs up.
+func (v *VSHandler) reconcileResticRepositorySecret(name string) (string, bool, error) {
+	resticSecretName := GetVolSyncResticSecretNameFromVRGName(v.owner.GetName())
+
+	resticSecret, err := v.getSecretAndAddVRGOwnerRef(resticSecretName)
+	if err != nil || resticSecret == nil {
+		return "", false, err
+	}
+
+	password, ok := resticSecret.Data[ResticPasswordKey]
+	if !ok {
+		return "", false, fmt.Errorf("secret %s has no restic password", resticSecretName)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getResticRepositorySecretName(name),
+			Namespace: v.owner.GetNamespace(),
+		},
+	}
+
+	op, err := ctrlutil.CreateOrUpdate(v.ctx, v.client, secret, func() error {
+		if err := ctrl.SetControllerReference(v.owner, secret, v.client.Scheme()); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		addVRGOwnerLabel(v.owner, secret)
+
+		secret.Data = map[string][]byte{
+			resticRepositoryKey:      []byte(v.resticRepository.repositoryURL(name)),
+			ResticPasswordKey:        password,
+			resticAccessKeyIDKey:     v.resticRepository.AccessKeyID,
+			resticSecretAccessKeyKey: v.resticRepository.SecretAccessKey,
+			resticRegionKey:          []byte(v.resticRepository.S3Region),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("error creating or updating restic repository secret %s (%w)", secret.Name, err)
+	}
+
+	v.log.V(1).Info("Restic repository secret createOrUpdate Complete", "secretName", secret.Name, "op", op)
+
+	return secret.Name, true, nil
+}
+
+// setRDRestic configures the ReplicationDestination to restore the latest
+// backup from the restic repository on the replication schedule
+func (v *VSHandler) setRDRestic(rd *volsyncv1alpha1.ReplicationDestination, repositorySecretName string,
+	volumeOptions volsyncv1alpha1.ReplicationDestinationVolumeOptions,
+) error {
+	trigger, err := v.getRDTrigger()
+	if err != nil {
+		v.log.Error(err, "unable to parse schedulingInterval")
+
+		return err
+	}
+
+	rd.Spec.Trigger = trigger
+	rd.Spec.Rsync = nil
+	rd.Spec.Restic = &volsyncv1alpha1.ReplicationDestinationResticSpec{
+		Repository: repositorySecretName,
+
+		ReplicationDestinationVolumeOptions: volumeOptions,
+	}
+
+	return nil
+}
+
+// setRSRestic configures the ReplicationSource to back up to the restic
+// repository, retaining the backups as per the recovery point retention
+func setRSRestic(rs *volsyncv1alpha1.ReplicationSource, repositorySecretName string,
+	volumeOptions volsyncv1alpha1.ReplicationSourceVolumeOptions,
+	retention *ramendrv1alpha1.VolSyncRecoveryPointRetention,
+) {
+	rs.Spec.Rsync = nil
+	rs.Spec.Restic = &volsyncv1alpha1.ReplicationSourceResticSpec{
+		Repository: repositorySecretName,
+		Retain:     resticRetainPolicy(retention),
+
+		ReplicationSourceVolumeOptions: volumeOptions,
+	}
+}
+
+// resticRetainPolicy returns the restic retain policy of the backups of a PVC:
+// those taken within the maxAge of the recovery point retention, or else the
+// latest backup of each of the last count hours, or else those taken within
+// resticRetainWithin
+func resticRetainPolicy(retention *ramendrv1alpha1.VolSyncRecoveryPointRetention,
+) *volsyncv1alpha1.ResticRetainPolicy {
+	retainWithin := resticRetainWithin
+
+	switch {
+	case retention == nil:
+	case retention.MaxAge != nil:
+		hours := int64(math.Ceil(retention.MaxAge.Hours()))
+		if hours < 1 {
+			hours = 1
+		}
+
+		retainWithin = fmt.Sprintf("%dh", hours)
+	case retention.Count != nil:
+		hourly := *retention.Count
+
+		return &volsyncv1alpha1.ResticRetainPolicy{Hourly: &hourly}
+	}
+
+	return &volsyncv1alpha1.ResticRetainPolicy{Within: &retainWithin}
+}
+
+func getResticRepositorySecretName(name string) string {
+	return fmt.Sprintf("volsync-%s-restic", name)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// ScheduledTriggerPrefix prefixes the manual triggers that Ramen sets on the
+// ReplicationSources, and restic ReplicationDestinations, to synchronize at
+// the start of each scheduling interval, for intervals that a cronspec can
+// not express
+const ScheduledTriggerPrefix = "vrg-scheduled-"
+
+// schedule is when the ReplicationSources of an owner synchronize. Intervals
+// that evenly divide an hour or a day are scheduled by VolSync with a cronspec.
+// Other intervals are scheduled by Ramen with manual triggers, at the start of
+// each interval since the Unix epoch. Either way, the owner is scheduled at a
+// deterministic offset into the interval, so that the ReplicationSources of
+// all owners with the same interval do not synchronize at the same time.
+type schedule struct {
+	interval time.Duration
+	offset   time.Duration
+}
+
+// Convert from schedulingInterval which is in the format of <num><m,h,d>
+// to the format VolSync expects, which is cronspec: https://en.wikipedia.org/wiki/Cron#Overview
+// Returns an error for intervals that a cronspec can not express, such as
+// intervals that do not evenly divide an hour or a day
+func ConvertSchedulingIntervalToCronSpec(schedulingInterval string) (*string, error) {
+	interval, err := rmnutil.ParseSchedulingInterval(schedulingInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	cronSpec, ok := schedule{interval: interval}.cronSpec()
+	if !ok {
+		return nil, fmt.Errorf("scheduling interval %s can not be expressed as a cronspec", schedulingInterval)
+	}
+
+	return &cronSpec, nil
+}
+
+// getSchedule returns the schedule of the owner on the scheduling interval,
+// offset into the interval by a hash of the namespace and name of the owner,
+// which are the same on the peer clusters
+func (v *VSHandler) getSchedule(schedulingInterval string) (schedule, error) {
+	interval, err := rmnutil.ParseSchedulingInterval(schedulingInterval)
+	if err != nil {
+		return schedule{}, err
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(v.owner.GetNamespace() + "/" + v.owner.GetName()))
+
+	offset := time.Duration(uint64(hash.Sum32())%uint64(interval/time.Minute)) * time.Minute
+
+	return schedule{interval: interval, offset: offset}, nil
+}
+
+// cronSpec returns the cronspec of the schedule, or false if the interval does
+// not evenly divide an hour or a day
+func (s schedule) cronSpec() (string, bool) {
+	const (
+		minutesPerHour = 60
+		hoursPerDay    = 24
+	)
+
+	minutes := int64(s.interval / time.Minute)
+	offset := int64(s.offset / time.Minute)
+
+	switch {
+	case s.interval%time.Minute != 0:
+		return "", false
+	case minutes < minutesPerHour && minutesPerHour%minutes == 0:
+		return fmt.Sprintf("%s * * * *", cronStep(offset, minutes)), true
+	case minutes%minutesPerHour != 0:
+		return "", false
+	}
+
+	hours := minutes / minutesPerHour
+
+	switch {
+	case hours == 1:
+		return fmt.Sprintf("%d * * * *", offset), true
+	case hours == hoursPerDay:
+		return fmt.Sprintf("%d %d * * *", offset%minutesPerHour, offset/minutesPerHour), true
+	case hours < hoursPerDay && hoursPerDay%hours == 0:
+		return fmt.Sprintf("%d %s * * *", offset%minutesPerHour, cronStep(offset/minutesPerHour, hours)), true
+	}
+
+	return "", false
+}
+
+// cronStep returns a cronspec field that starts at start, and repeats every step
+func cronStep(start, step int64) string {
+	if start == 0 {
+		return fmt.Sprintf("*/%d", step)
+	}
+
+	return fmt.Sprintf("%d/%d", start, step)
+}
+
+// currentIntervalStart returns the start of the current interval of the
+// schedule, and the start of the next one
+func (s schedule) currentIntervalStart(now time.Time) (time.Time, time.Time) {
+	elapsed := now.Sub(time.Unix(0, 0).Add(s.offset))
+	start := now.Add(-(elapsed % s.interval))
+
+	if elapsed < 0 {
+		start = start.Add(-s.interval)
+	}
+
+	return start, start.Add(s.interval)
+}
+
+// manualTrigger returns the manual trigger of the current interval of the
+// schedule
+func (s schedule) manualTrigger(now time.Time) string {
+	start, _ := s.currentIntervalStart(now)
+
+	return ScheduledTriggerPrefix + start.UTC().Format(time.RFC3339)
+}
+
+// getRSTrigger returns the trigger of a ReplicationSource on the schedule of
+// the scheduling interval
+func (v *VSHandler) getRSTrigger(schedulingInterval string) (*volsyncv1alpha1.ReplicationSourceTriggerSpec, error) {
+	if schedulingInterval == "" {
+		// Use default value if not specified
+		v.log.Info("Warning - scheduling interval is empty, using default Schedule for volsync",
+			"DefaultScheduleCronSpec", DefaultScheduleCronSpec)
+
+		return &volsyncv1alpha1.ReplicationSourceTriggerSpec{Schedule: &DefaultScheduleCronSpec}, nil
+	}
+
+	s, err := v.getSchedule(schedulingInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	if cronSpec, ok := s.cronSpec(); ok {
+		return &volsyncv1alpha1.ReplicationSourceTriggerSpec{Schedule: &cronSpec}, nil
+	}
+
+	return &volsyncv1alpha1.ReplicationSourceTriggerSpec{Manual: s.manualTrigger(time.Now())}, nil
+}
+
+// getRDTrigger returns the trigger of a restic ReplicationDestination on the
+// schedule
+func (v *VSHandler) getRDTrigger() (*volsyncv1alpha1.ReplicationDestinationTriggerSpec, error) {
+	rsTrigger, err := v.getRSTrigger(v.schedulingInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &volsyncv1alpha1.ReplicationDestinationTriggerSpec{
+		Schedule: rsTrigger.Schedule,
+		Manual:   rsTrigger.Manual,
+	}, nil
+}
+
+// NextScheduledSync returns the time the owner is to trigger the next
+// synchronization of its ReplicationSources, to any of the destinations, and
+// restic ReplicationDestinations, at, or nil if VolSync schedules them all
+// with a cronspec
+func (v *VSHandler) NextScheduledSync() *time.Time {
+	var nextSync *time.Time
+
+	for _, destination := range v.Destinations() {
+		schedulingInterval := v.getDestinationSchedulingInterval(destination)
+		if schedulingInterval == "" {
+			continue
+		}
+
+		s, err := v.getSchedule(schedulingInterval)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := s.cronSpec(); ok {
+			continue
+		}
+
+		_, next := s.currentIntervalStart(time.Now())
+		if nextSync == nil || next.Before(*nextSync) {
+			nextSync = &next
+		}
+	}
+
+	return nextSync
+}
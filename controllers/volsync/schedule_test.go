@@ -0,0 +1,181 @@
+package volsync_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/volsync"
+)
+
+var _ = Describe("VolSync Handler - schedule", func() {
+	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+
+	newVSHandler := func(namespace, name string) *volsync.VSHandler {
+		owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+
+		return volsync.NewVSHandler(ctx, nil, logger, owner, "5m", metav1.LabelSelector{})
+	}
+
+	Context("When offsetting the schedule of an owner into the interval", func() {
+		It("Should offset the owner by the same whole minutes on every cluster", func() {
+			for _, schedulingInterval := range []string{"5m", "1h", "90m", "25h"} {
+				s1, err := newVSHandler("app-ns", "app").GetSchedule(schedulingInterval)
+				Expect(err).ToNot(HaveOccurred())
+				s2, err := newVSHandler("app-ns", "app").GetSchedule(schedulingInterval)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(s1.Offset()).To(Equal(s2.Offset()), schedulingInterval)
+				Expect(s1.Offset() % time.Minute).To(BeZero())
+			}
+		})
+
+		It("Should offset the owners within the interval, apart from each other", func() {
+			offsets := map[time.Duration]bool{}
+
+			for i := 0; i < 20; i++ {
+				s, err := newVSHandler("app-ns", fmt.Sprintf("app-%d", i)).GetSchedule("1h")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(s.Offset()).To(BeNumerically(">=", 0))
+				Expect(s.Offset()).To(BeNumerically("<", time.Hour))
+
+				offsets[s.Offset()] = true
+			}
+
+			Expect(len(offsets)).To(BeNumerically(">", 1))
+		})
+
+		It("Should offset the owners by their namespace as well as their name", func() {
+			offsets := map[time.Duration]bool{}
+
+			for i := 0; i < 20; i++ {
+				s, err := newVSHandler(fmt.Sprintf("app-ns-%d", i), "app").GetSchedule("1h")
+				Expect(err).ToNot(HaveOccurred())
+
+				offsets[s.Offset()] = true
+			}
+
+			Expect(len(offsets)).To(BeNumerically(">", 1))
+		})
+	})
+
+	Context("When expressing the schedule as a cronspec", func() {
+		It("Should start the cronspec at the offset into the interval", func() {
+			for _, test := range []struct {
+				interval time.Duration
+				offset   time.Duration
+				cronSpec string
+			}{
+				{5 * time.Minute, 0, "*/5 * * * *"},
+				{5 * time.Minute, 3 * time.Minute, "3/5 * * * *"},
+				{time.Hour, 42 * time.Minute, "42 * * * *"},
+				{2 * time.Hour, 0, "0 */2 * * *"},
+				{2 * time.Hour, 75 * time.Minute, "15 1/2 * * *"},
+				{24 * time.Hour, 0, "0 0 * * *"},
+				{24 * time.Hour, 13*time.Hour + 7*time.Minute, "7 13 * * *"},
+			} {
+				cronSpec, ok := volsync.NewSchedule(test.interval, test.offset).CronSpec()
+				Expect(ok).To(BeTrue())
+				Expect(cronSpec).To(Equal(test.cronSpec))
+			}
+		})
+
+		It("Should not express intervals that do not evenly divide an hour or a day", func() {
+			for _, interval := range []time.Duration{90 * time.Minute, 25 * time.Hour, 7 * time.Minute, 5 * time.Hour} {
+				_, ok := volsync.NewSchedule(interval, 0).CronSpec()
+				Expect(ok).To(BeFalse(), interval.String())
+			}
+		})
+	})
+
+	Context("When scheduling intervals that a cronspec can not express", func() {
+		epoch := time.Unix(0, 0)
+
+		It("Should start the intervals at the offset from the Unix epoch", func() {
+			for _, test := range []struct {
+				interval time.Duration
+				offset   time.Duration
+			}{
+				{90 * time.Minute, 0},
+				{90 * time.Minute, 7 * time.Minute},
+				{25 * time.Hour, 0},
+				{25 * time.Hour, 1000 * time.Minute},
+			} {
+				s := volsync.NewSchedule(test.interval, test.offset)
+				intervalStart := epoch.Add(test.offset).Add(1000 * test.interval)
+
+				for _, elapsed := range []time.Duration{0, time.Second, test.interval / 2, test.interval - time.Second} {
+					start, next := s.CurrentIntervalStart(intervalStart.Add(elapsed))
+					Expect(start).To(BeTemporally("==", intervalStart), "%v %v", test, elapsed)
+					Expect(next).To(BeTemporally("==", intervalStart.Add(test.interval)), "%v %v", test, elapsed)
+				}
+
+				start, _ := s.CurrentIntervalStart(intervalStart.Add(test.interval))
+				Expect(start).To(BeTemporally("==", intervalStart.Add(test.interval)))
+			}
+		})
+
+		It("Should start the intervals before the offset into the first interval since the Unix epoch", func() {
+			s := volsync.NewSchedule(90*time.Minute, 30*time.Minute)
+
+			start, next := s.CurrentIntervalStart(epoch.Add(10 * time.Minute))
+			Expect(start).To(BeTemporally("==", epoch.Add(-60*time.Minute)))
+			Expect(next).To(BeTemporally("==", epoch.Add(30*time.Minute)))
+		})
+
+		It("Should trigger once per interval", func() {
+			s := volsync.NewSchedule(25*time.Hour, 7*time.Minute)
+			intervalStart := epoch.Add(7 * time.Minute).Add(500 * 25 * time.Hour)
+
+			trigger := s.ManualTrigger(intervalStart)
+			Expect(trigger).To(Equal(volsync.ScheduledTriggerPrefix + intervalStart.UTC().Format(time.RFC3339)))
+			Expect(s.ManualTrigger(intervalStart.Add(25*time.Hour - time.Second))).To(Equal(trigger))
+			Expect(s.ManualTrigger(intervalStart.Add(25 * time.Hour))).NotTo(Equal(trigger))
+			Expect(s.ManualTrigger(intervalStart.Add(-time.Second))).NotTo(Equal(trigger))
+		})
+
+		It("Should trigger the ReplicationSources manually, and requeue at the start of the next interval", func() {
+			for _, schedulingInterval := range []string{"90m", "25h"} {
+				vsHandler := newVSHandler("app-ns", "app")
+				vsHandler.SetDestinations([]ramendrv1alpha1.VolSyncDestination{
+					{Name: "east", SchedulingInterval: schedulingInterval},
+				})
+
+				s, err := vsHandler.GetSchedule(schedulingInterval)
+				Expect(err).ToNot(HaveOccurred())
+
+				trigger, err := vsHandler.GetRSTrigger(schedulingInterval)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(trigger.Schedule).To(BeNil())
+				Expect(strings.HasPrefix(trigger.Manual, volsync.ScheduledTriggerPrefix)).To(BeTrue())
+				Expect(trigger.Manual).To(Equal(s.ManualTrigger(time.Now())))
+
+				_, next := s.CurrentIntervalStart(time.Now())
+				nextSync := vsHandler.NextScheduledSync()
+				Expect(nextSync).ToNot(BeNil())
+				Expect(nextSync.UTC()).To(Equal(next.UTC()))
+			}
+		})
+
+		It("Should not requeue for intervals that a cronspec expresses", func() {
+			vsHandler := newVSHandler("app-ns", "app")
+			vsHandler.SetDestinations([]ramendrv1alpha1.VolSyncDestination{
+				{Name: "east", SchedulingInterval: "1h"},
+			})
+
+			trigger, err := vsHandler.GetRSTrigger("1h")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trigger.Manual).To(BeEmpty())
+			Expect(trigger.Schedule).ToNot(BeNil())
+			Expect(vsHandler.NextScheduledSync()).To(BeNil())
+		})
+	})
+})
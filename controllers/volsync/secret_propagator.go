@@ -7,8 +7,10 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -22,14 +24,19 @@ func GetVolSyncSSHSecretNameFromVRGName(vrgName string) string {
 	return fmt.Sprintf("%s-vs-secret", vrgName)
 }
 
+func GetVolSyncResticSecretNameFromVRGName(vrgName string) string {
+	return fmt.Sprintf("%s-vs-restic-secret", vrgName)
+}
+
 // Should be run from a hub - assumes the source secret exists on the hub cluster and should be propagated
 // to destClusters.
-// Creates Policy/PlacementRule/PlacementBinding on the hub in the same namespace as the source secret
+// Creates Policy/PlacementRule/PlacementBinding, named after the destination secret, on the hub in the same
+// namespace as the source secret
 func PropagateSecretToClusters(ctx context.Context, k8sClient client.Client, sourceSecret *corev1.Secret,
 	ownerObject metav1.Object, destClusters []string, destSecretName, destSecretNamespace string,
 	log logr.Logger,
 ) error {
-	secretPropagationPolicyName := ownerObject.GetName() + "-vs-secret"
+	secretPropagationPolicyName := destSecretName
 	secretPropagationPolicyPlacementRuleName := secretPropagationPolicyName
 	secretPropagationPolicyPlacementBindingName := secretPropagationPolicyName
 
@@ -261,3 +268,57 @@ func (sp *secretPropagator) reconcileSecretPropagationPlacementBinding() error {
 
 	return nil
 }
+
+// IsSecretPropagated returns true if the policy that propagates the secret named destSecretName, created by
+// PropagateSecretToClusters in the given namespace, reports the secret as compliant on all of destClusters
+func IsSecretPropagated(ctx context.Context, k8sClient client.Client, destSecretName, namespace string,
+	destClusters []string,
+) (bool, error) {
+	policy := &policyv1.Policy{}
+
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: destSecretName, Namespace: namespace}, policy)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("error getting secret propagation policy %s (%w)", destSecretName, err)
+	}
+
+	for _, clusterName := range destClusters {
+		compliant := false
+
+		for _, clusterStatus := range policy.Status.Status {
+			if clusterStatus != nil && clusterStatus.ClusterName == clusterName {
+				compliant = clusterStatus.ComplianceState == policyv1.Compliant
+
+				break
+			}
+		}
+
+		if !compliant {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// DeleteSecretPropagation deletes the Policy/PlacementRule/PlacementBinding that propagate the secret named
+// destSecretName, created by PropagateSecretToClusters in the given namespace. The secrets already propagated
+// to the clusters are left in place.
+func DeleteSecretPropagation(ctx context.Context, k8sClient client.Client, destSecretName, namespace string) error {
+	objects := []client.Object{
+		&policyv1.PlacementBinding{ObjectMeta: metav1.ObjectMeta{Name: destSecretName, Namespace: namespace}},
+		&plrulev1.PlacementRule{ObjectMeta: metav1.ObjectMeta{Name: destSecretName, Namespace: namespace}},
+		&policyv1.Policy{ObjectMeta: metav1.ObjectMeta{Name: destSecretName, Namespace: namespace}},
+	}
+
+	for _, obj := range objects {
+		if err := k8sClient.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting secret propagation object %T %s (%w)", obj, destSecretName, err)
+		}
+	}
+
+	return nil
+}
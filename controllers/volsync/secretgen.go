@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 
@@ -19,12 +20,37 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const keyBitSize = 4096
+const (
+	keyBitSize         = 4096
+	resticPasswordSize = 32
+
+	// ResticPasswordKey is the key of the password of the restic repositories
+	// in the restic secret, named after the restic environment variable
+	ResticPasswordKey = "RESTIC_PASSWORD"
+)
 
 // Creates a new volsync replication secret on the cluster (should be called on the hub cluster).  If the secret
 // already exists, nop
 func ReconcileVolSyncReplicationSecret(ctx context.Context, k8sClient client.Client, ownerObject metav1.Object,
 	secretName, secretNamespace string, log logr.Logger) (*corev1.Secret, error,
+) {
+	return reconcileVolSyncSecret(ctx, k8sClient, ownerObject, secretName, secretNamespace,
+		generateNewVolSyncReplicationSecret, log)
+}
+
+// Creates a new volsync restic secret, with the password of the restic repositories of the PVCs, on the cluster
+// (should be called on the hub cluster).  If the secret already exists, nop
+func ReconcileVolSyncResticSecret(ctx context.Context, k8sClient client.Client, ownerObject metav1.Object,
+	secretName, secretNamespace string, log logr.Logger) (*corev1.Secret, error,
+) {
+	return reconcileVolSyncSecret(ctx, k8sClient, ownerObject, secretName, secretNamespace,
+		generateNewVolSyncResticSecret, log)
+}
+
+func reconcileVolSyncSecret(ctx context.Context, k8sClient client.Client, ownerObject metav1.Object,
+	secretName, secretNamespace string,
+	generateSecret func(secretName, secretNamespace string, log logr.Logger) (*corev1.Secret, error),
+	log logr.Logger) (*corev1.Secret, error,
 ) {
 	existingSecret := &corev1.Secret{}
 	// See if it exists already
@@ -39,7 +65,7 @@ func ReconcileVolSyncReplicationSecret(ctx context.Context, k8sClient client.Cli
 		return existingSecret, nil
 	}
 
-	secret, err := generateNewVolSyncReplicationSecret(secretName, secretNamespace, log)
+	secret, err := generateSecret(secretName, secretNamespace, log)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +76,7 @@ func ReconcileVolSyncReplicationSecret(ctx context.Context, k8sClient client.Cli
 		return nil, fmt.Errorf("%w", err)
 	}
 
-	log.Info("Creating new volsync rsync secret", "secretName", secretName)
+	log.Info("Creating new volsync secret", "secretName", secretName)
 
 	err = k8sClient.Create(ctx, secret)
 	if err != nil {
@@ -86,6 +112,27 @@ func generateNewVolSyncReplicationSecret(secretName, secretNamespace string, log
 	return secret, nil
 }
 
+func generateNewVolSyncResticSecret(secretName, secretNamespace string, log logr.Logger) (*corev1.Secret, error) {
+	password := make([]byte, resticPasswordSize)
+	if _, err := rand.Read(password); err != nil {
+		log.Error(err, "Unable to generate new restic password")
+
+		return nil, fmt.Errorf("unable to generate new restic password (%w)", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: secretNamespace,
+		},
+		Data: map[string][]byte{
+			ResticPasswordKey: []byte(base64.StdEncoding.EncodeToString(password)),
+		},
+	}
+
+	return secret, nil
+}
+
 func generateKeyPair(log logr.Logger) (priv []byte, pub []byte, err error) {
 	rsaPrivateKey, err := generateNewPrivateKey(log)
 	if err != nil {
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetVolSyncSSHSecretName returns the name of the secret with the ssh keys of
+// the given generation, propagated to the clusters of the VRG. Each rotation
+// of the keys propagates a secret of the next generation, so that the secret
+// in use is not modified while the ReplicationSources and
+// ReplicationDestinations switch over.
+func GetVolSyncSSHSecretName(vrgName string, generation int64) string {
+	if generation == 0 {
+		return GetVolSyncSSHSecretNameFromVRGName(vrgName)
+	}
+
+	return fmt.Sprintf("%s-%d", GetVolSyncSSHSecretNameFromVRGName(vrgName), generation)
+}
+
+// isVolSyncSSHSecretName returns true if the secret is a secret with the ssh
+// keys of any generation for the VRG
+func isVolSyncSSHSecretName(vrgName, secretName string) bool {
+	prefix := GetVolSyncSSHSecretNameFromVRGName(vrgName)
+	if secretName == prefix {
+		return true
+	}
+
+	if !strings.HasPrefix(secretName, prefix+"-") {
+		return false
+	}
+
+	_, err := strconv.ParseUint(strings.TrimPrefix(secretName, prefix+"-"), 10, 63)
+
+	return err == nil
+}
+
+// SetSSHSecretName sets the name of the secret with the ssh keys of the rsync
+// mover. An empty name selects the secret named after the VRG.
+func (v *VSHandler) SetSSHSecretName(secretName string) {
+	v.sshSecretName = secretName
+}
+
+// GetSSHSecretName returns the name of the secret with the ssh keys of the
+// rsync mover
+func (v *VSHandler) GetSSHSecretName() string {
+	if v.sshSecretName == "" {
+		return GetVolSyncSSHSecretNameFromVRGName(v.owner.GetName())
+	}
+
+	return v.sshSecretName
+}
+
+// DeleteStaleSSHSecrets deletes the secrets with the ssh keys of the previous
+// generations, once the ReplicationSources and ReplicationDestinations of the
+// VRG use the keys of the current generation
+func (v *VSHandler) DeleteStaleSSHSecrets() error {
+	secretList := &corev1.SecretList{}
+
+	if err := v.client.List(v.ctx, secretList, client.InNamespace(v.owner.GetNamespace())); err != nil {
+		return fmt.Errorf("error listing secrets (%w)", err)
+	}
+
+	for idx := range secretList.Items {
+		secret := &secretList.Items[idx]
+
+		if secret.GetName() == v.GetSSHSecretName() ||
+			!isVolSyncSSHSecretName(v.owner.GetName(), secret.GetName()) ||
+			!v.isOwnedByVRG(secret) {
+			continue
+		}
+
+		v.log.Info("Deleting stale VolSync ssh secret", "secretName", secret.GetName())
+
+		if err := v.client.Delete(v.ctx, secret); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting secret %s (%w)", secret.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (v *VSHandler) isOwnedByVRG(obj client.Object) bool {
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		if ownerRef.UID == v.owner.GetUID() {
+			return true
+		}
+	}
+
+	return false
+}
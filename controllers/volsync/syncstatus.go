@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volsync
+
+import (
+	"fmt"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// PVCSyncStatusFromRSs returns the replication progress of a PVC protected by
+// a ReplicationSource per destination, or nil without any. The PVC is as much
+// in sync as the destination synchronized least recently, among those
+// synchronized at least once, regardless of the order of the
+// ReplicationSources. It is in its initial synchronization while any
+// destination has not been synchronized yet, with the last sync time of the
+// others, if any.
+func PVCSyncStatusFromRSs(rss []*volsyncv1alpha1.ReplicationSource) *ramendrv1alpha1.PVCSyncStatus {
+	if len(rss) == 0 {
+		return nil
+	}
+
+	var syncStatus *ramendrv1alpha1.PVCSyncStatus
+
+	initialSyncs := 0
+
+	for _, rs := range rss {
+		if rs.Status == nil || rs.Status.LastSyncTime == nil {
+			initialSyncs++
+
+			continue
+		}
+
+		if syncStatus == nil || rs.Status.LastSyncTime.Before(syncStatus.LastSyncTime) {
+			syncStatus = &ramendrv1alpha1.PVCSyncStatus{
+				State:            ramendrv1alpha1.PVCSyncStateInSync,
+				LastSyncTime:     rs.Status.LastSyncTime,
+				LastSyncDuration: rs.Status.LastSyncDuration,
+			}
+		}
+	}
+
+	if initialSyncs == 0 {
+		return syncStatus
+	}
+
+	if syncStatus == nil {
+		syncStatus = &ramendrv1alpha1.PVCSyncStatus{}
+	}
+
+	syncStatus.State = ramendrv1alpha1.PVCSyncStateInitialSync
+	syncStatus.Message = "Initial synchronization to the peer cluster in progress"
+
+	if len(rss) > 1 {
+		syncStatus.Message = fmt.Sprintf("Initial synchronization to %d of %d peer clusters in progress",
+			initialSyncs, len(rss))
+	}
+
+	return syncStatus
+}
@@ -0,0 +1,66 @@
+package volsync_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/volsync"
+)
+
+var _ = Describe("VolSync Handler - PVC sync status", func() {
+	now := time.Now().Truncate(time.Second)
+	older := metav1.NewTime(now.Add(-time.Hour))
+	newer := metav1.NewTime(now)
+
+	rs := func(lastSyncTime *metav1.Time) *volsyncv1alpha1.ReplicationSource {
+		if lastSyncTime == nil {
+			return &volsyncv1alpha1.ReplicationSource{}
+		}
+
+		return &volsyncv1alpha1.ReplicationSource{Status: &volsyncv1alpha1.ReplicationSourceStatus{
+			LastSyncTime:     lastSyncTime,
+			LastSyncDuration: &metav1.Duration{Duration: time.Minute},
+		}}
+	}
+
+	It("Should report no sync status without ReplicationSources", func() {
+		Expect(volsync.PVCSyncStatusFromRSs(nil)).To(BeNil())
+	})
+
+	It("Should report the initial synchronization of a PVC not synchronized yet", func() {
+		syncStatus := volsync.PVCSyncStatusFromRSs([]*volsyncv1alpha1.ReplicationSource{rs(nil)})
+		Expect(syncStatus.State).To(Equal(ramendrv1alpha1.PVCSyncStateInitialSync))
+		Expect(syncStatus.LastSyncTime).To(BeNil())
+	})
+
+	It("Should report the least recently synchronized destination, in any order", func() {
+		for _, rss := range [][]*volsyncv1alpha1.ReplicationSource{
+			{rs(&older), rs(&newer)},
+			{rs(&newer), rs(&older)},
+		} {
+			syncStatus := volsync.PVCSyncStatusFromRSs(rss)
+			Expect(syncStatus.State).To(Equal(ramendrv1alpha1.PVCSyncStateInSync))
+			Expect(syncStatus.LastSyncTime).To(Equal(&older))
+		}
+	})
+
+	It("Should report the initial synchronization of a destination, in any order, with the last sync time of "+
+		"the others", func() {
+		for _, rss := range [][]*volsyncv1alpha1.ReplicationSource{
+			{rs(nil), rs(&newer), rs(&older)},
+			{rs(&newer), rs(nil), rs(&older)},
+			{rs(&newer), rs(&older), rs(nil)},
+		} {
+			syncStatus := volsync.PVCSyncStatusFromRSs(rss)
+			Expect(syncStatus.State).To(Equal(ramendrv1alpha1.PVCSyncStateInitialSync))
+			Expect(syncStatus.LastSyncTime).To(Equal(&older))
+			Expect(syncStatus.Message).To(ContainSubstring("1 of 3"))
+		}
+	})
+})
@@ -19,9 +19,10 @@ package volsync
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"reflect"
-	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
@@ -56,14 +57,19 @@ const (
 	VRGOwnerLabel          string = "volumereplicationgroups-owner"
 	FinalSyncTriggerString string = "vrg-final-sync"
 
-	SchedulingIntervalMinLength int = 2
-	CronSpecMaxDayOfMonth       int = 28
-
 	VolSyncDoNotDeleteLabel    = "volsync.backube/do-not-delete" // TODO: point to volsync constant once it is available
 	VolSyncDoNotDeleteLabelVal = "true"
 
 	ACMAppSubDoNotDeleteLabel    = "do-not-delete" // See: https://issues.redhat.com/browse/ACM-1256
 	ACMAppSubDoNotDeleteLabelVal = "true"
+
+	// WarmStandbyLabel labels the warm standby volumes of a VRG, with the VRG name
+	WarmStandbyLabel = "ramendr.openshift.io/warm-standby"
+
+	// standbyReclaimPolicyAnnotation records the reclaim policy of the volume of
+	// a warm standby PVC, retained while the volume is handed over to the PVC
+	// it stands by for
+	standbyReclaimPolicyAnnotation = "ramendr.openshift.io/warm-standby-reclaim-policy"
 )
 
 type VSHandler struct {
@@ -74,6 +80,14 @@ type VSHandler struct {
 	schedulingInterval          string
 	volumeSnapshotClassSelector metav1.LabelSelector // volume snapshot classes to be filtered label selector
 	volumeSnapshotClassList     *snapv1.VolumeSnapshotClassList
+	volumeSnapshotClassListLock sync.Mutex        // PVCs of the owner may be reconciled concurrently
+	resticRepository            *ResticRepository // nil for the rsync mover
+	profile                     *ramendrv1alpha1.VolSyncProfile
+	sshSecretName               string // empty for the secret named after the owner
+	recoveryPointRetention      *ramendrv1alpha1.VolSyncRecoveryPointRetention
+	recoveryPointTime           *metav1.Time                         // nil to restore the latest images
+	destinations                []ramendrv1alpha1.VolSyncDestination // nil for a single destination
+	destinationName             string                               // empty for a single destination
 }
 
 func NewVSHandler(ctx context.Context, client client.Client, log logr.Logger, owner metav1.Object,
@@ -116,10 +130,8 @@ func (v *VSHandler) ReconcileRD(
 		return nil, fmt.Errorf("protectedPVC %s is not VolSync Enabled", rdSpec.ProtectedPVC.Name)
 	}
 
-	// Pre-allocated shared secret - DRPC will generate and propagate this secret from hub to clusters
-	sshKeysSecretName := GetVolSyncSSHSecretNameFromVRGName(v.owner.GetName())
-	// Need to confirm this secret exists on the cluster before proceeding, otherwise volsync will generate it
-	secretExists, err := v.validateSecretAndAddVRGOwnerRef(sshKeysSecretName)
+	moverSecretName, secretExists, err := v.reconcileMoverSecret(
+		getReplicationDestinationName(rdSpec.ProtectedPVC.Name, v.destinationName))
 	if err != nil || !secretExists {
 		return nil, err
 	}
@@ -134,14 +146,20 @@ func (v *VSHandler) ReconcileRD(
 
 	var rd *volsyncv1alpha1.ReplicationDestination
 
-	rd, err = v.createOrUpdateRD(rdSpec, sshKeysSecretName)
+	rd, err = v.createOrUpdateRD(rdSpec, moverSecretName)
 	if err != nil {
 		return nil, err
 	}
 
-	err = v.reconcileServiceExportForRD(rd)
-	if err != nil {
-		return nil, err
+	v.updateRDMetrics(rdSpec, rd)
+
+	// The restic mover transfers through the S3 store, and needs no service for the source to connect to.
+	// Other than ClusterIP services are reached through the address published by the VRG instead
+	if v.resticRepository == nil && *v.getRsyncServiceType() == corev1.ServiceTypeClusterIP {
+		err = v.reconcileServiceExportForRD(rd)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if !rdStatusReady(rd, l) {
@@ -156,11 +174,16 @@ func (v *VSHandler) ReconcileRD(
 // For ReplicationDestination - considered ready when a sync has completed
 // - rsync address should be filled out in the status
 // - latest image should be set properly in the status (at least one sync cycle has completed and we have a snapshot)
+// A restic ReplicationDestination is ready once VolSync reports its status, as it has no address
 func rdStatusReady(rd *volsyncv1alpha1.ReplicationDestination, log logr.Logger) bool {
 	if rd.Status == nil {
 		return false
 	}
 
+	if rd.Spec.Restic != nil {
+		return true
+	}
+
 	if rd.Status.Rsync == nil || rd.Status.Rsync.Address == nil {
 		log.V(1).Info("ReplicationDestination waiting for Address ...")
 
@@ -172,11 +195,11 @@ func rdStatusReady(rd *volsyncv1alpha1.ReplicationDestination, log logr.Logger)
 
 func (v *VSHandler) createOrUpdateRD(
 	rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
-	sshKeysSecretName string) (*volsyncv1alpha1.ReplicationDestination, error,
+	moverSecretName string) (*volsyncv1alpha1.ReplicationDestination, error,
 ) {
 	l := v.log.WithValues("rdSpec", rdSpec)
 
-	volumeSnapshotClassName, err := v.GetVolumeSnapshotClassFromPVCStorageClass(rdSpec.ProtectedPVC.StorageClassName)
+	copyMethod, err := v.getCopyMethodSpec(rdSpec.ProtectedPVC.StorageClassName, rdSpec.ProtectedPVC.AccessModes)
 	if err != nil {
 		return nil, err
 	}
@@ -188,7 +211,7 @@ func (v *VSHandler) createOrUpdateRD(
 
 	rd := &volsyncv1alpha1.ReplicationDestination{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getReplicationDestinationName(rdSpec.ProtectedPVC.Name),
+			Name:      getReplicationDestinationName(rdSpec.ProtectedPVC.Name, v.destinationName),
 			Namespace: v.owner.GetNamespace(),
 		},
 	}
@@ -202,17 +225,25 @@ func (v *VSHandler) createOrUpdateRD(
 
 		addVRGOwnerLabel(v.owner, rd)
 
+		volumeOptions := volsyncv1alpha1.ReplicationDestinationVolumeOptions{
+			Capacity:         rdSpec.ProtectedPVC.Resources.Requests.Storage(),
+			StorageClassName: rdSpec.ProtectedPVC.StorageClassName,
+			AccessModes:      pvcAccessModes,
+			DestinationPVC:   destinationPVCName(rdSpec),
+		}
+		volumeOptions.CopyMethod, volumeOptions.VolumeSnapshotClassName = copyMethod.rdCopyMethod()
+
+		if v.resticRepository != nil {
+			return v.setRDRestic(rd, moverSecretName, volumeOptions)
+		}
+
+		rd.Spec.Restic = nil
 		rd.Spec.Rsync = &volsyncv1alpha1.ReplicationDestinationRsyncSpec{
 			ServiceType: v.getRsyncServiceType(),
-			SSHKeys:     &sshKeysSecretName,
-
-			ReplicationDestinationVolumeOptions: volsyncv1alpha1.ReplicationDestinationVolumeOptions{
-				CopyMethod:              volsyncv1alpha1.CopyMethodSnapshot,
-				Capacity:                rdSpec.ProtectedPVC.Resources.Requests.Storage(),
-				StorageClassName:        rdSpec.ProtectedPVC.StorageClassName,
-				AccessModes:             pvcAccessModes,
-				VolumeSnapshotClassName: &volumeSnapshotClassName,
-			},
+			SSHKeys:     &moverSecretName,
+			Port:        v.getRsyncPort(),
+
+			ReplicationDestinationVolumeOptions: volumeOptions,
 		}
 
 		return nil
@@ -223,9 +254,111 @@ func (v *VSHandler) createOrUpdateRD(
 
 	l.V(1).Info("ReplicationDestination createOrUpdate Complete", "op", op)
 
+	if err := v.ensureRDDestinationPVC(rdSpec, rd, pvcAccessModes); err != nil {
+		return nil, err
+	}
+
 	return rd, nil
 }
 
+// destinationPVCName returns the name of the PVC the ReplicationDestination
+// transfers to, when it is not provisioned by VolSync. VolSync provisions
+// destination volumes in Filesystem mode only, so the destination of a PVC in
+// Block mode is provisioned with the volume mode and annotations of the PVC.
+func destinationPVCName(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec) *string {
+	if rdSpec.ProtectedPVC.VolumeMode == nil || *rdSpec.ProtectedPVC.VolumeMode != corev1.PersistentVolumeBlock {
+		return nil
+	}
+
+	name := getRDDestinationPVCName(rdSpec.ProtectedPVC.Name)
+
+	return &name
+}
+
+func (v *VSHandler) ensureRDDestinationPVC(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	rd *volsyncv1alpha1.ReplicationDestination, accessModes []corev1.PersistentVolumeAccessMode,
+) error {
+	pvcName := destinationPVCName(rdSpec)
+	if pvcName == nil {
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      *pvcName,
+			Namespace: v.owner.GetNamespace(),
+		},
+	}
+
+	op, err := ctrlutil.CreateOrUpdate(v.ctx, v.client, pvc, func() error {
+		// The ReplicationDestination owns the PVC, which is deleted along with it
+		if err := ctrl.SetControllerReference(rd, pvc, v.client.Scheme()); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		addVRGOwnerLabel(v.owner, pvc)
+		setAnnotations(pvc, rdSpec.ProtectedPVC.Annotations)
+
+		if pvc.CreationTimestamp.IsZero() { // set immutable fields
+			pvc.Spec.AccessModes = accessModes
+			pvc.Spec.StorageClassName = rdSpec.ProtectedPVC.StorageClassName
+			pvc.Spec.VolumeMode = rdSpec.ProtectedPVC.VolumeMode
+			pvc.Spec.Resources = rdSpec.ProtectedPVC.Resources
+
+			return nil
+		}
+
+		growStorageRequest(pvc, rdSpec.ProtectedPVC.Resources.Requests)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error creating or updating ReplicationDestination PVC %s (%w)", *pvcName, err)
+	}
+
+	v.log.V(1).Info("ReplicationDestination PVC createOrUpdate Complete", "pvcName", *pvcName, "op", op)
+
+	return nil
+}
+
+// growStorageRequest raises the storage request of the PVC to the requested
+// storage, if larger. A PVC can only be expanded, once bound, so a smaller
+// request is left as it is, and a larger one is applied once the PVC is bound.
+func growStorageRequest(pvc *corev1.PersistentVolumeClaim, requests corev1.ResourceList) {
+	request, ok := requests[corev1.ResourceStorage]
+	if !ok || pvc.Status.Phase != corev1.ClaimBound {
+		return
+	}
+
+	if current, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok && current.Cmp(request) >= 0 {
+		return
+	}
+
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = request
+}
+
+// setAnnotations adds the annotations to the object, keeping its other annotations
+func setAnnotations(obj metav1.Object, annotations map[string]string) {
+	if len(annotations) == 0 {
+		return
+	}
+
+	objAnnotations := obj.GetAnnotations()
+	if objAnnotations == nil {
+		objAnnotations = map[string]string{}
+	}
+
+	for key, val := range annotations {
+		objAnnotations[key] = val
+	}
+
+	obj.SetAnnotations(objAnnotations)
+}
+
 // Returns true only if runFinalSync is true and the final sync is done
 // Returns replication source only if create/update is successful
 // Callers should assume getting a nil replication source back means they should retry/requeue.
@@ -239,11 +372,8 @@ func (v *VSHandler) ReconcileRS(rsSpec ramendrv1alpha1.VolSyncReplicationSourceS
 		return false, nil, fmt.Errorf("protectedPVC %s is not VolSync Enabled", rsSpec.ProtectedPVC.Name)
 	}
 
-	// Pre-allocated shared secret - DRPC will generate and propagate this secret from hub to clusters
-	sshKeysSecretName := GetVolSyncSSHSecretNameFromVRGName(v.owner.GetName())
-
-	// Need to confirm this secret exists on the cluster before proceeding, otherwise volsync will generate it
-	secretExists, err := v.validateSecretAndAddVRGOwnerRef(sshKeysSecretName)
+	moverSecretName, secretExists, err := v.reconcileMoverSecret(
+		getReplicationSourceName(rsSpec.ProtectedPVC.Name, rsSpec.Destination))
 	if err != nil || !secretExists {
 		return false, nil, err
 	}
@@ -257,21 +387,42 @@ func (v *VSHandler) ReconcileRS(rsSpec ramendrv1alpha1.VolSyncReplicationSourceS
 		return false, nil, err
 	}
 
+	var rsyncAddress *ramendrv1alpha1.VolSyncRsyncAddress
+
+	if v.resticRepository == nil {
+		rsyncAddress = v.getRsyncAddressForRS(rsSpec)
+		if rsyncAddress == nil {
+			l.Info("ReplicationSource waiting for the address of the ReplicationDestination on the peer cluster")
+
+			return false, nil, nil
+		}
+	}
+
 	pvcOk, err := v.validatePVCBeforeRS(rsSpec, runFinalSync)
 	if !pvcOk || err != nil {
 		return false, nil, err
 	}
 
-	replicationSource, err := v.createOrUpdateRS(rsSpec, sshKeysSecretName, runFinalSync)
+	replicationSource, err := v.createOrUpdateRS(rsSpec, moverSecretName, rsyncAddress, runFinalSync)
 	if err != nil {
 		return false, nil, err
 	}
 
+	v.updateRSMetrics(rsSpec, replicationSource)
+
 	//
 	// For final sync only - check status to make sure the final sync is complete
-	// and also run cleanup (removes PVC we just ran the final sync from)
+	// and also run cleanup (removes PVC we just ran the final sync from), once
+	// the final sync to all the destinations is complete
 	//
 	if runFinalSync && isFinalSyncComplete(replicationSource, l) {
+		v.updateFinalSyncMetrics(rsSpec, replicationSource)
+
+		allComplete, err := v.isFinalSyncCompleteToAllDestinations(rsSpec.ProtectedPVC.Name)
+		if err != nil || !allComplete {
+			return true, replicationSource, err
+		}
+
 		return true, replicationSource, v.cleanupAfterRSFinalSync(rsSpec)
 	}
 
@@ -312,7 +463,7 @@ func (v *VSHandler) validatePVCBeforeRS(rsSpec ramendrv1alpha1.VolSyncReplicatio
 	// Not running final sync - if we have not yet created an RS for this PVC, then make sure a pod has mounted
 	// the PVC and is in "Running" state before attempting to create an RS.
 	// This is a best effort to confirm the app that is using the PVC is started before trying to replicate the PVC.
-	_, err := v.getRS(getReplicationSourceName(rsSpec.ProtectedPVC.Name))
+	_, err := v.getRS(getReplicationSourceName(rsSpec.ProtectedPVC.Name, rsSpec.Destination))
 	if err != nil && kerrors.IsNotFound(err) {
 		l.Info("ReplicationSource does not exist yet. " +
 			"validating that the PVC to be protected is in use by a ready pod ...")
@@ -354,6 +505,27 @@ func isFinalSyncComplete(replicationSource *volsyncv1alpha1.ReplicationSource, l
 	return true
 }
 
+// isFinalSyncCompleteToAllDestinations returns true if the final sync of the
+// PVC to each of the destinations is complete
+func (v *VSHandler) isFinalSyncCompleteToAllDestinations(pvcName string) (bool, error) {
+	for _, destination := range v.Destinations() {
+		rs, err := v.getRS(getReplicationSourceName(pvcName, destination))
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		if !isFinalSyncComplete(rs, v.log) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func (v *VSHandler) cleanupAfterRSFinalSync(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec) error {
 	// Final sync is done, make sure PVC is cleaned up
 	v.log.Info("Cleanup after final sync", "pvcName", rsSpec.ProtectedPVC.Name)
@@ -363,22 +535,18 @@ func (v *VSHandler) cleanupAfterRSFinalSync(rsSpec ramendrv1alpha1.VolSyncReplic
 
 // nolint: funlen
 func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec,
-	sshKeysSecretName string, runFinalSync bool) (*volsyncv1alpha1.ReplicationSource, error,
-) {
+	moverSecretName string, rsyncAddress *ramendrv1alpha1.VolSyncRsyncAddress, runFinalSync bool,
+) (*volsyncv1alpha1.ReplicationSource, error) {
 	l := v.log.WithValues("rsSpec", rsSpec, "runFinalSync", runFinalSync)
 
-	volumeSnapshotClassName, err := v.GetVolumeSnapshotClassFromPVCStorageClass(rsSpec.ProtectedPVC.StorageClassName)
+	copyMethod, err := v.getCopyMethodSpec(rsSpec.ProtectedPVC.StorageClassName, rsSpec.ProtectedPVC.AccessModes)
 	if err != nil {
 		return nil, err
 	}
 
-	// Remote service address created for the ReplicationDestination on the secondary
-	// The secondary namespace will be the same as primary namespace so use the vrg.Namespace
-	remoteAddress := getRemoteServiceNameForRDFromPVCName(rsSpec.ProtectedPVC.Name, v.owner.GetNamespace())
-
 	rs := &volsyncv1alpha1.ReplicationSource{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      getReplicationSourceName(rsSpec.ProtectedPVC.Name),
+			Name:      getReplicationSourceName(rsSpec.ProtectedPVC.Name, rsSpec.Destination),
 			Namespace: v.owner.GetNamespace(),
 		},
 	}
@@ -393,6 +561,7 @@ func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSo
 		addVRGOwnerLabel(v.owner, rs)
 
 		rs.Spec.SourcePVC = rsSpec.ProtectedPVC.Name
+		rs.Spec.Paused = rsSpec.Paused
 
 		if runFinalSync {
 			l.V(1).Info("ReplicationSource - final sync")
@@ -403,28 +572,31 @@ func (v *VSHandler) createOrUpdateRS(rsSpec ramendrv1alpha1.VolSyncReplicationSo
 			}
 		} else {
 			// Set schedule
-			scheduleCronSpec, err := v.getScheduleCronSpec()
+			trigger, err := v.getRSTrigger(v.getDestinationSchedulingInterval(rsSpec.Destination))
 			if err != nil {
 				l.Error(err, "unable to parse schedulingInterval")
 
 				return err
 			}
-			rs.Spec.Trigger = &volsyncv1alpha1.ReplicationSourceTriggerSpec{
-				Schedule: scheduleCronSpec,
-			}
+			rs.Spec.Trigger = trigger
+		}
+
+		// Not setting storageclassname - volsync can find that from the sourcePVC
+		volumeOptions := copyMethod.rsVolumeOptions()
+
+		if v.resticRepository != nil {
+			setRSRestic(rs, moverSecretName, volumeOptions, v.recoveryPointRetention)
+
+			return nil
 		}
 
+		rs.Spec.Restic = nil
 		rs.Spec.Rsync = &volsyncv1alpha1.ReplicationSourceRsyncSpec{
-			SSHKeys: &sshKeysSecretName,
-			Address: &remoteAddress,
-
-			ReplicationSourceVolumeOptions: volsyncv1alpha1.ReplicationSourceVolumeOptions{
-				// Always using CopyMethod of snapshot for now - could use 'Clone' CopyMethod for specific
-				// storage classes that support it in the future
-				CopyMethod:              volsyncv1alpha1.CopyMethodSnapshot,
-				VolumeSnapshotClassName: &volumeSnapshotClassName,
-				// Not setting storageclassname - volsync can find that from the sourcePVC
-			},
+			SSHKeys: &moverSecretName,
+			Address: &rsyncAddress.Address,
+			Port:    rsyncAddress.Port,
+
+			ReplicationSourceVolumeOptions: volumeOptions,
 		}
 
 		return nil
@@ -629,6 +801,14 @@ func (v *VSHandler) validatePVCAndAddVRGOwnerRef(pvcName string) (*corev1.Persis
 }
 
 func (v *VSHandler) validateSecretAndAddVRGOwnerRef(secretName string) (bool, error) {
+	secret, err := v.getSecretAndAddVRGOwnerRef(secretName)
+
+	return secret != nil, err
+}
+
+// getSecretAndAddVRGOwnerRef returns the secret with the VRG added as its owner,
+// or nil if the secret does not exist
+func (v *VSHandler) getSecretAndAddVRGOwnerRef(secretName string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
 
 	err := v.client.Get(v.ctx,
@@ -640,13 +820,13 @@ func (v *VSHandler) validateSecretAndAddVRGOwnerRef(secretName string) (bool, er
 		if !kerrors.IsNotFound(err) {
 			v.log.Error(err, "Failed to get secret", "secretName", secretName)
 
-			return false, fmt.Errorf("error getting secret (%w)", err)
+			return nil, fmt.Errorf("error getting secret (%w)", err)
 		}
 
 		// Secret is not found
 		v.log.Info("Secret not found", "secretName", secretName)
 
-		return false, nil
+		return nil, nil
 	}
 
 	v.log.Info("Secret exists", "secretName", secretName)
@@ -655,12 +835,12 @@ func (v *VSHandler) validateSecretAndAddVRGOwnerRef(secretName string) (bool, er
 	if err := v.addOwnerReferenceAndUpdate(secret, v.owner); err != nil {
 		v.log.Error(err, "Unable to update secret", "secretName", secretName)
 
-		return true, err
+		return secret, err
 	}
 
 	v.log.V(1).Info("VolSync secret validated", "secret name", secretName)
 
-	return true, nil
+	return secret, nil
 }
 
 func (v *VSHandler) getRS(name string) (*volsyncv1alpha1.ReplicationSource, error) {
@@ -678,8 +858,8 @@ func (v *VSHandler) getRS(name string) (*volsyncv1alpha1.ReplicationSource, erro
 	return rs, nil
 }
 
+// DeleteRS deletes the ReplicationSources of the PVC to all destinations, that are owned (by parent vrg owner)
 func (v *VSHandler) DeleteRS(pvcName string) error {
-	// Remove a ReplicationSource by name that is owned (by parent vrg owner)
 	currentRSListByOwner, err := v.listRSByOwner()
 	if err != nil {
 		return err
@@ -688,7 +868,7 @@ func (v *VSHandler) DeleteRS(pvcName string) error {
 	for i := range currentRSListByOwner.Items {
 		rs := currentRSListByOwner.Items[i]
 
-		if rs.GetName() == getReplicationSourceName(pvcName) {
+		if rs.Spec.SourcePVC == pvcName {
 			// Delete the ReplicationSource, log errors with cleanup but continue on
 			if err := v.client.Delete(v.ctx, &rs); err != nil {
 				v.log.Error(err, "Error cleaning up ReplicationSource", "name", rs.GetName())
@@ -711,7 +891,7 @@ func (v *VSHandler) DeleteRD(pvcName string) error {
 	for i := range currentRDListByOwner.Items {
 		rd := currentRDListByOwner.Items[i]
 
-		if rd.GetName() == getReplicationDestinationName(pvcName) {
+		if rd.GetName() == getReplicationDestinationName(pvcName, v.destinationName) {
 			// Delete the ReplicationDestination, log errors with cleanup but continue on
 			if err := v.client.Delete(v.ctx, &rd); err != nil {
 				v.log.Error(err, "Error cleaning up ReplicationDestination", "name", rd.GetName())
@@ -737,7 +917,7 @@ func (v *VSHandler) CleanupRDNotInSpecList(rdSpecList []ramendrv1alpha1.VolSyncR
 		foundInSpecList := false
 
 		for _, rdSpec := range rdSpecList {
-			if rd.GetName() == getReplicationDestinationName(rdSpec.ProtectedPVC.Name) {
+			if rd.GetName() == getReplicationDestinationName(rdSpec.ProtectedPVC.Name, v.destinationName) {
 				foundInSpecList = true
 
 				break
@@ -846,6 +1026,18 @@ func (v *VSHandler) listByOwner(list client.ObjectList) error {
 func (v *VSHandler) EnsurePVCfromRD(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec) error {
 	l := v.log.WithValues("rdSpec", rdSpec)
 
+	if v.recoveryPointTime != nil {
+		recoveryPointRef, err := v.getRecoveryPointImage(rdSpec.ProtectedPVC.Name)
+		if err != nil {
+			return err
+		}
+
+		l.Info("Restoring PVC from recovery point", "recoveryPointTime", v.recoveryPointTime,
+			"recoveryPoint", recoveryPointRef.Name)
+
+		return v.adoptStandbyPVCAndEnsurePVC(rdSpec, *recoveryPointRef)
+	}
+
 	latestImage, err := v.getRDLatestImage(rdSpec.ProtectedPVC.Name)
 	if err != nil {
 		return err
@@ -858,20 +1050,263 @@ func (v *VSHandler) EnsurePVCfromRD(rdSpec ramendrv1alpha1.VolSyncReplicationDes
 		return noSnapErr
 	}
 
-	// Make copy of the ref and make sure API group is filled out correctly (shouldn't really need this part)
+	vsImageRef := latestImageRef(latestImage)
+
+	l.V(1).Info("Latest Image for ReplicationDestination", "latestImage	", vsImageRef)
+
+	return v.adoptStandbyPVCAndEnsurePVC(rdSpec, vsImageRef)
+}
+
+// adoptStandbyPVCAndEnsurePVC restores the PVC from the image, adopting the
+// volume of its warm standby PVC, if any, and restores the reclaim policy of
+// the adopted volume once the PVC is bound to it
+func (v *VSHandler) adoptStandbyPVCAndEnsurePVC(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	imageRef corev1.TypedLocalObjectReference,
+) error {
+	if err := v.adoptStandbyPVC(rdSpec, imageRef); err != nil {
+		return err
+	}
+
+	if err := v.validateSnapshotAndEnsurePVC(rdSpec, imageRef); err != nil {
+		return err
+	}
+
+	return v.restoreAdoptedPVReclaimPolicy(rdSpec.ProtectedPVC.Name)
+}
+
+// Make copy of the ref and make sure API group is filled out correctly (shouldn't really need this part)
+func latestImageRef(latestImage *corev1.TypedLocalObjectReference) corev1.TypedLocalObjectReference {
 	vsImageRef := latestImage.DeepCopy()
-	if vsImageRef.APIGroup == nil || *vsImageRef.APIGroup == "" {
+	if vsImageRef.Kind == VolumeSnapshotKind && (vsImageRef.APIGroup == nil || *vsImageRef.APIGroup == "") {
 		vsGroup := snapv1.GroupName
 		vsImageRef.APIGroup = &vsGroup
 	}
 
-	l.V(1).Info("Latest Image for ReplicationDestination", "latestImage	", vsImageRef)
+	return *vsImageRef
+}
+
+// EnsureStandbyPVCfromRD keeps a warm standby PVC restored from the latest
+// image of the ReplicationDestination. The standby PVC is named apart from the
+// PVC it stands by for, and carries none of its labels, so that it is not
+// mistaken for it. Once bound, it is replaced as a sync completes, so that
+// EnsurePVCfromRD can adopt its volume, restored from the latest image, as the
+// cluster becomes primary. A standby PVC still being restored is kept until it
+// is bound, rather than restarted by each sync.
+// Returns true if the standby PVC is restored from the latest image.
+func (v *VSHandler) EnsureStandbyPVCfromRD(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec) (bool, error) {
+	l := v.log.WithValues("pvcName", rdSpec.ProtectedPVC.Name)
+
+	latestImage, err := v.getRDLatestImage(rdSpec.ProtectedPVC.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if !isLatestImageReady(latestImage) {
+		l.V(1).Info("No latestImage to restore the standby PVC from yet")
+
+		return false, nil
+	}
+
+	vsImageRef := latestImageRef(latestImage)
+	if vsImageRef.Kind != VolumeSnapshotKind {
+		// The PVC replicated to directly is the same latest image after each sync
+		return false, fmt.Errorf("warm standby pvc %s requires the %s copy method", rdSpec.ProtectedPVC.Name,
+			CopyMethodSnapshot)
+	}
+
+	standbyPVC, err := v.getStandbyPVC(rdSpec.ProtectedPVC.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if standbyPVC != nil && !objectRefMatches(standbyPVC.Spec.DataSource, &vsImageRef) {
+		if standbyPVC.Status.Phase != corev1.ClaimBound {
+			l.V(1).Info("Standby PVC restored from a previous image is not bound yet", "latestImage", vsImageRef)
+
+			return false, nil
+		}
+
+		l.Info("Replacing standby PVC with one restored from the latest image", "latestImage", vsImageRef)
+
+		if err := v.client.Delete(v.ctx, standbyPVC); err != nil && !kerrors.IsNotFound(err) {
+			return false, fmt.Errorf("error deleting standby pvc %s (%w)", standbyPVC.GetName(), err)
+		}
 
-	return v.validateSnapshotAndEnsurePVC(rdSpec, *vsImageRef)
+		return false, nil
+	}
+
+	if err := v.validateSnapshotAndEnsurePVC(v.standbyRDSpec(rdSpec), vsImageRef); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// standbyRDSpec returns the spec of the warm standby PVC of a PVC
+func (v *VSHandler) standbyRDSpec(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+) ramendrv1alpha1.VolSyncReplicationDestinationSpec {
+	standbyRDSpec := rdSpec.DeepCopy()
+	standbyRDSpec.ProtectedPVC.Name = getStandbyPVCName(rdSpec.ProtectedPVC.Name)
+	standbyRDSpec.ProtectedPVC.Labels = map[string]string{WarmStandbyLabel: v.owner.GetName()}
+
+	return *standbyRDSpec
+}
+
+// getStandbyPVC returns the warm standby PVC of a PVC, or nil if it does not
+// exist
+func (v *VSHandler) getStandbyPVC(pvcName string) (*corev1.PersistentVolumeClaim, error) {
+	standbyPVC, err := v.getPVC(getStandbyPVCName(pvcName))
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error getting standby pvc of pvc %s (%w)", pvcName, err)
+	}
+
+	if standbyPVC.GetLabels()[WarmStandbyLabel] != v.owner.GetName() {
+		return nil, fmt.Errorf("pvc %s exists and is not a warm standby pvc", standbyPVC.GetName())
+	}
+
+	return standbyPVC, nil
+}
+
+// adoptStandbyPVC hands the volume of the warm standby PVC of a PVC over to the
+// PVC, if the standby PVC is bound and is restored from the image the PVC is
+// restored from, and the PVC is not restored yet. The volume is retained and
+// pre-bound to the PVC before the standby PVC is deleted, so that the PVC
+// binds to it rather than to a volume provisioned from the image. A standby
+// PVC that is not adopted is deleted, and a PVC of the same name that is not
+// a standby PVC is left alone.
+func (v *VSHandler) adoptStandbyPVC(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	imageRef corev1.TypedLocalObjectReference,
+) error {
+	standbyPVC, err := v.getPVC(getStandbyPVCName(rdSpec.ProtectedPVC.Name))
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("error getting standby pvc of pvc %s (%w)", rdSpec.ProtectedPVC.Name, err)
+	}
+
+	if standbyPVC.GetLabels()[WarmStandbyLabel] != v.owner.GetName() {
+		return nil
+	}
+
+	_, err = v.getPVC(rdSpec.ProtectedPVC.Name)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("error getting pvc %s (%w)", rdSpec.ProtectedPVC.Name, err)
+	}
+
+	if kerrors.IsNotFound(err) && standbyPVC.Status.Phase == corev1.ClaimBound &&
+		objectRefMatches(standbyPVC.Spec.DataSource, &imageRef) {
+		if err := v.preBindStandbyPV(standbyPVC.Spec.VolumeName, rdSpec.ProtectedPVC.Name); err != nil {
+			return err
+		}
+	}
+
+	v.log.Info("Deleting standby PVC", "pvcName", standbyPVC.GetName())
+
+	if err := v.client.Delete(v.ctx, standbyPVC); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting standby pvc %s (%w)", standbyPVC.GetName(), err)
+	}
+
+	return nil
+}
+
+// preBindStandbyPV retains the volume of a warm standby PVC, recording its
+// reclaim policy, and pre-binds it to the PVC it stands by for
+func (v *VSHandler) preBindStandbyPV(pvName, pvcName string) error {
+	pv := &corev1.PersistentVolume{}
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: pvName}, pv); err != nil {
+		return fmt.Errorf("error getting standby pv %s (%w)", pvName, err)
+	}
+
+	if _, recorded := pv.GetAnnotations()[standbyReclaimPolicyAnnotation]; !recorded {
+		setAnnotations(pv, map[string]string{
+			standbyReclaimPolicyAnnotation: string(pv.Spec.PersistentVolumeReclaimPolicy),
+		})
+	}
+
+	pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+	pv.Spec.ClaimRef = &corev1.ObjectReference{
+		Kind:       "PersistentVolumeClaim",
+		APIVersion: "v1",
+		Namespace:  v.owner.GetNamespace(),
+		Name:       pvcName,
+	}
+
+	if err := v.client.Update(v.ctx, pv); err != nil {
+		return fmt.Errorf("error pre-binding standby pv %s to pvc %s (%w)", pvName, pvcName, err)
+	}
+
+	v.log.Info("Pre-bound standby PV to PVC", "pvName", pvName, "pvcName", pvcName)
+
+	return nil
+}
+
+// restoreAdoptedPVReclaimPolicy restores the reclaim policy of the standby
+// volume adopted by the PVC, once the PVC is bound to it
+func (v *VSHandler) restoreAdoptedPVReclaimPolicy(pvcName string) error {
+	pvc, err := v.getPVC(pvcName)
+	if err != nil {
+		return fmt.Errorf("error getting pvc %s (%w)", pvcName, err)
+	}
+
+	if pvc.Status.Phase != corev1.ClaimBound || pvc.Spec.VolumeName == "" {
+		return nil
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := v.client.Get(v.ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+		return fmt.Errorf("error getting pv %s of pvc %s (%w)", pvc.Spec.VolumeName, pvcName, err)
+	}
+
+	reclaimPolicy, adopted := pv.GetAnnotations()[standbyReclaimPolicyAnnotation]
+	if !adopted {
+		return nil
+	}
+
+	pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimPolicy(reclaimPolicy)
+	delete(pv.Annotations, standbyReclaimPolicyAnnotation)
+
+	if err := v.client.Update(v.ctx, pv); err != nil {
+		return fmt.Errorf("error restoring reclaim policy of pv %s (%w)", pv.GetName(), err)
+	}
+
+	return nil
+}
+
+// DeleteStandbyPVCs deletes the warm standby PVCs of the owner
+func (v *VSHandler) DeleteStandbyPVCs() error {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+
+	err := v.client.List(v.ctx, pvcList, client.InNamespace(v.owner.GetNamespace()),
+		client.MatchingLabels{WarmStandbyLabel: v.owner.GetName()})
+	if err != nil {
+		return fmt.Errorf("error listing standby pvcs (%w)", err)
+	}
+
+	for idx := range pvcList.Items {
+		pvc := &pvcList.Items[idx]
+
+		v.log.Info("Deleting standby PVC", "pvcName", pvc.GetName())
+
+		if err := v.client.Delete(v.ctx, pvc); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("error deleting standby pvc %s (%w)", pvc.GetName(), err)
+		}
+	}
+
+	return nil
 }
 
 func (v *VSHandler) validateSnapshotAndEnsurePVC(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
 	snapshotRef corev1.TypedLocalObjectReference) error {
+	if snapshotRef.Kind != VolumeSnapshotKind {
+		return v.validateImagePVCAndEnsurePVC(rdSpec, snapshotRef)
+	}
+
 	snap, err := v.validateSnapshotAndAddDoNotDeleteLabel(snapshotRef)
 	if err != nil {
 		return err
@@ -888,6 +1323,33 @@ func (v *VSHandler) validateSnapshotAndEnsurePVC(rdSpec ramendrv1alpha1.VolSyncR
 	return v.addOwnerReferenceAndUpdate(snap, pvc)
 }
 
+// validateImagePVCAndEnsurePVC restores the PVC as a clone of the PVC that the
+// ReplicationDestination replicates directly to, with other than the Snapshot
+// copy method
+func (v *VSHandler) validateImagePVCAndEnsurePVC(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	imageRef corev1.TypedLocalObjectReference,
+) error {
+	if imageRef.Kind != "PersistentVolumeClaim" {
+		return fmt.Errorf("unsupported latest image %s/%s to restore pvc %s from", imageRef.Kind, imageRef.Name,
+			rdSpec.ProtectedPVC.Name)
+	}
+
+	imagePVC := &corev1.PersistentVolumeClaim{}
+
+	err := v.client.Get(v.ctx, types.NamespacedName{Name: imageRef.Name, Namespace: v.owner.GetNamespace()}, imagePVC)
+	if err != nil {
+		return fmt.Errorf("error getting latest image pvc %s (%w)", imageRef.Name, err)
+	}
+
+	pvc, err := v.ensurePVCFromSnapshot(rdSpec, imageRef)
+	if err != nil {
+		return err
+	}
+
+	// Keep the PVC of the ReplicationDestination as long as the clone of it, which may be provisioned as it is used
+	return v.addOwnerReferenceAndUpdate(imagePVC, pvc)
+}
+
 //nolint:funlen,gocognit,cyclop
 func (v *VSHandler) ensurePVCFromSnapshot(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
 	snapshotRef corev1.TypedLocalObjectReference) (*corev1.PersistentVolumeClaim, error) {
@@ -933,11 +1395,14 @@ func (v *VSHandler) ensurePVCFromSnapshot(rdSpec ramendrv1alpha1.VolSyncReplicat
 		if pvc.CreationTimestamp.IsZero() { // set immutable fields
 			pvc.Spec.AccessModes = accessModes
 			pvc.Spec.StorageClassName = rdSpec.ProtectedPVC.StorageClassName
+			pvc.Spec.VolumeMode = rdSpec.ProtectedPVC.VolumeMode
 
 			// Only set when initially creating
 			pvc.Spec.DataSource = &snapshotRef
 		}
 
+		setAnnotations(pvc, rdSpec.ProtectedPVC.Annotations)
+
 		pvc.Spec.Resources = rdSpec.ProtectedPVC.Resources
 
 		return nil
@@ -1081,26 +1546,49 @@ func (v *VSHandler) addOwnerReferenceAndUpdate(obj client.Object, owner metav1.O
 	return nil
 }
 
-func (v *VSHandler) getRsyncServiceType() *corev1.ServiceType {
-	// Use default right now - in future we may use a volsyncProfile
-	return &DefaultRsyncServiceType
+func (v *VSHandler) GetVolumeSnapshotClassFromPVCStorageClass(storageClassName *string) (string, error) {
+	storageClass, err := v.getStorageClass(storageClassName)
+	if err != nil {
+		return "", err
+	}
+
+	matchedVolumeSnapshotClassName, err := v.findVolumeSnapshotClassName(storageClass.Provisioner)
+	if err != nil {
+		return "", err
+	}
+
+	if matchedVolumeSnapshotClassName == "" {
+		noVSCFoundErr := fmt.Errorf("unable to find matching volumesnapshotclass for storage provisioner %s",
+			storageClass.Provisioner)
+		v.log.Error(noVSCFoundErr, "No VolumeSnapshotClass found")
+
+		return "", noVSCFoundErr
+	}
+
+	return matchedVolumeSnapshotClassName, nil
 }
 
-func (v *VSHandler) GetVolumeSnapshotClassFromPVCStorageClass(storageClassName *string) (string, error) {
+func (v *VSHandler) getStorageClass(storageClassName *string) (*storagev1.StorageClass, error) {
 	if storageClassName == nil || *storageClassName == "" {
 		err := fmt.Errorf("no storageClassName given, cannot proceed")
 		v.log.Error(err, "Failed to get StorageClass")
 
-		return "", err
+		return nil, err
 	}
 
 	storageClass := &storagev1.StorageClass{}
 	if err := v.client.Get(v.ctx, types.NamespacedName{Name: *storageClassName}, storageClass); err != nil {
 		v.log.Error(err, "Failed to get StorageClass", "name", storageClassName)
 
-		return "", fmt.Errorf("error getting storage class (%w)", err)
+		return nil, fmt.Errorf("error getting storage class (%w)", err)
 	}
 
+	return storageClass, nil
+}
+
+// findVolumeSnapshotClassName returns the name of the VolumeSnapshotClass for
+// the provisioner, or an empty name if there is none
+func (v *VSHandler) findVolumeSnapshotClassName(provisioner string) (string, error) {
 	volumeSnapshotClasses, err := v.GetVolumeSnapshotClasses()
 	if err != nil {
 		return "", err
@@ -1109,7 +1597,7 @@ func (v *VSHandler) GetVolumeSnapshotClassFromPVCStorageClass(storageClassName *
 	var matchedVolumeSnapshotClassName string
 
 	for _, volumeSnapshotClass := range volumeSnapshotClasses {
-		if volumeSnapshotClass.Driver == storageClass.Provisioner {
+		if volumeSnapshotClass.Driver == provisioner {
 			// Match the first one where driver/provisioner == the storage class provisioner
 			// But keep looping - if we find the default storageVolumeClass, use it instead
 			if matchedVolumeSnapshotClassName == "" || isDefaultVolumeSnapshotClass(volumeSnapshotClass) {
@@ -1118,14 +1606,6 @@ func (v *VSHandler) GetVolumeSnapshotClassFromPVCStorageClass(storageClassName *
 		}
 	}
 
-	if matchedVolumeSnapshotClassName == "" {
-		noVSCFoundErr := fmt.Errorf("unable to find matching volumesnapshotclass for storage provisioner %s",
-			storageClass.Provisioner)
-		v.log.Error(noVSCFoundErr, "No VolumeSnapshotClass found")
-
-		return "", noVSCFoundErr
-	}
-
 	return matchedVolumeSnapshotClassName, nil
 }
 
@@ -1136,6 +1616,9 @@ func isDefaultVolumeSnapshotClass(volumeSnapshotClass snapv1.VolumeSnapshotClass
 }
 
 func (v *VSHandler) GetVolumeSnapshotClasses() ([]snapv1.VolumeSnapshotClass, error) {
+	v.volumeSnapshotClassListLock.Lock()
+	defer v.volumeSnapshotClassListLock.Unlock()
+
 	if v.volumeSnapshotClassList == nil {
 		// Load the list if it hasn't been initialized yet
 		v.log.Info("Fetching VolumeSnapshotClass", "labelSelector", v.volumeSnapshotClassSelector)
@@ -1166,71 +1649,36 @@ func (v *VSHandler) GetVolumeSnapshotClasses() ([]snapv1.VolumeSnapshotClass, er
 	return v.volumeSnapshotClassList.Items, nil
 }
 
-func (v *VSHandler) getScheduleCronSpec() (*string, error) {
-	if v.schedulingInterval != "" {
-		return ConvertSchedulingIntervalToCronSpec(v.schedulingInterval)
-	}
-
-	// Use default value if not specified
-	v.log.Info("Warning - scheduling interval is empty, using default Schedule for volsync",
-		"DefaultScheduleCronSpec", DefaultScheduleCronSpec)
-
-	return &DefaultScheduleCronSpec, nil
-}
-
-// Convert from schedulingInterval which is in the format of <num><m,h,d>
-// to the format VolSync expects, which is cronspec: https://en.wikipedia.org/wiki/Cron#Overview
-func ConvertSchedulingIntervalToCronSpec(schedulingInterval string) (*string, error) {
-	// format needs to have at least 1 number and end with m or h or d
-	if len(schedulingInterval) < SchedulingIntervalMinLength {
-		return nil, fmt.Errorf("scheduling interval %s is invalid", schedulingInterval)
-	}
-
-	mhd := schedulingInterval[len(schedulingInterval)-1:]
-	mhd = strings.ToLower(mhd) // Make sure we get lowercase m, h or d
-
-	num := schedulingInterval[:len(schedulingInterval)-1]
+// IsRSDataProtected returns true if at least one sync has completed to any of the destinations, that the PVC can
+// fail over to. Whether the PVC is protected on each of the destinations is reported in the status of the protected
+// PVC, for the target of a failover or relocation to be gated on.
+func (v *VSHandler) IsRSDataProtected(pvcName string) (bool, error) {
+	var firstErr error
 
-	numInt, err := strconv.Atoi(num)
-	if err != nil {
-		return nil, fmt.Errorf("scheduling interval prefix %s cannot be convered to an int value", num)
-	}
-
-	var cronSpec string
-
-	switch mhd {
-	case "m":
-		cronSpec = fmt.Sprintf("*/%s * * * *", num)
-	case "h":
-		// TODO: cronspec has a max here of 23 hours - do we try to convert into days?
-		cronSpec = fmt.Sprintf("0 */%s * * *", num)
-	case "d":
-		if numInt > CronSpecMaxDayOfMonth {
-			// Max # of days in interval we'll allow is 28 - otherwise there are issues converting to a cronspec
-			// which is expected to be a day of the month (1-31).  I.e. if we tried to set to */31 we'd get
-			// every 31st day of the month
-			num = "28"
+	for _, destination := range v.Destinations() {
+		protected, err := v.IsRSDataProtectedToDestination(pvcName, destination)
+		if protected {
+			return true, nil
 		}
 
-		cronSpec = fmt.Sprintf("0 0 */%s * *", num)
-	}
-
-	if cronSpec == "" {
-		return nil, fmt.Errorf("scheduling interval %s is invalid. Unable to parse m/h/d", schedulingInterval)
+		if firstErr == nil {
+			firstErr = err
+		}
 	}
 
-	return &cronSpec, nil
+	return false, firstErr
 }
 
-func (v *VSHandler) IsRSDataProtected(pvcName string) (bool, error) {
-	l := v.log.WithValues("pvcName", pvcName)
+// IsRSDataProtectedToDestination returns true if at least one sync has completed to the destination
+func (v *VSHandler) IsRSDataProtectedToDestination(pvcName, destination string) (bool, error) {
+	l := v.log.WithValues("pvcName", pvcName, "destination", destination)
 
-	// Get RD instance
+	// Get RS instance
 	rs := &volsyncv1alpha1.ReplicationSource{}
 
 	err := v.client.Get(v.ctx,
 		types.NamespacedName{
-			Name:      getReplicationSourceName(pvcName),
+			Name:      getReplicationSourceName(pvcName, destination),
 			Namespace: v.owner.GetNamespace(),
 		}, rs)
 	if err != nil {
@@ -1264,7 +1712,7 @@ func (v *VSHandler) getRDLatestImage(pvcName string) (*corev1.TypedLocalObjectRe
 
 	err := v.client.Get(v.ctx,
 		types.NamespacedName{
-			Name:      getReplicationDestinationName(pvcName),
+			Name:      getReplicationDestinationName(pvcName, v.destinationName),
 			Namespace: v.owner.GetNamespace(),
 		}, rdInst)
 	if err != nil {
@@ -1298,7 +1746,12 @@ func (v *VSHandler) IsRDDataProtected(pvcName string) (bool, error) {
 }
 
 func isLatestImageReady(latestImage *corev1.TypedLocalObjectReference) bool {
-	if latestImage == nil || latestImage.Name == "" || latestImage.Kind != VolumeSnapshotKind {
+	if latestImage == nil || latestImage.Name == "" {
+		return false
+	}
+
+	// The PVC that the ReplicationDestination replicates directly to, with other than the Snapshot copy method
+	if latestImage.Kind != VolumeSnapshotKind && latestImage.Kind != "PersistentVolumeClaim" {
 		return false
 	}
 
@@ -1316,17 +1769,42 @@ func addVRGOwnerLabel(owner, obj metav1.Object) {
 	obj.SetLabels(labels)
 }
 
-func getReplicationDestinationName(pvcName string) string {
-	return pvcName // Use PVC name as name of ReplicationDestination
+// Use PVC name as name of ReplicationDestination, suffixed with the name of the destination, if any, so that the
+// services of the ReplicationDestinations of all destinations can be told apart
+func getReplicationDestinationName(pvcName, destinationName string) string {
+	return getDestinationResourceName(pvcName, destinationName)
 }
 
-func getReplicationSourceName(pvcName string) string {
-	return pvcName // Use PVC name as name of ReplicationSource
+func getRDDestinationPVCName(pvcName string) string {
+	return fmt.Sprintf("volsync-%s-dst", pvcName)
+}
+
+func getStandbyPVCName(pvcName string) string {
+	return fmt.Sprintf("volsync-%s-standby", pvcName)
+}
+
+// Use PVC name as name of ReplicationSource, suffixed with the name of the destination, if any
+func getReplicationSourceName(pvcName, destination string) string {
+	return getDestinationResourceName(pvcName, destination)
+}
+
+// getDestinationResourceName returns the PVC name, suffixed with the name of the destination, if any, and a hash of
+// both. PVC names may contain dashes followed by the name of a destination, so that without the hash the names of
+// the resources of a PVC to one destination could be the names of those of another PVC.
+func getDestinationResourceName(pvcName, destination string) string {
+	if destination == "" {
+		return pvcName
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(pvcName + "/" + destination))
+
+	return fmt.Sprintf("%s-%s-%08x", pvcName, destination, hash.Sum32())
 }
 
 // Service name that VolSync will create locally in the same namespace as the ReplicationDestination
-func getLocalServiceNameForRDFromPVCName(pvcName string) string {
-	return getLocalServiceNameForRD(getReplicationDestinationName(pvcName))
+func getLocalServiceNameForRDFromPVCName(pvcName, destinationName string) string {
+	return getLocalServiceNameForRD(getReplicationDestinationName(pvcName, destinationName))
 }
 
 func getLocalServiceNameForRD(rdName string) string {
@@ -1336,8 +1814,9 @@ func getLocalServiceNameForRD(rdName string) string {
 
 // This is the remote service name that can be accessed from another cluster.  This assumes submariner and that
 // a ServiceExport is created for the service on the cluster that has the ReplicationDestination
-func getRemoteServiceNameForRDFromPVCName(pvcName, rdNamespace string) string {
-	return fmt.Sprintf("%s.%s.svc.clusterset.local", getLocalServiceNameForRDFromPVCName(pvcName), rdNamespace)
+func getRemoteServiceNameForRDFromPVCName(pvcName, destinationName, rdNamespace string) string {
+	return fmt.Sprintf("%s.%s.svc.clusterset.local", getLocalServiceNameForRDFromPVCName(pvcName, destinationName),
+		rdNamespace)
 }
 
 func getKindAndName(scheme *runtime.Scheme, obj client.Object) string {
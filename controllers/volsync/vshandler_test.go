@@ -2,6 +2,7 @@ package volsync_test
 
 import (
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
@@ -51,11 +54,29 @@ var _ = Describe("VolSync Handler - utils", func() {
 			Expect(cronSpecSchedule).ToNot(BeNil())
 			Expect(*cronSpecSchedule).To(Equal("0 */12 * * *"))
 		})
-		It("Should successfully convert an interval specified in days", func() {
-			cronSpecSchedule, err := volsync.ConvertSchedulingIntervalToCronSpec("13d")
+		It("Should successfully convert an interval specified in minutes that is a multiple of an hour", func() {
+			cronSpecSchedule, err := volsync.ConvertSchedulingIntervalToCronSpec("120m")
 			Expect(err).NotTo((HaveOccurred()))
 			Expect(cronSpecSchedule).ToNot(BeNil())
-			Expect(*cronSpecSchedule).To(Equal("0 0 */13 * *"))
+			Expect(*cronSpecSchedule).To(Equal("0 */2 * * *"))
+		})
+		It("Should successfully convert an interval of a day", func() {
+			cronSpecSchedule, err := volsync.ConvertSchedulingIntervalToCronSpec("1d")
+			Expect(err).NotTo((HaveOccurred()))
+			Expect(cronSpecSchedule).ToNot(BeNil())
+			Expect(*cronSpecSchedule).To(Equal("0 0 * * *"))
+		})
+		It("Should fail if interval does not evenly divide an hour or a day", func() {
+			for _, schedulingInterval := range []string{"90m", "7m", "25h", "5h", "13d"} {
+				_, err := volsync.ConvertSchedulingIntervalToCronSpec(schedulingInterval)
+				Expect(err).To(HaveOccurred(), schedulingInterval)
+			}
+		})
+		It("Should fail if interval is out of range", func() {
+			for _, schedulingInterval := range []string{"0m", "366d", "99999999999d"} {
+				_, err := volsync.ConvertSchedulingIntervalToCronSpec(schedulingInterval)
+				Expect(err).To(HaveOccurred(), schedulingInterval)
+			}
 		})
 		It("Should fail if interval is invalid (no num)", func() {
 			_, err := volsync.ConvertSchedulingIntervalToCronSpec("d")
@@ -182,7 +203,7 @@ var _ = Describe("VolSync Handler", func() {
 	var vsHandler *volsync.VSHandler
 
 	schedulingInterval := "5m"
-	expectedCronSpecSchedule := "*/5 * * * *"
+	var expectedCronSpecSchedule string
 
 	BeforeEach(func() {
 		// Create namespace for test
@@ -206,6 +227,7 @@ var _ = Describe("VolSync Handler", func() {
 		Expect(k8sClient.Create(ctx, ownerCm)).To(Succeed())
 		Expect(ownerCm.GetName()).NotTo(BeEmpty())
 		owner = ownerCm
+		expectedCronSpecSchedule = expectedCronSpec(owner, 5)
 
 		vsHandler = volsync.NewVSHandler(ctx, k8sClient, logger, owner, schedulingInterval, metav1.LabelSelector{})
 	})
@@ -375,35 +397,23 @@ var _ = Describe("VolSync Handler", func() {
 						Expect(ownerMatches(svcExport, createdRD.GetName(), "ReplicationDestination", false)).To(BeTrue())
 					})
 
-					/*
-						Context("When empty volsyncProfile is specified", func() {
-							It("Should use the default rsync service type in the ReplicationDestination", func() {
-								Expect(*createdRD.Spec.Rsync.ServiceType).To(Equal(volsync.DefaultRsyncServiceType))
-							})
+					Context("When empty volsyncProfile is specified", func() {
+						BeforeEach(func() {
+							vsHandler.SetVolSyncProfile(&ramendrv1alpha1.VolSyncProfile{})
 						})
-
-						Context("When no volsyncProfile is specified", func() {
-							BeforeEach(func() {
-								vsHandler.SetVolSyncProfile(nil)
-							})
-							It("Should use the default rsync service type in the ReplicationDestination", func() {
-								Expect(*createdRD.Spec.Rsync.ServiceType).To(Equal(volsync.DefaultRsyncServiceType))
-							})
+						It("Should use the default rsync service type in the ReplicationDestination", func() {
+							Expect(*createdRD.Spec.Rsync.ServiceType).To(Equal(volsync.DefaultRsyncServiceType))
 						})
+					})
 
-						Context("When a volsyncProfile is specified with serviceType", func() {
-							typeLoadBalancer := corev1.ServiceTypeLoadBalancer
-							BeforeEach(func() {
-								vsHandler.SetVolSyncProfile(&ramendrv1alpha1.VolSyncProfile{
-									VolSyncProfileName: "default",
-									ServiceType:        &typeLoadBalancer,
-								})
-							})
-							It("Should use the rsync service type in the VolSyncProfile", func() {
-								Expect(*createdRD.Spec.Rsync.ServiceType).To(Equal(typeLoadBalancer))
-							})
+					Context("When no volsyncProfile is specified", func() {
+						BeforeEach(func() {
+							vsHandler.SetVolSyncProfile(nil)
 						})
-					*/
+						It("Should use the default rsync service type in the ReplicationDestination", func() {
+							Expect(*createdRD.Spec.Rsync.ServiceType).To(Equal(volsync.DefaultRsyncServiceType))
+						})
+					})
 
 					Context("When replication destination already exists with status.address specified", func() {
 						myTestAddress := "https://fakeaddress.abc.org:8888"
@@ -450,6 +460,68 @@ var _ = Describe("VolSync Handler", func() {
 							Expect(returnedRD).ToNot(BeNil())
 						})
 					})
+
+					Context("When the pvc to protect is in block volume mode", func() {
+						blockMode := corev1.PersistentVolumeBlock
+						BeforeEach(func() {
+							rdSpec.ProtectedPVC.VolumeMode = &blockMode
+							rdSpec.ProtectedPVC.Annotations = map[string]string{"csi.example.com/key": "val"}
+						})
+						AfterEach(func() {
+							rdSpec.ProtectedPVC.VolumeMode = nil
+							rdSpec.ProtectedPVC.Annotations = nil
+						})
+
+						It("Should provision the destination pvc in block volume mode", func() {
+							Expect(createdRD.Spec.Rsync.DestinationPVC).ToNot(BeNil())
+
+							dstPVC := &corev1.PersistentVolumeClaim{}
+							Eventually(func() error {
+								return k8sClient.Get(ctx, types.NamespacedName{
+									Name:      *createdRD.Spec.Rsync.DestinationPVC,
+									Namespace: testNamespace.GetName(),
+								}, dstPVC)
+							}, maxWait, interval).Should(Succeed())
+
+							Expect(dstPVC.Spec.VolumeMode).To(Equal(&blockMode))
+							Expect(dstPVC.Annotations).To(HaveKeyWithValue("csi.example.com/key", "val"))
+							Expect(*dstPVC.Spec.StorageClassName).To(Equal(testStorageClassName))
+							Expect(ownerMatches(dstPVC, createdRD.GetName(), "ReplicationDestination", true)).To(BeTrue())
+						})
+
+						It("Should only grow the storage request of the destination pvc, once bound", func() {
+							dstPVC := &corev1.PersistentVolumeClaim{}
+							dstPVCKey := types.NamespacedName{
+								Name:      *createdRD.Spec.Rsync.DestinationPVC,
+								Namespace: testNamespace.GetName(),
+							}
+							storageRequest := func() string {
+								Expect(k8sClient.Get(ctx, dstPVCKey, dstPVC)).To(Succeed())
+								request := dstPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+
+								return request.String()
+							}
+							reconcileRDWithRequest := func(request string) {
+								resizedRDSpec := rdSpec
+								resizedRDSpec.ProtectedPVC.Resources = corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(request)},
+								}
+								_, err := vsHandler.ReconcileRD(resizedRDSpec)
+								Expect(err).ToNot(HaveOccurred())
+							}
+
+							reconcileRDWithRequest("1Gi")
+							Expect(storageRequest()).To(Equal(capacity.String()))
+
+							reconcileRDWithRequest("3Gi")
+							Expect(storageRequest()).To(Equal(capacity.String()))
+
+							dstPVC.Status.Phase = corev1.ClaimBound
+							Expect(k8sClient.Status().Update(ctx, dstPVC)).To(Succeed())
+							reconcileRDWithRequest("3Gi")
+							Eventually(storageRequest, maxWait, interval).Should(Equal("3Gi"))
+						})
+					})
 				})
 			})
 		})
@@ -689,9 +761,9 @@ var _ = Describe("VolSync Handler", func() {
 							Expect(*createdRS.Spec.Rsync.VolumeSnapshotClassName).To(Equal(testVolumeSnapshotClassName))
 
 							Expect(createdRS.Spec.Trigger).ToNot(BeNil())
-							Expect(createdRS.Spec.Trigger).To(Equal(&volsyncv1alpha1.ReplicationSourceTriggerSpec{
-								Schedule: &expectedCronSpecSchedule,
-							}))
+							Expect(createdRS.Spec.Trigger.Manual).To(BeEmpty())
+							Expect(createdRS.Spec.Trigger.Schedule).ToNot(BeNil())
+							Expect(*createdRS.Spec.Trigger.Schedule).To(Equal(expectedCronSpecSchedule))
 							Expect(createdRS.GetLabels()).To(HaveKeyWithValue(volsync.VRGOwnerLabel, owner.GetName()))
 						})
 
@@ -720,124 +792,1059 @@ var _ = Describe("VolSync Handler", func() {
 								}
 								Expect(k8sClient.Create(ctx, rsPrecreate)).To(Succeed())
 
-								//
-								// Make sure the RS is created
-								//
-								Eventually(func() error {
-									return k8sClient.Get(ctx, client.ObjectKeyFromObject(rsPrecreate), rsPrecreate)
-								}, maxWait, interval).Should(Succeed())
-							})
+								//
+								// Make sure the RS is created
+								//
+								Eventually(func() error {
+									return k8sClient.Get(ctx, client.ObjectKeyFromObject(rsPrecreate), rsPrecreate)
+								}, maxWait, interval).Should(Succeed())
+							})
+
+							It("Should properly update ReplicationSource and return rsInfo", func() {
+								// all checks here performed in the JustBeforeEach(common checks)
+								Expect(returnedRS).NotTo(BeNil())
+							})
+
+							It("Should expect reconcileRS to return a replicationsource", func() {
+								// reconcile should return our RS
+								Expect(returnedRS).NotTo(BeNil())
+							})
+
+							Context("When running a final sync", func() {
+								Context("When the pvc is still in use", func() {
+									It("Should not complete the final sync", func() {
+										finalSyncDone, returnedRS, err := vsHandler.ReconcileRS(rsSpec, true)
+										Expect(err).NotTo(HaveOccurred()) // Not considered an error, we should just wait
+										Expect(returnedRS).To(BeNil())
+										Expect(finalSyncDone).To(BeFalse())
+									})
+								})
+
+								Context("When the pvc is no longer in use", func() {
+									JustBeforeEach(func() {
+										// Pod mounting PVC is created above - delete it to simulate removing app
+										// Fake out the pod in "Running" Phase
+										Expect(k8sClient.Delete(ctx, podMountingPVC)).To(Succeed())
+										Eventually(func() bool {
+											err := k8sClient.Get(ctx, client.ObjectKeyFromObject(podMountingPVC), podMountingPVC)
+
+											return kerrors.IsNotFound(err)
+										}, maxWait, interval).Should(BeTrue())
+									})
+
+									It("Should update the trigger on the RS and return true when replication is complete"+
+										" and also delete the pvc after replication complete", func() {
+										// Run ReconcileRS - indicate final sync
+										finalSyncDone, returnedRS, err := vsHandler.ReconcileRS(rsSpec, true)
+										Expect(err).ToNot(HaveOccurred())
+										Expect(finalSyncDone).To(BeFalse()) // Should not return true since sync has not completed
+										Expect(returnedRS).NotTo(BeNil())
+
+										// Check that the manual sync triggger is set correctly on the RS
+										Eventually(func() string {
+											err := k8sClient.Get(ctx,
+												types.NamespacedName{
+													Name:      rsSpec.ProtectedPVC.Name,
+													Namespace: testNamespace.GetName(),
+												},
+												createdRS)
+											if err != nil || createdRS.Spec.Trigger == nil {
+												return ""
+											}
+
+											return createdRS.Spec.Trigger.Manual
+										}, maxWait, interval).Should(Equal(volsync.FinalSyncTriggerString))
+
+										// We have triggered a final sync - manually update the status on the RS to
+										// simulate that it has completed the sync and confirm ReconcileRS correctly sees the update
+										now := metav1.Now()
+										createdRS.Status = &volsyncv1alpha1.ReplicationSourceStatus{
+											LastManualSync: volsync.FinalSyncTriggerString,
+											LastSyncTime:   &now,
+										}
+										Expect(k8sClient.Status().Update(ctx, createdRS)).To(Succeed())
+
+										Eventually(func() bool {
+											// Make sure the update has been picked up by the client cache
+											err := k8sClient.Get(ctx, client.ObjectKeyFromObject(createdRS), createdRS)
+											if err != nil {
+												return false
+											}
+
+											return createdRS.Status != nil && createdRS.Status.LastManualSync != ""
+										}, maxWait, interval).Should(BeTrue())
+
+										finalSyncDone, returnedRS, err = vsHandler.ReconcileRS(rsSpec, true)
+										Expect(err).ToNot(HaveOccurred())
+										Expect(finalSyncDone).To(BeTrue())
+										Expect(returnedRS).NotTo(BeNil())
+
+										// Now check to see if the pvc was removed
+										Eventually(func() bool {
+											err := k8sClient.Get(ctx, client.ObjectKeyFromObject(testPVC), testPVC)
+											if err == nil {
+												if !testPVC.GetDeletionTimestamp().IsZero() {
+													// PVC protection finalizer is added automatically to PVC - but testenv
+													// doesn't have anything that will remove it for us - we're good as long
+													// as the pvc is marked for deletion
+
+													testPVC.Finalizers = []string{} // Clear finalizers
+													Expect(k8sClient.Update(ctx, testPVC)).To(Succeed())
+												}
+
+												return false // try again
+											}
+
+											return kerrors.IsNotFound(err)
+										}, maxWait, interval).Should(BeTrue())
+
+										// Run reconcileRS with final sync again, even with PVC removed it should be able to
+										// reconcile RS and check from the status that the final sync is complete
+										finalSyncDone, returnedRS, err = vsHandler.ReconcileRS(rsSpec, true)
+										Expect(err).ToNot(HaveOccurred())
+										Expect(finalSyncDone).To(BeTrue())
+										Expect(returnedRS).NotTo(BeNil())
+									})
+								})
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Describe("Copy method of the PVCs of a StorageClass", func() {
+		var storageClass *storagev1.StorageClass
+
+		createStorageClass := func(name, provisioner, copyMethod string) {
+			storageClass = &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Provisioner: provisioner,
+			}
+			if copyMethod != "" {
+				storageClass.Annotations = map[string]string{volsync.CopyMethodAnnotation: copyMethod}
+			}
+			Expect(k8sClient.Create(ctx, storageClass)).To(Succeed())
+		}
+
+		AfterEach(func() {
+			if storageClass != nil {
+				Expect(k8sClient.Delete(ctx, storageClass)).To(Succeed())
+				storageClass = nil
+			}
+		})
+
+		It("Should copy with snapshots when a VolumeSnapshotClass matches the provisioner", func() {
+			copyMethod, err := vsHandler.GetCopyMethod(&testStorageClassName, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(copyMethod).To(Equal(volsync.CopyMethodSnapshot))
+		})
+
+		It("Should fail to copy when no VolumeSnapshotClass matches the provisioner, and none is configured", func() {
+			createStorageClass("sc-copy-discovered", "no.snapshots.provisioner", "")
+
+			_, err := vsHandler.GetCopyMethod(&storageClass.Name, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to find matching volumesnapshotclass"))
+			Expect(err.Error()).To(ContainSubstring(volsync.CopyMethodAnnotation))
+		})
+
+		It("Should copy with clones when configured, for the StorageClass of a CSI driver", func() {
+			csiDriver := &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "clone.csi.driver"}}
+			Expect(k8sClient.Create(ctx, csiDriver)).To(Succeed())
+			defer func() { Expect(k8sClient.Delete(ctx, csiDriver)).To(Succeed()) }()
+
+			createStorageClass("sc-copy-clone", csiDriver.Name, string(volsync.CopyMethodClone))
+
+			copyMethod, err := vsHandler.GetCopyMethod(&storageClass.Name, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(copyMethod).To(Equal(volsync.CopyMethodClone))
+		})
+
+		It("Should fail to copy with clones when configured, for the StorageClass of a non CSI provisioner", func() {
+			createStorageClass("sc-copy-clone-in-tree", "kubernetes.io/no-csi", string(volsync.CopyMethodClone))
+
+			_, err := vsHandler.GetCopyMethod(&storageClass.Name, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not a CSI driver"))
+		})
+
+		It("Should fail to copy with snapshots when configured, and no VolumeSnapshotClass matches", func() {
+			createStorageClass("sc-copy-snapshot", "no.snapshots.provisioner", string(volsync.CopyMethodSnapshot))
+
+			_, err := vsHandler.GetCopyMethod(&storageClass.Name, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to find matching volumesnapshotclass"))
+		})
+
+		It("Should fail with an invalid copy method", func() {
+			createStorageClass("sc-copy-invalid", testStorageDriverName, "Teleport")
+
+			_, err := vsHandler.GetCopyMethod(&storageClass.Name, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid copy method"))
+		})
+
+		Context("When the StorageClass is configured with the Direct copy method", func() {
+			capacity := resource.MustParse("1Gi")
+			testPVCName := "mytestpvc"
+
+			var protectedPVC ramendrv1alpha1.ProtectedPVC
+
+			BeforeEach(func() {
+				createStorageClass("sc-copy-direct", testStorageDriverName, string(volsync.CopyMethodDirect))
+
+				protectedPVC = ramendrv1alpha1.ProtectedPVC{
+					Name:               testPVCName,
+					ProtectedByVolSync: true,
+					StorageClassName:   &storageClass.Name,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: capacity,
+						},
+					},
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				}
+
+				// Create a dummy volsync ssh secret (will be pushed down by drpc from hub)
+				sshSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      volsync.GetVolSyncSSHSecretNameFromVRGName(owner.GetName()),
+						Namespace: testNamespace.GetName(),
+					},
+					StringData: map[string]string{
+						"testkey": "testval",
+					},
+				}
+				Expect(k8sClient.Create(ctx, sshSecret)).To(Succeed())
+			})
+
+			It("Should replicate the PVC in use with no copy of it", func() {
+				createDummyPVCAndMountingPod(testPVCName, testNamespace.GetName(),
+					capacity, nil, corev1.PodRunning, true /* pod should be Ready */)
+
+				_, rs, err := vsHandler.ReconcileRS(
+					ramendrv1alpha1.VolSyncReplicationSourceSpec{ProtectedPVC: protectedPVC}, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rs).ToNot(BeNil())
+				Expect(rs.Spec.Rsync.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodNone))
+				Expect(rs.Spec.Rsync.VolumeSnapshotClassName).To(BeNil())
+			})
+
+			It("Should replicate directly to the PVC of the ReplicationDestination", func() {
+				_, err := vsHandler.ReconcileRD(
+					ramendrv1alpha1.VolSyncReplicationDestinationSpec{ProtectedPVC: protectedPVC})
+				Expect(err).ToNot(HaveOccurred())
+
+				rd := &volsyncv1alpha1.ReplicationDestination{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: testPVCName, Namespace: testNamespace.GetName(),
+				}, rd)).To(Succeed())
+				Expect(rd.Spec.Rsync.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodNone))
+				Expect(rd.Spec.Rsync.VolumeSnapshotClassName).To(BeNil())
+			})
+
+			It("Should fail to replicate a PVC that is not ReadWriteMany", func() {
+				copyMethod, err := vsHandler.GetCopyMethod(&storageClass.Name, protectedPVC.AccessModes)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(copyMethod).To(Equal(volsync.CopyMethodDirect))
+
+				protectedPVC.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+
+				_, err = vsHandler.GetCopyMethod(&storageClass.Name, protectedPVC.AccessModes)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(string(corev1.ReadWriteMany)))
+
+				_, err = vsHandler.ReconcileRD(
+					ramendrv1alpha1.VolSyncReplicationDestinationSpec{ProtectedPVC: protectedPVC})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Reconcile with a VolSync profile", func() {
+		capacity := resource.MustParse("2Gi")
+		testPVCName := "mytestpvc"
+		typeLoadBalancer := corev1.ServiceTypeLoadBalancer
+		var testPort int32 = 2222
+
+		protectedPVC := ramendrv1alpha1.ProtectedPVC{
+			Name:               testPVCName,
+			ProtectedByVolSync: true,
+			StorageClassName:   &testStorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: capacity,
+				},
+			},
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		}
+		rdSpec := ramendrv1alpha1.VolSyncReplicationDestinationSpec{ProtectedPVC: protectedPVC}
+
+		var profile *ramendrv1alpha1.VolSyncProfile
+
+		BeforeEach(func() {
+			profile = &ramendrv1alpha1.VolSyncProfile{
+				ServiceType: &typeLoadBalancer,
+				Port:        &testPort,
+			}
+			vsHandler.SetVolSyncProfile(profile)
+
+			// Create a dummy volsync ssh secret (will be pushed down by drpc from hub)
+			sshSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      volsync.GetVolSyncSSHSecretNameFromVRGName(owner.GetName()),
+					Namespace: testNamespace.GetName(),
+				},
+				StringData: map[string]string{
+					"testkey": "testval",
+				},
+			}
+			Expect(k8sClient.Create(ctx, sshSecret)).To(Succeed())
+		})
+
+		Context("When reconciling a ReplicationDestination", func() {
+			rd := &volsyncv1alpha1.ReplicationDestination{}
+
+			JustBeforeEach(func() {
+				_, err := vsHandler.ReconcileRD(rdSpec)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: testPVCName, Namespace: testNamespace.GetName(),
+				}, rd)).To(Succeed())
+			})
+
+			It("Should use the rsync service type and port in the VolSyncProfile, and export no service", func() {
+				Expect(*rd.Spec.Rsync.ServiceType).To(Equal(typeLoadBalancer))
+				Expect(*rd.Spec.Rsync.Port).To(Equal(testPort))
+
+				svcExport := &unstructured.Unstructured{}
+				svcExport.SetGroupVersionKind(schema.GroupVersionKind{
+					Group:   volsync.ServiceExportGroup,
+					Kind:    volsync.ServiceExportKind,
+					Version: volsync.ServiceExportVersion,
+				})
+				Expect(k8sClient.Get(ctx, client.ObjectKey{
+					Name:      fmt.Sprintf("volsync-rsync-dst-%s", rd.GetName()),
+					Namespace: rd.GetNamespace(),
+				}, svcExport)).ToNot(Succeed())
+			})
+
+			It("Should publish no address until the ReplicationDestination reports one", func() {
+				rsyncAddress, err := vsHandler.GetRsyncAddress(rd)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rsyncAddress).To(BeNil())
+			})
+
+			Context("When the ReplicationDestination reports its address", func() {
+				lbAddress := "10.0.0.10"
+
+				JustBeforeEach(func() {
+					rd.Status = &volsyncv1alpha1.ReplicationDestinationStatus{
+						Rsync: &volsyncv1alpha1.ReplicationDestinationRsyncStatus{
+							Address: &lbAddress,
+							Port:    &testPort,
+						},
+					}
+					Expect(k8sClient.Status().Update(ctx, rd)).To(Succeed())
+				})
+
+				It("Should publish the address of the ReplicationDestination", func() {
+					rsyncAddress, err := vsHandler.GetRsyncAddress(rd)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rsyncAddress).To(Equal(&ramendrv1alpha1.VolSyncRsyncAddress{
+						Address: lbAddress,
+						Port:    &testPort,
+					}))
+				})
+
+				Context("When the VolSyncProfile has a fixed address", func() {
+					BeforeEach(func() {
+						profile.Address = "volsync.dr.example.com"
+					})
+
+					It("Should publish the fixed address instead", func() {
+						rsyncAddress, err := vsHandler.GetRsyncAddress(rd)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(rsyncAddress).To(Equal(&ramendrv1alpha1.VolSyncRsyncAddress{
+							Address: "volsync.dr.example.com",
+							Port:    &testPort,
+						}))
+					})
+				})
+			})
+		})
+
+		Context("When reconciling a ReplicationSource", func() {
+			var rsSpec ramendrv1alpha1.VolSyncReplicationSourceSpec
+
+			BeforeEach(func() {
+				rsSpec = ramendrv1alpha1.VolSyncReplicationSourceSpec{ProtectedPVC: protectedPVC}
+
+				createDummyPVCAndMountingPod(testPVCName, testNamespace.GetName(),
+					capacity, nil, corev1.PodRunning, true /* pod should be Ready */)
+			})
+
+			It("Should not create a ReplicationSource until the address of the destination is passed", func() {
+				_, rs, err := vsHandler.ReconcileRS(rsSpec, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rs).To(BeNil())
+			})
+
+			It("Should connect the ReplicationSource to the address passed for the destination", func() {
+				rsSpec.RsyncAddress = &ramendrv1alpha1.VolSyncRsyncAddress{
+					Address: "10.0.0.10",
+					Port:    &testPort,
+				}
+
+				_, rs, err := vsHandler.ReconcileRS(rsSpec, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rs).ToNot(BeNil())
+				Expect(*rs.Spec.Rsync.Address).To(Equal("10.0.0.10"))
+				Expect(*rs.Spec.Rsync.Port).To(Equal(testPort))
+			})
+		})
+	})
+
+	Describe("Reconcile with multiple destinations", func() {
+		capacity := resource.MustParse("2Gi")
+		testPVCName := "mytestpvc"
+
+		protectedPVC := ramendrv1alpha1.ProtectedPVC{
+			Name:               testPVCName,
+			ProtectedByVolSync: true,
+			StorageClassName:   &testStorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: capacity,
+				},
+			},
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		}
+
+		BeforeEach(func() {
+			vsHandler.SetDestinations([]ramendrv1alpha1.VolSyncDestination{
+				{Name: "east"},
+				{Name: "west", SchedulingInterval: "10m"},
+			})
+
+			// Create a dummy volsync ssh secret (will be pushed down by drpc from hub)
+			sshSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      volsync.GetVolSyncSSHSecretNameFromVRGName(owner.GetName()),
+					Namespace: testNamespace.GetName(),
+				},
+				StringData: map[string]string{
+					"testkey": "testval",
+				},
+			}
+			Expect(k8sClient.Create(ctx, sshSecret)).To(Succeed())
+		})
+
+		Context("When reconciling a ReplicationSource per destination", func() {
+			rsEast := &volsyncv1alpha1.ReplicationSource{}
+			rsWest := &volsyncv1alpha1.ReplicationSource{}
+
+			JustBeforeEach(func() {
+				createDummyPVCAndMountingPod(testPVCName, testNamespace.GetName(),
+					capacity, nil, corev1.PodRunning, true /* pod should be Ready */)
+
+				for _, destination := range vsHandler.Destinations() {
+					_, rs, err := vsHandler.ReconcileRS(ramendrv1alpha1.VolSyncReplicationSourceSpec{
+						ProtectedPVC: protectedPVC,
+						Destination:  destination,
+					}, false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rs).ToNot(BeNil())
+				}
+
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: volsync.GetReplicationSourceName(testPVCName, "east"), Namespace: testNamespace.GetName(),
+				}, rsEast)).To(Succeed())
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: volsync.GetReplicationSourceName(testPVCName, "west"), Namespace: testNamespace.GetName(),
+				}, rsWest)).To(Succeed())
+			})
+
+			It("Should replicate the PVC to the ReplicationDestination of each destination", func() {
+				Expect(rsEast.Spec.SourcePVC).To(Equal(testPVCName))
+				Expect(*rsEast.Spec.Rsync.Address).To(Equal("volsync-rsync-dst-" +
+					volsync.GetReplicationDestinationName(testPVCName, "east") + "." +
+					testNamespace.GetName() + ".svc.clusterset.local"))
+				Expect(rsWest.Spec.SourcePVC).To(Equal(testPVCName))
+				Expect(*rsWest.Spec.Rsync.Address).To(Equal("volsync-rsync-dst-" +
+					volsync.GetReplicationDestinationName(testPVCName, "west") + "." +
+					testNamespace.GetName() + ".svc.clusterset.local"))
+			})
+
+			It("Should replicate to each destination at its scheduling interval", func() {
+				Expect(*rsEast.Spec.Trigger.Schedule).To(Equal(expectedCronSpecSchedule))
+				Expect(*rsWest.Spec.Trigger.Schedule).To(Equal(expectedCronSpec(owner, 10)))
+			})
+
+			It("Should delete the ReplicationSources of destinations no longer replicated to", func() {
+				vsHandler.SetDestinations([]ramendrv1alpha1.VolSyncDestination{{Name: "east"}})
+				Expect(vsHandler.CleanupRSNotInDestinations()).To(Succeed())
+
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(rsWest), rsWest)
+
+					return kerrors.IsNotFound(err)
+				}, maxWait, interval).Should(BeTrue())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(rsEast), rsEast)).To(Succeed())
+			})
+
+			It("Should delete the ReplicationSources of all destinations of the PVC", func() {
+				Expect(vsHandler.DeleteRS(testPVCName)).To(Succeed())
+
+				Eventually(func() int {
+					rsList := &volsyncv1alpha1.ReplicationSourceList{}
+					Expect(k8sClient.List(ctx, rsList, client.InNamespace(testNamespace.GetName()))).To(Succeed())
+
+					return len(rsList.Items)
+				}, maxWait, interval).Should(Equal(0))
+			})
+		})
+
+		Context("When reconciling a ReplicationDestination of a destination", func() {
+			BeforeEach(func() {
+				vsHandler.SetDestinationName("west")
+			})
+
+			It("Should name the ReplicationDestination after the destination", func() {
+				_, err := vsHandler.ReconcileRD(
+					ramendrv1alpha1.VolSyncReplicationDestinationSpec{ProtectedPVC: protectedPVC})
+				Expect(err).ToNot(HaveOccurred())
+
+				rd := &volsyncv1alpha1.ReplicationDestination{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: volsync.GetReplicationDestinationName(testPVCName, "west"), Namespace: testNamespace.GetName(),
+				}, rd)).To(Succeed())
+			})
+		})
+
+		It("Should not name the resources of a PVC to a destination after those of another PVC", func() {
+			Expect(volsync.GetReplicationSourceName("app-east", "west")).NotTo(
+				Equal(volsync.GetReplicationSourceName("app", "east-west")))
+			Expect(volsync.GetReplicationDestinationName("app-east", "west")).NotTo(
+				Equal(volsync.GetReplicationDestinationName("app", "east-west")))
+			Expect(volsync.GetReplicationSourceName("app", "west")).NotTo(Equal("app-west"))
+			Expect(volsync.GetReplicationSourceName("app", "west")).To(
+				Equal(volsync.GetReplicationDestinationName("app", "west")))
+		})
+	})
+
+	Describe("Mover scheduling", func() {
+		var moverPod *corev1.Pod
+
+		BeforeEach(func() {
+			moverPod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "volsync-rsync-src-mytestpvc-x1y2z",
+					Namespace: testNamespace.GetName(),
+					Labels:    map[string]string{"job-name": "volsync-rsync-src-mytestpvc"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "rsync", Image: "testimage123"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, moverPod)).To(Succeed())
+		})
+
+		It("Should report no failure while the mover pod is scheduled", func() {
+			Consistently(func() (string, error) {
+				return vsHandler.GetRSMoverSchedulingFailure("mytestpvc")
+			}, 1*time.Second, interval).Should(BeEmpty())
+		})
+
+		Context("When the mover pod fails to schedule", func() {
+			BeforeEach(func() {
+				moverPod.Status.Conditions = []corev1.PodCondition{{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector.",
+				}}
+				Expect(k8sClient.Status().Update(ctx, moverPod)).To(Succeed())
+			})
+
+			It("Should report why the mover pod of the ReplicationSource fails to schedule", func() {
+				Eventually(func() (string, error) {
+					return vsHandler.GetRSMoverSchedulingFailure("mytestpvc")
+				}, maxWait, interval).Should(And(
+					ContainSubstring(moverPod.GetName()),
+					ContainSubstring("didn't match Pod's node affinity/selector")))
+			})
+
+			It("Should not report it for the mover of a ReplicationDestination of the same name", func() {
+				Consistently(func() (string, error) {
+					return vsHandler.GetRDMoverSchedulingFailure("mytestpvc")
+				}, 1*time.Second, interval).Should(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Metrics of the movers", func() {
+		capacity := resource.MustParse("2Gi")
+		testPVCName := "mytestpvc"
+
+		rsSpec := ramendrv1alpha1.VolSyncReplicationSourceSpec{
+			ProtectedPVC: ramendrv1alpha1.ProtectedPVC{
+				Name:               testPVCName,
+				ProtectedByVolSync: true,
+				StorageClassName:   &testStorageClassName,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: capacity,
+					},
+				},
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			},
+		}
+
+		var (
+			labels map[string]string
+			rs     *volsyncv1alpha1.ReplicationSource
+		)
+
+		BeforeEach(func() {
+			// Create a dummy volsync ssh secret (will be pushed down by drpc from hub)
+			sshSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      volsync.GetVolSyncSSHSecretNameFromVRGName(owner.GetName()),
+					Namespace: testNamespace.GetName(),
+				},
+				StringData: map[string]string{
+					"testkey": "testval",
+				},
+			}
+			Expect(k8sClient.Create(ctx, sshSecret)).To(Succeed())
+
+			createDummyPVCAndMountingPod(testPVCName, testNamespace.GetName(),
+				capacity, nil, corev1.PodRunning, true /* pod should be Ready */)
+
+			labels = map[string]string{
+				"vrg_namespace":  testNamespace.GetName(),
+				"vrg":            owner.GetName(),
+				"drpc_namespace": testNamespace.GetName(),
+				"drpc":           owner.GetName(),
+				"pvc":            testPVCName,
+				"kind":           "ReplicationSource",
+				"destination":    "",
+			}
+
+			var err error
+
+			_, rs, err = vsHandler.ReconcileRS(rsSpec, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rs).ToNot(BeNil())
+		})
+
+		AfterEach(func() {
+			vsHandler.DeleteMetrics()
+		})
+
+		It("Should report no synchronization before the first one", func() {
+			_, found := volSyncMetricValue("ramen_volsync_last_sync_timestamp_seconds", labels)
+			Expect(found).To(BeFalse())
+
+			failures, found := volSyncMetricValue("ramen_volsync_observed_mover_failures", labels)
+			Expect(found).To(BeTrue())
+			Expect(failures).To(BeZero())
+		})
+
+		Context("When the ReplicationSource has synchronized", func() {
+			lastSyncTime := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+			nextSyncTime := metav1.NewTime(time.Now().Add(4 * time.Minute).Truncate(time.Second))
+
+			BeforeEach(func() {
+				rs.Status = &volsyncv1alpha1.ReplicationSourceStatus{
+					LastSyncTime:     &lastSyncTime,
+					LastSyncDuration: &metav1.Duration{Duration: 30 * time.Second},
+					NextSyncTime:     &nextSyncTime,
+				}
+				Expect(k8sClient.Status().Update(ctx, rs)).To(Succeed())
+
+				Eventually(func() float64 {
+					_, _, err := vsHandler.ReconcileRS(rsSpec, false)
+					Expect(err).ToNot(HaveOccurred())
+
+					value, _ := volSyncMetricValue("ramen_volsync_last_sync_timestamp_seconds", labels)
+
+					return value
+				}, maxWait, interval).Should(Equal(float64(lastSyncTime.Unix())))
+			})
+
+			It("Should report the last and next synchronizations", func() {
+				duration, _ := volSyncMetricValue("ramen_volsync_last_sync_duration_seconds", labels)
+				Expect(duration).To(Equal(30.0))
+
+				nextSync, _ := volSyncMetricValue("ramen_volsync_next_sync_timestamp_seconds", labels)
+				Expect(nextSync).To(Equal(float64(nextSyncTime.Unix())))
+			})
+
+			It("Should count the mover pods failed since the last synchronization", func() {
+				isController := true
+				moverPod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "volsync-rsync-src-" + testPVCName + "-f4i1d",
+						Namespace: testNamespace.GetName(),
+						Labels:    map[string]string{"job-name": "volsync-rsync-src-" + testPVCName},
+						OwnerReferences: []metav1.OwnerReference{{
+							APIVersion: "batch/v1",
+							Kind:       "Job",
+							Name:       "volsync-rsync-src-" + testPVCName,
+							UID:        "2a9d5f4e-2b45-4a5e-9c3a-6f1e3c3d7a11",
+							Controller: &isController,
+						}},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "rsync", Image: "testimage123"}},
+					},
+				}
+				Expect(k8sClient.Create(ctx, moverPod)).To(Succeed())
+				moverPod.Status.Phase = corev1.PodFailed
+				Expect(k8sClient.Status().Update(ctx, moverPod)).To(Succeed())
+
+				Eventually(func() float64 {
+					_, _, err := vsHandler.ReconcileRS(rsSpec, false)
+					Expect(err).ToNot(HaveOccurred())
+
+					value, _ := volSyncMetricValue("ramen_volsync_observed_mover_failures", labels)
+
+					return value
+				}, maxWait, interval).Should(Equal(1.0))
+			})
+
+			It("Should delete the metrics of a deleted ReplicationSource", func() {
+				Expect(vsHandler.DeleteRS(testPVCName)).To(Succeed())
+
+				Eventually(func() bool {
+					Expect(vsHandler.PruneMetrics()).To(Succeed())
+
+					_, found := volSyncMetricValue("ramen_volsync_last_sync_timestamp_seconds", labels)
+
+					return found
+				}, maxWait, interval).Should(BeFalse())
+			})
+		})
+	})
+
+	Describe("Reconcile with a rotated ssh secret", func() {
+		capacity := resource.MustParse("2Gi")
+		testPVCName := "mytestpvc"
+
+		rdSpec := ramendrv1alpha1.VolSyncReplicationDestinationSpec{
+			ProtectedPVC: ramendrv1alpha1.ProtectedPVC{
+				Name:               testPVCName,
+				ProtectedByVolSync: true,
+				StorageClassName:   &testStorageClassName,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: capacity,
+					},
+				},
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			},
+		}
+
+		var rotatedSecretName string
+		var staleSecretNames []string
+		var otherSecretName string
+
+		createOwnedSecret := func(secretName string) {
+			// Secrets of previous rotations were owned by the VRG while in use
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      secretName,
+					Namespace: testNamespace.GetName(),
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "v1",
+							Kind:       "ConfigMap",
+							Name:       owner.GetName(),
+							UID:        owner.GetUID(),
+						},
+					},
+				},
+				StringData: map[string]string{
+					"testkey": "testval",
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			rotatedSecretName = volsync.GetVolSyncSSHSecretName(owner.GetName(), 2)
+			staleSecretNames = []string{
+				volsync.GetVolSyncSSHSecretName(owner.GetName(), 0),
+				volsync.GetVolSyncSSHSecretName(owner.GetName(), 1),
+			}
+			otherSecretName = volsync.GetVolSyncSSHSecretNameFromVRGName(owner.GetName()) + "-other"
+
+			for _, secretName := range append(staleSecretNames, otherSecretName) {
+				createOwnedSecret(secretName)
+			}
+
+			// Create a dummy rotated volsync ssh secret (will be pushed down by drpc from hub)
+			sshSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      rotatedSecretName,
+					Namespace: testNamespace.GetName(),
+				},
+				StringData: map[string]string{
+					"testkey": "testval",
+				},
+			}
+			Expect(k8sClient.Create(ctx, sshSecret)).To(Succeed())
+
+			vsHandler.SetSSHSecretName(rotatedSecretName)
+		})
+
+		It("Should name the secrets of the rotations after the VRG and the generation", func() {
+			Expect(staleSecretNames[0]).To(Equal(owner.GetName() + "-vs-secret"))
+			Expect(staleSecretNames[1]).To(Equal(owner.GetName() + "-vs-secret-1"))
+			Expect(rotatedSecretName).To(Equal(owner.GetName() + "-vs-secret-2"))
+		})
+
+		It("Should replicate with the rotated ssh secret, and delete the ssh secrets of previous rotations", func() {
+			rd, err := vsHandler.ReconcileRD(rdSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rd).To(BeNil()) // Not ready yet, as the RD has no address
+
+			createdRD := &volsyncv1alpha1.ReplicationDestination{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name: testPVCName, Namespace: testNamespace.GetName(),
+			}, createdRD)).To(Succeed())
+			Expect(*createdRD.Spec.Rsync.SSHKeys).To(Equal(rotatedSecretName))
+
+			Expect(vsHandler.DeleteStaleSSHSecrets()).To(Succeed())
+
+			for _, secretName := range staleSecretNames {
+				Eventually(func() bool {
+					err := k8sClient.Get(ctx, types.NamespacedName{
+						Name: secretName, Namespace: testNamespace.GetName(),
+					}, &corev1.Secret{})
+
+					return kerrors.IsNotFound(err)
+				}, maxWait, interval).Should(BeTrue())
+			}
+
+			for _, secretName := range []string{rotatedSecretName, otherSecretName} {
+				Consistently(func() error {
+					return k8sClient.Get(ctx, types.NamespacedName{
+						Name: secretName, Namespace: testNamespace.GetName(),
+					}, &corev1.Secret{})
+				}, 1*time.Second, interval).Should(Succeed())
+			}
+		})
+	})
+
+	Describe("Reconcile with the restic mover", func() {
+		capacity := resource.MustParse("2Gi")
+		testPVCName := "mytestpvc"
+		testRepositorySecretName := "volsync-mytestpvc-restic"
+
+		protectedPVC := ramendrv1alpha1.ProtectedPVC{
+			Name:               testPVCName,
+			ProtectedByVolSync: true,
+			StorageClassName:   &testStorageClassName,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: capacity,
+				},
+			},
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		}
+		rdSpec := ramendrv1alpha1.VolSyncReplicationDestinationSpec{ProtectedPVC: protectedPVC}
+		rsSpec := ramendrv1alpha1.VolSyncReplicationSourceSpec{ProtectedPVC: protectedPVC}
+
+		BeforeEach(func() {
+			vsHandler.SetResticRepository(&volsync.ResticRepository{
+				S3CompatibleEndpoint: "http://s3.example.com/",
+				S3Bucket:             "bucket",
+				S3Region:             "east",
+				KeyPrefix:            "vrg-namespace/vrg-name/volsync/",
+				AccessKeyID:          []byte("accessid"),
+				SecretAccessKey:      []byte("secretaccesskey"),
+			})
+		})
+
+		Context("When the restic secret for volsync does not exist", func() {
+			It("Should not create a ReplicationDestination or a ReplicationSource yet", func() {
+				rd, err := vsHandler.ReconcileRD(rdSpec)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rd).To(BeNil())
 
-							It("Should properly update ReplicationSource and return rsInfo", func() {
-								// all checks here performed in the JustBeforeEach(common checks)
-								Expect(returnedRS).NotTo(BeNil())
-							})
+				_, rs, err := vsHandler.ReconcileRS(rsSpec, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rs).To(BeNil())
 
-							It("Should expect reconcileRS to return a replicationsource", func() {
-								// reconcile should return our RS
-								Expect(returnedRS).NotTo(BeNil())
-							})
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: testRepositorySecretName, Namespace: testNamespace.GetName(),
+				}, &corev1.Secret{})).ToNot(Succeed())
+			})
+		})
 
-							Context("When running a final sync", func() {
-								Context("When the pvc is still in use", func() {
-									It("Should not complete the final sync", func() {
-										finalSyncDone, returnedRS, err := vsHandler.ReconcileRS(rsSpec, true)
-										Expect(err).NotTo(HaveOccurred()) // Not considered an error, we should just wait
-										Expect(returnedRS).To(BeNil())
-										Expect(finalSyncDone).To(BeFalse())
-									})
-								})
+		Context("When the restic secret for volsync exists (will be pushed down by drpc from hub)", func() {
+			BeforeEach(func() {
+				resticSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      volsync.GetVolSyncResticSecretNameFromVRGName(owner.GetName()),
+						Namespace: testNamespace.GetName(),
+					},
+					Data: map[string][]byte{
+						volsync.ResticPasswordKey: []byte("resticpassword"),
+					},
+				}
+				Expect(k8sClient.Create(ctx, resticSecret)).To(Succeed())
+			})
 
-								Context("When the pvc is no longer in use", func() {
-									JustBeforeEach(func() {
-										// Pod mounting PVC is created above - delete it to simulate removing app
-										// Fake out the pod in "Running" Phase
-										Expect(k8sClient.Delete(ctx, podMountingPVC)).To(Succeed())
-										Eventually(func() bool {
-											err := k8sClient.Get(ctx, client.ObjectKeyFromObject(podMountingPVC), podMountingPVC)
+			It("Should create the restic repository secret of the pvc", func() {
+				_, err := vsHandler.ReconcileRD(rdSpec)
+				Expect(err).ToNot(HaveOccurred())
+
+				repositorySecret := &corev1.Secret{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: testRepositorySecretName, Namespace: testNamespace.GetName(),
+				}, repositorySecret)).To(Succeed())
+
+				Expect(ownerMatches(repositorySecret, owner.GetName(), "ConfigMap", true)).To(BeTrue())
+				Expect(repositorySecret.Data).To(HaveKeyWithValue("RESTIC_REPOSITORY",
+					[]byte("s3:http://s3.example.com/bucket/vrg-namespace/vrg-name/volsync/mytestpvc")))
+				Expect(repositorySecret.Data).To(HaveKeyWithValue(volsync.ResticPasswordKey, []byte("resticpassword")))
+				Expect(repositorySecret.Data).To(HaveKeyWithValue("AWS_ACCESS_KEY_ID", []byte("accessid")))
+				Expect(repositorySecret.Data).To(HaveKeyWithValue("AWS_SECRET_ACCESS_KEY", []byte("secretaccesskey")))
+				Expect(repositorySecret.Data).To(HaveKeyWithValue("AWS_DEFAULT_REGION", []byte("east")))
+			})
 
-											return kerrors.IsNotFound(err)
-										}, maxWait, interval).Should(BeTrue())
-									})
+			It("Should create a ReplicationDestination restoring from the restic repository on schedule", func() {
+				_, err := vsHandler.ReconcileRD(rdSpec)
+				Expect(err).ToNot(HaveOccurred())
+
+				rd := &volsyncv1alpha1.ReplicationDestination{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: testPVCName, Namespace: testNamespace.GetName(),
+				}, rd)).To(Succeed())
+
+				Expect(rd.Spec.Rsync).To(BeNil())
+				Expect(rd.Spec.Restic).ToNot(BeNil())
+				Expect(rd.Spec.Restic.Repository).To(Equal(testRepositorySecretName))
+				Expect(rd.Spec.Restic.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodSnapshot))
+				Expect(*rd.Spec.Restic.Capacity).To(Equal(capacity))
+				Expect(*rd.Spec.Restic.VolumeSnapshotClassName).To(Equal(testVolumeSnapshotClassName))
+				Expect(rd.Spec.Trigger).ToNot(BeNil())
+				Expect(*rd.Spec.Trigger.Schedule).To(Equal(expectedCronSpecSchedule))
+
+				// No service is exported, as the source does not connect to the destination
+				svcExport := &unstructured.Unstructured{}
+				svcExport.SetGroupVersionKind(schema.GroupVersionKind{
+					Group:   volsync.ServiceExportGroup,
+					Kind:    volsync.ServiceExportKind,
+					Version: volsync.ServiceExportVersion,
+				})
+				Expect(k8sClient.Get(ctx, client.ObjectKey{
+					Name:      fmt.Sprintf("volsync-rsync-dst-%s", rd.GetName()),
+					Namespace: rd.GetNamespace(),
+				}, svcExport)).ToNot(Succeed())
+			})
 
-									It("Should update the trigger on the RS and return true when replication is complete"+
-										" and also delete the pvc after replication complete", func() {
-										// Run ReconcileRS - indicate final sync
-										finalSyncDone, returnedRS, err := vsHandler.ReconcileRS(rsSpec, true)
-										Expect(err).ToNot(HaveOccurred())
-										Expect(finalSyncDone).To(BeFalse()) // Should not return true since sync has not completed
-										Expect(returnedRS).NotTo(BeNil())
+			It("Should create a ReplicationSource backing up to the restic repository", func() {
+				createDummyPVCAndMountingPod(testPVCName, testNamespace.GetName(),
+					capacity, nil, corev1.PodRunning, true /* pod should be Ready */)
+
+				_, rs, err := vsHandler.ReconcileRS(rsSpec, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rs).ToNot(BeNil())
+
+				Expect(rs.Spec.Rsync).To(BeNil())
+				Expect(rs.Spec.Restic).ToNot(BeNil())
+				Expect(rs.Spec.Restic.Repository).To(Equal(testRepositorySecretName))
+				Expect(rs.Spec.Restic.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodSnapshot))
+				Expect(rs.Spec.Restic.Retain).ToNot(BeNil())
+				Expect(rs.Spec.Restic.Retain.Within).ToNot(BeNil())
+				Expect(*rs.Spec.Restic.Retain.Within).To(Equal("1d"))
+				Expect(*rs.Spec.Trigger.Schedule).To(Equal(expectedCronSpecSchedule))
+			})
 
-										// Check that the manual sync triggger is set correctly on the RS
-										Eventually(func() string {
-											err := k8sClient.Get(ctx,
-												types.NamespacedName{
-													Name:      rsSpec.ProtectedPVC.Name,
-													Namespace: testNamespace.GetName(),
-												},
-												createdRS)
-											if err != nil || createdRS.Spec.Trigger == nil {
-												return ""
-											}
+			It("Should back up to a restic repository per destination, that the destination restores from", func() {
+				createDummyPVCAndMountingPod(testPVCName, testNamespace.GetName(),
+					capacity, nil, corev1.PodRunning, true /* pod should be Ready */)
 
-											return createdRS.Spec.Trigger.Manual
-										}, maxWait, interval).Should(Equal(volsync.FinalSyncTriggerString))
+				vsHandler.SetDestinations([]ramendrv1alpha1.VolSyncDestination{{Name: "east"}, {Name: "west"}})
+				Expect(vsHandler.Destinations()).To(Equal([]string{"east", "west"}))
 
-										// We have triggered a final sync - manually update the status on the RS to
-										// simulate that it has completed the sync and confirm ReconcileRS correctly sees the update
-										now := metav1.Now()
-										createdRS.Status = &volsyncv1alpha1.ReplicationSourceStatus{
-											LastManualSync: volsync.FinalSyncTriggerString,
-											LastSyncTime:   &now,
-										}
-										Expect(k8sClient.Status().Update(ctx, createdRS)).To(Succeed())
+				repositories := map[string]string{}
 
-										Eventually(func() bool {
-											// Make sure the update has been picked up by the client cache
-											err := k8sClient.Get(ctx, client.ObjectKeyFromObject(createdRS), createdRS)
-											if err != nil {
-												return false
-											}
+				for _, destination := range vsHandler.Destinations() {
+					rsSpec := rsSpec
+					rsSpec.Destination = destination
 
-											return createdRS.Status != nil && createdRS.Status.LastManualSync != ""
-										}, maxWait, interval).Should(BeTrue())
+					_, rs, err := vsHandler.ReconcileRS(rsSpec, false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rs).ToNot(BeNil())
+					Expect(rs.Spec.Restic.Repository).To(Equal(
+						"volsync-" + volsync.GetReplicationSourceName(testPVCName, destination) + "-restic"))
+
+					repositorySecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{
+						Name: rs.Spec.Restic.Repository, Namespace: testNamespace.GetName(),
+					}, repositorySecret)).To(Succeed())
+					Expect(repositorySecret.Data).To(HaveKeyWithValue("RESTIC_REPOSITORY",
+						[]byte("s3:http://s3.example.com/bucket/vrg-namespace/vrg-name/volsync/"+
+							volsync.GetReplicationSourceName(testPVCName, destination))))
+
+					repositories[destination] = rs.Spec.Restic.Repository
+				}
 
-										finalSyncDone, returnedRS, err = vsHandler.ReconcileRS(rsSpec, true)
-										Expect(err).ToNot(HaveOccurred())
-										Expect(finalSyncDone).To(BeTrue())
-										Expect(returnedRS).NotTo(BeNil())
+				Expect(repositories["east"]).NotTo(Equal(repositories["west"]))
 
-										// Now check to see if the pvc was removed
-										Eventually(func() bool {
-											err := k8sClient.Get(ctx, client.ObjectKeyFromObject(testPVC), testPVC)
-											if err == nil {
-												if !testPVC.GetDeletionTimestamp().IsZero() {
-													// PVC protection finalizer is added automatically to PVC - but testenv
-													// doesn't have anything that will remove it for us - we're good as long
-													// as the pvc is marked for deletion
+				vsHandler.SetDestinations(nil)
+				vsHandler.SetDestinationName("west")
 
-													testPVC.Finalizers = []string{} // Clear finalizers
-													Expect(k8sClient.Update(ctx, testPVC)).To(Succeed())
-												}
+				_, err := vsHandler.ReconcileRD(rdSpec)
+				Expect(err).ToNot(HaveOccurred())
 
-												return false // try again
-											}
+				rd := &volsyncv1alpha1.ReplicationDestination{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name: volsync.GetReplicationDestinationName(testPVCName, "west"), Namespace: testNamespace.GetName(),
+				}, rd)).To(Succeed())
+				Expect(rd.Spec.Restic.Repository).To(Equal(repositories["west"]))
+			})
 
-											return kerrors.IsNotFound(err)
-										}, maxWait, interval).Should(BeTrue())
+			It("Should retain the backups as per the recovery point retention", func() {
+				createDummyPVCAndMountingPod(testPVCName, testNamespace.GetName(),
+					capacity, nil, corev1.PodRunning, true /* pod should be Ready */)
+
+				defer vsHandler.SetRecoveryPointRetention(nil)
+
+				count := int32(3)
+				within := "2h"
+				for _, test := range []struct {
+					retention ramendrv1alpha1.VolSyncRecoveryPointRetention
+					expected  volsyncv1alpha1.ResticRetainPolicy
+				}{
+					{
+						ramendrv1alpha1.VolSyncRecoveryPointRetention{MaxAge: &metav1.Duration{Duration: 90 * time.Minute}},
+						volsyncv1alpha1.ResticRetainPolicy{Within: &within},
+					},
+					{
+						ramendrv1alpha1.VolSyncRecoveryPointRetention{Count: &count},
+						volsyncv1alpha1.ResticRetainPolicy{Hourly: &count},
+					},
+				} {
+					retention := test.retention
+					vsHandler.SetRecoveryPointRetention(&retention)
 
-										// Run reconcileRS with final sync again, even with PVC removed it should be able to
-										// reconcile RS and check from the status that the final sync is complete
-										finalSyncDone, returnedRS, err = vsHandler.ReconcileRS(rsSpec, true)
-										Expect(err).ToNot(HaveOccurred())
-										Expect(finalSyncDone).To(BeTrue())
-										Expect(returnedRS).NotTo(BeNil())
-									})
-								})
-							})
-						})
-					})
-				})
+					_, rs, err := vsHandler.ReconcileRS(rsSpec, false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rs).ToNot(BeNil())
+					Expect(rs.Spec.Restic.Retain).To(Equal(&test.expected))
+				}
 			})
 		})
 	})
@@ -1018,6 +2025,128 @@ var _ = Describe("VolSync Handler", func() {
 					})
 				})
 
+				Context("When pvc to be restored is in block volume mode and has annotations", func() {
+					blockMode := corev1.PersistentVolumeBlock
+					BeforeEach(func() {
+						rdSpec.ProtectedPVC.VolumeMode = &blockMode
+						rdSpec.ProtectedPVC.Annotations = map[string]string{
+							"csi.example.com/key": "val",
+						}
+					})
+
+					It("Should create PVC in block volume mode with annotations", func() {
+						Expect(pvc.Spec.VolumeMode).To(Equal(&blockMode))
+						Expect(pvc.Annotations).To(HaveKeyWithValue("csi.example.com/key", "val"))
+					})
+				})
+
+				Context("When a warm standby pvc is restored", func() {
+					standbyPVCName := fmt.Sprintf("volsync-%s-standby", pvcName)
+					standbyPVC := &corev1.PersistentVolumeClaim{}
+					getStandbyPVC := func() error {
+						return k8sClient.Get(ctx, types.NamespacedName{
+							Name:      standbyPVCName,
+							Namespace: testNamespace.GetName(),
+						}, standbyPVC)
+					}
+					bindPVC := func(pvc *corev1.PersistentVolumeClaim, pvName string) {
+						pvc.Spec.VolumeName = pvName
+						Expect(k8sClient.Update(ctx, pvc)).To(Succeed())
+						pvc.Status.Phase = corev1.ClaimBound
+						Expect(k8sClient.Status().Update(ctx, pvc)).To(Succeed())
+					}
+
+					BeforeEach(func() {
+						rdSpec.ProtectedPVC.Labels = map[string]string{"app": "standby-test"}
+					})
+
+					It("Should restore the standby pvc apart from the pvc, and replace it only once bound", func() {
+						ready, err := vsHandler.EnsureStandbyPVCfromRD(rdSpec)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(ready).To(BeTrue())
+
+						Eventually(getStandbyPVC, maxWait, interval).Should(Succeed())
+						Expect(standbyPVC.Labels).To(Equal(map[string]string{volsync.WarmStandbyLabel: owner.GetName()}))
+						Expect(standbyPVC.Spec.DataSource.Name).To(Equal(latestImageSnapshotName))
+
+						// A sync completes while the standby pvc is not bound yet
+						newImageSnap := createSnapshot("standby-snap-00002", testNamespace.GetName())
+						rd := &volsyncv1alpha1.ReplicationDestination{}
+						Expect(k8sClient.Get(ctx, types.NamespacedName{
+							Name:      pvcName,
+							Namespace: testNamespace.GetName(),
+						}, rd)).To(Succeed())
+						rd.Status.LatestImage.Name = newImageSnap.GetName()
+						Expect(k8sClient.Status().Update(ctx, rd)).To(Succeed())
+
+						ready, err = vsHandler.EnsureStandbyPVCfromRD(rdSpec)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(ready).To(BeFalse())
+						Expect(getStandbyPVC()).To(Succeed())
+						Expect(standbyPVC.Spec.DataSource.Name).To(Equal(latestImageSnapshotName))
+
+						// Once bound, the standby pvc is replaced with one restored from the latest image
+						bindPVC(standbyPVC, "standby-pv-00001")
+						ready, err = vsHandler.EnsureStandbyPVCfromRD(rdSpec)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(ready).To(BeFalse())
+						expectPVCDeleted(client.ObjectKeyFromObject(standbyPVC))
+
+						ready, err = vsHandler.EnsureStandbyPVCfromRD(rdSpec)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(ready).To(BeTrue())
+						Eventually(getStandbyPVC, maxWait, interval).Should(Succeed())
+						Expect(standbyPVC.Spec.DataSource.Name).To(Equal(newImageSnap.GetName()))
+					})
+
+					It("Should hand the volume of the bound standby pvc over to the pvc as it is restored", func() {
+						// The pvc is restored as the cluster becomes primary, after the standby pvc
+						Expect(k8sClient.Delete(ctx, pvc)).To(Succeed())
+						expectPVCDeleted(client.ObjectKeyFromObject(pvc))
+
+						ready, err := vsHandler.EnsureStandbyPVCfromRD(rdSpec)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(ready).To(BeTrue())
+						Eventually(getStandbyPVC, maxWait, interval).Should(Succeed())
+
+						pv := &corev1.PersistentVolume{
+							ObjectMeta: metav1.ObjectMeta{Name: "standby-pv-" + testNamespace.GetName()},
+							Spec: corev1.PersistentVolumeSpec{
+								Capacity:                      corev1.ResourceList{corev1.ResourceStorage: pvcCapacity},
+								AccessModes:                   []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+								PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+								StorageClassName:              testStorageClassName,
+								PersistentVolumeSource: corev1.PersistentVolumeSource{
+									CSI: &corev1.CSIPersistentVolumeSource{Driver: "csi.example.com", VolumeHandle: "standby"},
+								},
+							},
+						}
+						Expect(k8sClient.Create(ctx, pv)).To(Succeed())
+						bindPVC(standbyPVC, pv.GetName())
+
+						Expect(vsHandler.EnsurePVCfromRD(rdSpec)).To(Succeed())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pv), pv)).To(Succeed())
+						Expect(pv.Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+						Expect(pv.Spec.ClaimRef).NotTo(BeNil())
+						Expect(pv.Spec.ClaimRef.Name).To(Equal(pvcName))
+						Expect(pv.Spec.ClaimRef.Namespace).To(Equal(testNamespace.GetName()))
+						expectPVCDeleted(client.ObjectKeyFromObject(standbyPVC))
+						Eventually(func() error {
+							return k8sClient.Get(ctx, client.ObjectKeyFromObject(pvc), pvc)
+						}, maxWait, interval).Should(Succeed())
+						Expect(pvc.Labels).To(HaveKeyWithValue("app", "standby-test"))
+
+						// The reclaim policy of the volume is restored once the pvc binds to it
+						bindPVC(pvc, pv.GetName())
+						Expect(vsHandler.EnsurePVCfromRD(rdSpec)).To(Succeed())
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pv), pv)).To(Succeed())
+						Expect(pv.Spec.PersistentVolumeReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimDelete))
+
+						Expect(k8sClient.Delete(ctx, pv)).To(Succeed())
+					})
+				})
+
 				Context("When pvc to be restored has already been created", func() {
 					It("ensure PVC should not fail", func() {
 						// Previous ensurePVC will already have created the PVC (see parent context)
@@ -1110,6 +2239,192 @@ var _ = Describe("VolSync Handler", func() {
 		})
 	})
 
+	Describe("Recovery points retained from ReplicationDestination", func() {
+		pvcName := "testpvc-recoverypoints"
+		pvcCapacity := resource.MustParse("1Gi")
+		now := time.Now()
+
+		rdSpec := ramendrv1alpha1.VolSyncReplicationDestinationSpec{
+			ProtectedPVC: ramendrv1alpha1.ProtectedPVC{
+				Name:               pvcName,
+				ProtectedByVolSync: true,
+				StorageClassName:   &testStorageClassName,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: pvcCapacity,
+					},
+				},
+			},
+		}
+
+		// Images replicated 3h, 2h and 1h ago, the latest one not retained yet
+		snapshotNames := []string{"rp-snap-1", "rp-snap-2", "rp-snap-3"}
+		latestImageName := snapshotNames[2]
+
+		createImageSnapshot := func(snapshotName string, snapTime time.Time, recoveryPoint bool) {
+			volSnap := createSnapshot(snapshotName, testNamespace.GetName())
+
+			if recoveryPoint {
+				volSnap.SetLabels(map[string]string{
+					volsync.VRGOwnerLabel:      owner.GetName(),
+					volsync.RecoveryPointLabel: pvcName,
+				})
+				Expect(k8sClient.Update(ctx, volSnap)).To(Succeed())
+			}
+
+			Expect(unstructured.SetNestedField(volSnap.Object, snapTime.UTC().Format(time.RFC3339),
+				"status", "creationTime")).To(Succeed())
+			Expect(k8sClient.Status().Update(ctx, volSnap)).To(Succeed())
+		}
+
+		recoveryPointNames := func(recoveryPoints []ramendrv1alpha1.VolSyncRecoveryPoint) []string {
+			names := []string{}
+			for _, recoveryPoint := range recoveryPoints {
+				names = append(names, recoveryPoint.SnapshotName)
+			}
+
+			return names
+		}
+
+		snapshotExists := func(snapshotName string) func() bool {
+			return func() bool {
+				volSnap := &unstructured.Unstructured{}
+				volSnap.SetGroupVersionKind(schema.GroupVersionKind{
+					Group:   APIGrp,
+					Kind:    volsync.VolumeSnapshotKind,
+					Version: "v1",
+				})
+
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name: snapshotName, Namespace: testNamespace.GetName(),
+				}, volSnap)
+
+				return !kerrors.IsNotFound(err)
+			}
+		}
+
+		BeforeEach(func() {
+			rd := &volsyncv1alpha1.ReplicationDestination{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      pvcName,
+					Namespace: testNamespace.GetName(),
+				},
+				Spec: volsyncv1alpha1.ReplicationDestinationSpec{
+					Rsync: &volsyncv1alpha1.ReplicationDestinationRsyncSpec{},
+				},
+			}
+			Expect(k8sClient.Create(ctx, rd)).To(Succeed())
+
+			apiGrp := APIGrp
+			rd.Status = &volsyncv1alpha1.ReplicationDestinationStatus{
+				LatestImage: &corev1.TypedLocalObjectReference{
+					Kind:     volsync.VolumeSnapshotKind,
+					APIGroup: &apiGrp,
+					Name:     latestImageName,
+				},
+			}
+			Expect(k8sClient.Status().Update(ctx, rd)).To(Succeed())
+
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(rd), rd)
+
+				return err == nil && rd.Status != nil && rd.Status.LatestImage != nil
+			}, maxWait, interval).Should(BeTrue())
+
+			for idx, snapshotName := range snapshotNames {
+				snapTime := now.Add(-time.Duration(len(snapshotNames)-idx) * time.Hour)
+				createImageSnapshot(snapshotName, snapTime, snapshotName != latestImageName)
+			}
+		})
+
+		AfterEach(func() {
+			vsHandler.SetRecoveryPointRetention(nil)
+			vsHandler.SetRecoveryPointTime(nil)
+		})
+
+		It("Should retain the latest image as a recovery point", func() {
+			count := int32(3)
+			vsHandler.SetRecoveryPointRetention(&ramendrv1alpha1.VolSyncRecoveryPointRetention{Count: &count})
+
+			recoveryPoints, err := vsHandler.ReconcileRecoveryPoints(pvcName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recoveryPointNames(recoveryPoints)).To(Equal([]string{"rp-snap-3", "rp-snap-2", "rp-snap-1"}))
+
+			latestImage := &unstructured.Unstructured{}
+			latestImage.SetGroupVersionKind(schema.GroupVersionKind{
+				Group:   APIGrp,
+				Kind:    volsync.VolumeSnapshotKind,
+				Version: "v1",
+			})
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name: latestImageName, Namespace: testNamespace.GetName(),
+			}, latestImage)).To(Succeed())
+			Expect(latestImage.GetLabels()).To(HaveKeyWithValue(volsync.VolSyncDoNotDeleteLabel,
+				volsync.VolSyncDoNotDeleteLabelVal))
+			Expect(latestImage.GetLabels()).To(HaveKeyWithValue(volsync.RecoveryPointLabel, pvcName))
+			Expect(ownerMatches(latestImage, owner.GetName(), "ConfigMap", false)).To(BeTrue())
+		})
+
+		It("Should delete the recovery points beyond the count", func() {
+			count := int32(2)
+			vsHandler.SetRecoveryPointRetention(&ramendrv1alpha1.VolSyncRecoveryPointRetention{Count: &count})
+
+			recoveryPoints, err := vsHandler.ReconcileRecoveryPoints(pvcName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recoveryPointNames(recoveryPoints)).To(Equal([]string{"rp-snap-3", "rp-snap-2"}))
+			Eventually(snapshotExists("rp-snap-1"), maxWait, interval).Should(BeFalse())
+		})
+
+		It("Should delete the recovery points older than the max age", func() {
+			vsHandler.SetRecoveryPointRetention(&ramendrv1alpha1.VolSyncRecoveryPointRetention{
+				MaxAge: &metav1.Duration{Duration: 150 * time.Minute},
+			})
+
+			recoveryPoints, err := vsHandler.ReconcileRecoveryPoints(pvcName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recoveryPointNames(recoveryPoints)).To(Equal([]string{"rp-snap-3", "rp-snap-2"}))
+			Eventually(snapshotExists("rp-snap-1"), maxWait, interval).Should(BeFalse())
+		})
+
+		It("Should delete all recovery points but the latest image without a retention", func() {
+			recoveryPoints, err := vsHandler.ReconcileRecoveryPoints(pvcName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recoveryPoints).To(BeEmpty())
+			Eventually(snapshotExists("rp-snap-1"), maxWait, interval).Should(BeFalse())
+			Eventually(snapshotExists("rp-snap-2"), maxWait, interval).Should(BeFalse())
+			Expect(snapshotExists(latestImageName)()).To(BeTrue())
+		})
+
+		It("Should restore the PVC from the latest recovery point at or before the recovery point time", func() {
+			vsHandler.SetRecoveryPointTime(&metav1.Time{Time: now.Add(-90 * time.Minute)})
+			Expect(vsHandler.EnsurePVCfromRD(rdSpec)).To(Succeed())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, types.NamespacedName{
+					Name: pvcName, Namespace: testNamespace.GetName(),
+				}, pvc)
+			}, maxWait, interval).Should(Succeed())
+			Expect(pvc.Spec.DataSource).ToNot(BeNil())
+			Expect(pvc.Spec.DataSource.Name).To(Equal("rp-snap-2"))
+		})
+
+		It("Should fail to restore the PVC without a recovery point at or before the recovery point time", func() {
+			vsHandler.SetRecoveryPointTime(&metav1.Time{Time: now.Add(-4 * time.Hour)})
+
+			err := vsHandler.EnsurePVCfromRD(rdSpec)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no recovery point"))
+		})
+
+		It("Should delete the recovery points of PVCs no longer replicated", func() {
+			Expect(vsHandler.CleanupRecoveryPointsNotInSpecList(nil)).To(Succeed())
+			Eventually(snapshotExists("rp-snap-1"), maxWait, interval).Should(BeFalse())
+			Eventually(snapshotExists("rp-snap-2"), maxWait, interval).Should(BeFalse())
+			Expect(snapshotExists(latestImageName)()).To(BeTrue())
+		})
+	})
+
 	Describe("Cleanup ReplicationDestination", func() {
 		pvcNamePrefix := "test-pvc-rdcleanuptests-"
 		pvcNamePrefixOtherOwner := "otherowner-test-pvc-rdcleanuptests-"
@@ -1590,6 +2905,35 @@ var _ = Describe("VolSync Handler", func() {
 	})
 })
 
+// volSyncMetricValue returns the value of the series of the metric with the
+// labels, and whether it is found
+func volSyncMetricValue(name string, labels map[string]string) (float64, bool) {
+	families, err := metrics.Registry.Gather()
+	Expect(err).ToNot(HaveOccurred())
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			matched := 0
+
+			for _, label := range metric.GetLabel() {
+				if value, ok := labels[label.GetName()]; ok && value == label.GetValue() {
+					matched++
+				}
+			}
+
+			if matched == len(labels) {
+				return metric.GetGauge().GetValue(), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
 func ownerMatches(obj metav1.Object, ownerName, ownerKind string, ownerIsController bool) bool {
 	for _, ownerRef := range obj.GetOwnerReferences() {
 		if ownerRef.Name == ownerName && ownerRef.Kind == ownerKind {
@@ -1603,6 +2947,26 @@ func ownerMatches(obj metav1.Object, ownerName, ownerKind string, ownerIsControl
 	return false
 }
 
+// expectPVCDeleted waits for the deleted PVC to be gone, clearing the PVC
+// protection finalizer that testenv has no controller to remove
+func expectPVCDeleted(key types.NamespacedName) {
+	Eventually(func() bool {
+		pvc := &corev1.PersistentVolumeClaim{}
+
+		err := k8sClient.Get(ctx, key, pvc)
+		if err == nil {
+			Expect(pvc.GetDeletionTimestamp().IsZero()).To(BeFalse())
+
+			pvc.Finalizers = []string{}
+			Expect(k8sClient.Update(ctx, pvc)).To(Succeed())
+
+			return false
+		}
+
+		return kerrors.IsNotFound(err)
+	}, maxWait, interval).Should(BeTrue())
+}
+
 func createSnapshot(snapshotName, namespace string) *unstructured.Unstructured {
 	volSnap := &unstructured.Unstructured{}
 	volSnap.Object = map[string]interface{}{
@@ -1753,3 +3117,17 @@ func createDummyPVCAndMountingPod(pvcName, namespace string, capacity resource.Q
 
 	return pvc, pod
 }
+
+// expectedCronSpec returns the cronspec of a scheduling interval of minutes
+// that evenly divide an hour, offset into the interval by a hash of the owner
+func expectedCronSpec(owner metav1.Object, minutes uint32) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(owner.GetNamespace() + "/" + owner.GetName()))
+
+	offset := hash.Sum32() % minutes
+	if offset == 0 {
+		return fmt.Sprintf("*/%d * * * *", minutes)
+	}
+
+	return fmt.Sprintf("%d/%d * * * *", offset, minutes)
+}
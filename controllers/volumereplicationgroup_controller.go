@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -50,10 +51,12 @@ import (
 
 type PVDownloader interface {
 	DownloadPVs(objStore ObjectStorer, s3KeyPrefix string) ([]corev1.PersistentVolume, error)
+	DownloadPVCs(objStore ObjectStorer, s3KeyPrefix string) ([]corev1.PersistentVolumeClaim, error)
 }
 
 type PVUploader interface {
 	UploadPV(objectStore ObjectStorer, pvKeyPrefix string, pv *corev1.PersistentVolume) error
+	UploadPVC(objectStore ObjectStorer, pvcKeyPrefix string, pvc *corev1.PersistentVolumeClaim) error
 }
 
 type PVDeleter interface {
@@ -71,10 +74,18 @@ type VolumeReplicationGroupReconciler struct {
 	ObjStoreGetter ObjectStoreGetter
 	Scheme         *runtime.Scheme
 	eventRecorder  *rmnutil.EventReporter
+
+	// maxConcurrentPVCs is the number of PVCs of a VRG processed concurrently
+	maxConcurrentPVCs int
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *VolumeReplicationGroupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &ramendrv1alpha1.VolumeReplicationGroup{},
+		vrgProtectedNamespaceIndexName, vrgProtectedNamespaceIndexFunc); err != nil {
+		return fmt.Errorf("failed to index VolumeReplicationGroups by protected namespace, %w", err)
+	}
+
 	pvcPredicate := pvcPredicateFunc()
 	pvcMapFun := handler.EnqueueRequestsFromMapFunc(handler.MapFunc(func(obj client.Object) []reconcile.Request {
 		log := ctrl.Log.WithName("pvcmap").WithName("VolumeReplicationGroup")
@@ -91,15 +102,33 @@ func (r *VolumeReplicationGroupReconciler) SetupWithManager(mgr ctrl.Manager) er
 	}))
 
 	r.eventRecorder = rmnutil.NewEventReporter(mgr.GetEventRecorderFor("controller_VolumeReplicationGroup"))
+	r.maxConcurrentPVCs = getMaxConcurrentPVCs(r.Log)
 
 	r.Log.Info("Adding VolumeReplicationGroup controller")
 
-	return ctrl.NewControllerManagedBy(mgr).
+	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(ctrlcontroller.Options{MaxConcurrentReconciles: getMaxConcurrentReconciles(r.Log)}).
 		For(&ramendrv1alpha1.VolumeReplicationGroup{}).
 		Watches(&source.Kind{Type: &corev1.PersistentVolumeClaim{}}, pvcMapFun, builder.WithPredicates(pvcPredicate)).
+		Watches(&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(vrgNamespaceMapFunc(mgr.GetClient())),
+			builder.WithPredicates(namespacePredicateFunc())).
 		Owns(&volrep.VolumeReplication{}).
-		Complete(r)
+		Watches(&source.Kind{Type: &volrep.VolumeReplication{}},
+			handler.EnqueueRequestsFromMapFunc(vrgOwnerLabelsMapFunc))
+
+	// The VolumeGroupReplication API is optional, hence it is watched only if served
+	if _, err := mgr.GetRESTMapper().RESTMapping(volumeGroupReplicationGVK.GroupKind(),
+		volumeGroupReplicationGVK.Version); err == nil {
+		ctrlBuilder = ctrlBuilder.
+			Owns(newVolumeGroupReplication()).
+			Watches(&source.Kind{Type: newVolumeGroupReplication()},
+				handler.EnqueueRequestsFromMapFunc(vrgOwnerLabelsMapFunc))
+	} else {
+		r.Log.Info("VolumeGroupReplication API not served, not watching it", "error", err.Error())
+	}
+
+	return ctrlBuilder.Complete(r)
 }
 
 func init() {
@@ -170,6 +199,16 @@ func updateEventDecision(oldPVC *corev1.PersistentVolumeClaim,
 		return requeue
 	}
 
+	// A change in labels may add the PVC to, or remove it from, the PVCs
+	// selected by a VRG, and a change in its exclusion annotation may exclude
+	// it from, or include it in, protection
+	if !reflect.DeepEqual(oldPVC.Labels, newPVC.Labels) ||
+		oldPVC.GetAnnotations()[PVCExcludeAnnotation] != newPVC.GetAnnotations()[PVCExcludeAnnotation] {
+		predicateLog.Info("Reconciling due to change in labels or exclusion annotation")
+
+		return requeue
+	}
+
 	if oldPVC.Status.Phase != corev1.ClaimBound && newPVC.Status.Phase == corev1.ClaimBound {
 		predicateLog.Info("Reconciling due to phase change", "oldPhase", oldPVC.Status.Phase,
 			"newPhase", newPVC.Status.Phase)
@@ -208,26 +247,22 @@ func updateEventDecision(oldPVC *corev1.PersistentVolumeClaim,
 func filterPVC(mgr manager.Manager, pvc *corev1.PersistentVolumeClaim, log logr.Logger) []reconcile.Request {
 	req := []reconcile.Request{}
 
-	var vrgs ramendrv1alpha1.VolumeReplicationGroupList
-
-	listOptions := []client.ListOption{
-		client.InNamespace(pvc.Namespace),
-	}
-
 	// decide if reconcile request needs to be sent to the
 	// corresponding VolumeReplicationGroup CR by:
-	// - whether there is a VolumeReplicationGroup CR in the namespace
-	//   to which the the pvc belongs to.
+	// - whether there is a VolumeReplicationGroup CR protecting the
+	//   namespace to which the the pvc belongs to.
 	// - whether the labels on pvc match the label selectors from
 	//    VolumeReplicationGroup CR.
-	err := mgr.GetClient().List(context.TODO(), &vrgs, listOptions...)
+	vrgs, err := vrgsProtectingNamespace(context.TODO(), mgr.GetClient(), pvc.Namespace)
 	if err != nil {
 		log.Error(err, "Failed to get list of VolumeReplicationGroup resources")
 
 		return []reconcile.Request{}
 	}
 
-	for _, vrg := range vrgs.Items {
+	for idx := range vrgs {
+		vrg := &vrgs[idx]
+
 		vrgLabelSelector := vrg.Spec.PVCSelector
 		selector, err := metav1.LabelSelectorAsSelector(&vrgLabelSelector)
 		// continue if we fail to get the labels for this object hoping
@@ -256,14 +291,25 @@ func filterPVC(mgr manager.Manager, pvc *corev1.PersistentVolumeClaim, log logr.
 // +kubebuilder:rbac:groups=ramendr.openshift.io,resources=volumereplicationgroups/finalizers,verbs=update
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplications,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumereplicationclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumegroupreplications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=replication.storage.openshift.io,resources=volumegroupreplicationclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=csidrivers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update;patch;create
+// +kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;update;patch;create;delete
 // +kubebuilder:rbac:groups=volsync.backube,resources=replicationdestinations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=volsync.backube,resources=replicationsources,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceexports,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;create;patch;update
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=replicationcontrollers,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;patch
+// +kubebuilder:rbac:groups=apps.openshift.io,resources=deploymentconfigs,verbs=get;list;patch
 // +kubebuilder:rbac:groups="",namespace=system,resources=secrets,verbs=get;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -309,6 +355,7 @@ func (r *VolumeReplicationGroupReconciler) Reconcile(ctx context.Context, req ct
 
 	v.volSyncHandler = volsync.NewVSHandler(ctx, r.Client, log, v.instance,
 		v.instance.Spec.Async.SchedulingInterval, v.instance.Spec.Async.VolumeSnapshotClassSelector)
+	v.volSyncHandler.SetDestinationName(v.instance.Spec.VolSync.DestinationName)
 
 	// Save a copy of the instance status to be used for the VRG status update comparison
 	v.instance.Status.DeepCopyInto(&v.savedInstanceStatus)
@@ -333,6 +380,7 @@ type VRGInstance struct {
 	volSyncPVCs         []corev1.PersistentVolumeClaim
 	replClassList       *volrep.VolumeReplicationClassList
 	vrcUpdated          bool
+	namespaces          []string
 	namespacedName      string
 	volSyncHandler      *volsync.VSHandler
 }
@@ -349,6 +397,12 @@ const (
 	pvVRAnnotationRetentionKey    = "volumereplicationgroups.ramendr.openshift.io/vr-retained"
 	pvVRAnnotationRetentionValue  = "retained"
 	PVRestoreAnnotation           = "volumereplicationgroups.ramendr.openshift.io/ramen-restore"
+	pvUploadTimeAnnotation        = "volumereplicationgroups.ramendr.openshift.io/uploaded-at"
+
+	// PVCExcludeAnnotation, when set to "true" on a PVC selected by the VRG
+	// PVCSelector, excludes the PVC from protection by the VRG. It has no
+	// effect on a PVC that is already protected.
+	PVCExcludeAnnotation = "volumereplicationgroups.ramendr.openshift.io/exclude"
 )
 
 func (v *VRGInstance) processVRG() (ctrl.Result, error) {
@@ -479,6 +533,10 @@ func (v *VRGInstance) restorePVs() error {
 		return nil
 	}
 
+	if err := v.validateStorageClassMappings(); err != nil {
+		return fmt.Errorf("invalid storage class mappings (%w)", err)
+	}
+
 	err := v.restorePVsForVolSync()
 	if err != nil {
 		v.log.Info("VolSync PV restore failed")
@@ -495,6 +553,10 @@ func (v *VRGInstance) restorePVs() error {
 
 	// Only after both succeed, we mark ClusterDataReady as true
 	msg := "Restored PV cluster data"
+	if notRestored := v.pvsNotRestored(); notRestored != 0 {
+		msg = fmt.Sprintf("Restored PV cluster data, except for %d PVs listed in the PV restore results",
+			notRestored)
+	}
 	setVRGClusterDataReadyCondition(&v.instance.Status.Conditions, v.instance.Generation, msg)
 
 	return nil
@@ -514,20 +576,9 @@ func (v *VRGInstance) initializeStatus() {
 // updatePVCList fetches and updates the PVC list to process for the current instance of VRG
 func (v *VRGInstance) updatePVCList() error {
 	if v.instance.Spec.VolSync.Disabled {
-		labelSelector := v.instance.Spec.PVCSelector
-
-		v.log.Info("Fetching PersistentVolumeClaims", "labeled", labels.Set(labelSelector.MatchLabels))
-		listOptions := []client.ListOption{
-			client.InNamespace(v.instance.Namespace),
-			client.MatchingLabels(labelSelector.MatchLabels),
-		}
-
-		pvcList := &corev1.PersistentVolumeClaimList{}
-		if err := v.reconciler.List(v.ctx, pvcList, listOptions...); err != nil {
-			v.log.Error(err, "Failed to list PersistentVolumeClaims",
-				"labeled", labels.Set(labelSelector.MatchLabels))
-
-			return fmt.Errorf("failed to list PersistentVolumeClaims, %w", err)
+		pvcList, err := v.listPVCsByPVCSelector()
+		if err != nil {
+			return err
 		}
 
 		v.volRepPVCs = make([]corev1.PersistentVolumeClaim, len(pvcList.Items))
@@ -542,24 +593,86 @@ func (v *VRGInstance) updatePVCList() error {
 	return v.updatePVCListForAll()
 }
 
-func (v *VRGInstance) updatePVCListForAll() error {
-	labelSelector := v.instance.Spec.PVCSelector
+// listPVCsByPVCSelector lists the PVCs in the protected namespaces that match
+// the VRG PVCSelector, including its match expressions, and that are not
+// excluded from protection. The names of the excluded PVCs are reported in the
+// VRG status.
+func (v *VRGInstance) listPVCsByPVCSelector() (*corev1.PersistentVolumeClaimList, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&v.instance.Spec.PVCSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PVCSelector, %w", err)
+	}
 
-	v.log.Info("Fetching PersistentVolumeClaims", "labeled", labels.Set(labelSelector.MatchLabels))
-	listOptions := []client.ListOption{
-		client.InNamespace(v.instance.Namespace),
-		client.MatchingLabels(labelSelector.MatchLabels),
+	if err := v.updateProtectedNamespaces(); err != nil {
+		return nil, err
 	}
 
 	pvcList := &corev1.PersistentVolumeClaimList{}
-	if err := v.reconciler.List(v.ctx, pvcList, listOptions...); err != nil {
-		v.log.Error(err, "Failed to list PersistentVolumeClaims",
-			"labeled", labels.Set(labelSelector.MatchLabels))
 
-		return fmt.Errorf("failed to list PersistentVolumeClaims, %w", err)
+	for _, namespace := range v.namespaces {
+		v.log.Info("Fetching PersistentVolumeClaims", "namespace", namespace, "selector", selector.String())
+		listOptions := []client.ListOption{
+			client.InNamespace(namespace),
+			client.MatchingLabelsSelector{Selector: selector},
+		}
+
+		namespacePVCList := &corev1.PersistentVolumeClaimList{}
+		if err := v.reconciler.List(v.ctx, namespacePVCList, listOptions...); err != nil {
+			v.log.Error(err, "Failed to list PersistentVolumeClaims", "namespace", namespace,
+				"selector", selector.String())
+
+			return nil, fmt.Errorf("failed to list PersistentVolumeClaims in namespace %s, %w", namespace, err)
+		}
+
+		pvcList.Items = append(pvcList.Items, namespacePVCList.Items...)
+	}
+
+	selected := make([]corev1.PersistentVolumeClaim, 0, len(pvcList.Items))
+	v.instance.Status.ExcludedPVCs = nil
+
+	for idx := range pvcList.Items {
+		pvc := &pvcList.Items[idx]
+
+		if v.pvcExcluded(pvc) {
+			v.instance.Status.ExcludedPVCs = append(v.instance.Status.ExcludedPVCs, pvc.Namespace+"/"+pvc.Name)
+
+			continue
+		}
+
+		selected = append(selected, *pvc)
+	}
+
+	sort.Strings(v.instance.Status.ExcludedPVCs)
+
+	v.log.Info(fmt.Sprintf("Found %d PVCs using selector %s, excluded %d", len(pvcList.Items), selector.String(),
+		len(v.instance.Status.ExcludedPVCs)))
+
+	pvcList.Items = selected
+
+	return pvcList, nil
+}
+
+// pvcExcluded returns true if the PVC is annotated to be excluded from
+// protection, and is not already protected by the VRG
+func (v *VRGInstance) pvcExcluded(pvc *corev1.PersistentVolumeClaim) bool {
+	if pvc.GetAnnotations()[PVCExcludeAnnotation] != "true" {
+		return false
 	}
 
-	v.log.Info(fmt.Sprintf("Found %d PVCs using matching lables %v", len(pvcList.Items), labelSelector.MatchLabels))
+	if containsString(pvc.Finalizers, pvcVRFinalizerProtected) || v.findProtectedPVC(pvc.Namespace, pvc.Name) != nil {
+		v.log.Info("Ignoring exclusion of PersistentVolumeClaim that is already protected", "pvc", pvc.Name)
+
+		return false
+	}
+
+	return true
+}
+
+func (v *VRGInstance) updatePVCListForAll() error {
+	pvcList, err := v.listPVCsByPVCSelector()
+	if err != nil {
+		return err
+	}
 
 	if !v.vrcUpdated {
 		if err := v.updateReplicationClassList(); err != nil {
@@ -583,12 +696,14 @@ func (v *VRGInstance) updatePVCListForAll() error {
 		v.volSyncPVCs = make([]corev1.PersistentVolumeClaim, len(pvcList.Items))
 		numCopied := copy(v.volSyncPVCs, pvcList.Items)
 		v.log.Info("No VolumeReplicationClass available. Using all PVCs with VolSync", "pvcCount", numCopied)
-
-		return nil
+	} else if err := v.separatePVCsUsingStorageClassProvisioner(pvcList); err != nil {
+		// Separate PVCs targeted for VolRep from PVCs targeted for VolSync
+		return err
 	}
 
-	// Separate PVCs targeted for VolRep from PVCs targeted for VolSync
-	return v.separatePVCsUsingStorageClassProvisioner(pvcList)
+	v.rejectVolSyncPVCsOutsideVRGNamespace()
+
+	return nil
 }
 
 func (v *VRGInstance) updateReplicationClassList() error {
@@ -616,7 +731,8 @@ func (v *VRGInstance) separatePVCsUsingVRGStatus(pvcList *corev1.PersistentVolum
 		pvc := &pvcList.Items[idx]
 
 		for _, protectedPVC := range v.instance.Status.ProtectedPVCs {
-			if pvc.Name == protectedPVC.Name {
+			if pvc.Name == protectedPVC.Name &&
+				v.protectedPVCNamespace(pvc.Namespace) == v.protectedPVCNamespace(protectedPVC.Namespace) {
 				if protectedPVC.ProtectedByVolSync {
 					v.volSyncPVCs = append(v.volSyncPVCs, *pvc)
 				} else {
@@ -680,6 +796,12 @@ func (v *VRGInstance) processForDeletion() (ctrl.Result, error) {
 	}
 
 	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary {
+		if v.restoreScaledDownWorkloads() {
+			v.log.Info("Requeuing due to failure in restoring the workloads scaled down for the final sync")
+
+			return ctrl.Result{Requeue: true}, nil
+		}
+
 		if err := v.deleteClusterDataInS3Stores(v.log); err != nil {
 			v.log.Info("Requeuing due to failure in deleting PV cluster data from S3 stores",
 				"errorValue", err)
@@ -688,12 +810,22 @@ func (v *VRGInstance) processForDeletion() (ctrl.Result, error) {
 		}
 	}
 
+	if v.instance.Spec.ReplicationState == ramendrv1alpha1.Secondary {
+		if err := v.deleteWarmStandbyVolumes(); err != nil {
+			v.log.Info("Requeuing due to failure in deleting warm standby volumes", "errorValue", err)
+
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
 	if err := v.removeFinalizer(vrgFinalizerName); err != nil {
 		v.log.Info("Failed to remove finalizer", "finalizer", vrgFinalizerName, "errorValue", err)
 
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	v.volSyncHandler.DeleteMetrics()
+
 	rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeNormal,
 		rmnutil.EventReasonDeleteSuccess, "Deletion Success")
 
@@ -779,10 +911,12 @@ func (v *VRGInstance) processAsPrimary() (ctrl.Result, error) {
 
 	v.log.Info("Successfully processed vrg as primary")
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: v.volSyncScheduledSyncDelay()}, nil
 }
 
 func (v *VRGInstance) reconcileAsPrimary() bool {
+	requeueForScaleDown := v.reconcileWorkloadsScaleDown()
+
 	requeueForVolSync := false
 	if len(v.volSyncPVCs) != 0 {
 		requeueForVolSync = v.reconcileVolSyncAsPrimary()
@@ -790,7 +924,7 @@ func (v *VRGInstance) reconcileAsPrimary() bool {
 
 	requeueForVolRep := v.reconcileVolRepsAsPrimary()
 
-	return requeueForVolSync || requeueForVolRep
+	return requeueForScaleDown || requeueForVolSync || requeueForVolRep
 }
 
 // processAsSecondary reconciles the current instance of VRG as secondary
@@ -836,7 +970,12 @@ func (v *VRGInstance) processAsSecondary() (ctrl.Result, error) {
 
 	v.log.Info("Successfully processed vrg as secondary")
 
-	return ctrl.Result{}, nil
+	delay := v.volSyncScheduledSyncDelay()
+	if refreshDelay := v.warmStandbyPVRefreshDelay(); refreshDelay > 0 && (delay == 0 || refreshDelay < delay) {
+		delay = refreshDelay
+	}
+
+	return ctrl.Result{RequeueAfter: delay}, nil
 }
 
 func (v *VRGInstance) reconcileAsSecondary() bool {
@@ -844,7 +983,9 @@ func (v *VRGInstance) reconcileAsSecondary() bool {
 		return true // requeue
 	}
 
-	return v.reconcileVolRepsAsSecondary()
+	requeue := v.reconcileVolRepsAsSecondary()
+
+	return v.reconcileWarmStandby() || requeue
 }
 
 // For now, async mode and sync mode can be enabled only in either or fashion
@@ -869,6 +1010,8 @@ func (v *VRGInstance) updateVRGStatus(updateConditions bool) (bool, error) {
 
 	if updateConditions {
 		v.updateVRGConditions()
+		v.updateProtectedNamespacesStatus()
+		v.updateLastGroupSyncTime()
 	}
 
 	v.updateStatusState()
@@ -953,6 +1096,8 @@ func (v *VRGInstance) updateVRGConditions() {
 	v.updateVRGDataReadyCondition()
 	v.updateVRGDataProtectedCondition()
 	v.updateVRGClusterDataProtectedCondition()
+	v.updateVRGSuspendedCondition()
+	v.updateVRGStandbyCondition()
 }
 
 func (v *VRGInstance) updateVRGDataReadyCondition() {
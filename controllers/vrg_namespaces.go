@@ -0,0 +1,378 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// Labels identifying the VRG that owns a resource created in a namespace other
+// than the VRG namespace, where an owner reference cannot be used
+const (
+	vrgOwnerNameLabel      = "volumereplicationgroups.ramendr.openshift.io/owner-name"
+	vrgOwnerNamespaceLabel = "volumereplicationgroups.ramendr.openshift.io/owner-namespace"
+)
+
+// updateProtectedNamespaces determines the namespaces whose PVCs are protected
+// by the VRG: the VRG namespace, the namespaces listed in the VRG spec, and
+// the namespaces matching the VRG namespace selector.
+func (v *VRGInstance) updateProtectedNamespaces() error {
+	namespaces := map[string]struct{}{v.instance.Namespace: {}}
+
+	for _, namespace := range v.instance.Spec.ProtectedNamespaces {
+		namespaces[namespace] = struct{}{}
+	}
+
+	if v.instance.Spec.ProtectedNamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(v.instance.Spec.ProtectedNamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("failed to parse ProtectedNamespaceSelector, %w", err)
+		}
+
+		namespaceList := &corev1.NamespaceList{}
+		if err := v.reconciler.List(v.ctx, namespaceList,
+			client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return fmt.Errorf("failed to list namespaces, %w", err)
+		}
+
+		for idx := range namespaceList.Items {
+			namespaces[namespaceList.Items[idx].Name] = struct{}{}
+		}
+	}
+
+	v.namespaces = make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		v.namespaces = append(v.namespaces, namespace)
+	}
+
+	sort.Strings(v.namespaces)
+
+	return nil
+}
+
+// vrgProtectedNamespaceIndexName indexes VRGs by the namespaces they protect,
+// with VRGs having a namespace selector also indexed under
+// vrgNamespaceSelectorIndexKey, as the namespaces it matches change
+const (
+	vrgProtectedNamespaceIndexName = "vrg.protectedNamespace"
+	vrgNamespaceSelectorIndexKey   = "*"
+)
+
+func vrgProtectedNamespaceIndexFunc(object client.Object) []string {
+	vrg, ok := object.(*ramendrv1alpha1.VolumeReplicationGroup)
+	if !ok {
+		return nil
+	}
+
+	namespaces := append([]string{vrg.Namespace}, vrg.Spec.ProtectedNamespaces...)
+
+	if vrg.Spec.ProtectedNamespaceSelector != nil {
+		namespaces = append(namespaces, vrgNamespaceSelectorIndexKey)
+	}
+
+	return namespaces
+}
+
+// vrgsProtectingNamespace returns the VRGs that protect the PVCs of the named
+// namespace
+func vrgsProtectingNamespace(ctx context.Context, reader client.Reader, namespace string,
+) ([]ramendrv1alpha1.VolumeReplicationGroup, error) {
+	vrgs := &ramendrv1alpha1.VolumeReplicationGroupList{}
+	if err := reader.List(ctx, vrgs,
+		client.MatchingFields{vrgProtectedNamespaceIndexName: namespace}); err != nil {
+		return nil, fmt.Errorf("failed to list VolumeReplicationGroups protecting namespace %s, %w", namespace, err)
+	}
+
+	selectorVRGs := &ramendrv1alpha1.VolumeReplicationGroupList{}
+	if err := reader.List(ctx, selectorVRGs,
+		client.MatchingFields{vrgProtectedNamespaceIndexName: vrgNamespaceSelectorIndexKey}); err != nil {
+		return nil, fmt.Errorf("failed to list VolumeReplicationGroups with a namespace selector, %w", err)
+	}
+
+	if len(selectorVRGs.Items) == 0 {
+		return vrgs.Items, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s, %w", namespace, err)
+	}
+
+	for idx := range selectorVRGs.Items {
+		vrg := &selectorVRGs.Items[idx]
+
+		selector, err := metav1.LabelSelectorAsSelector(vrg.Spec.ProtectedNamespaceSelector)
+		if err != nil || !selector.Matches(labels.Set(ns.GetLabels())) {
+			continue
+		}
+
+		if !vrgListContains(vrgs.Items, vrg) {
+			vrgs.Items = append(vrgs.Items, *vrg)
+		}
+	}
+
+	return vrgs.Items, nil
+}
+
+func vrgListContains(vrgs []ramendrv1alpha1.VolumeReplicationGroup, vrg *ramendrv1alpha1.VolumeReplicationGroup) bool {
+	for idx := range vrgs {
+		if vrgs[idx].Name == vrg.Name && vrgs[idx].Namespace == vrg.Namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isProtectedNamespace returns true if the VRG protects the PVCs of the
+// namespace
+func (v *VRGInstance) isProtectedNamespace(namespace string) bool {
+	idx := sort.SearchStrings(v.namespaces, namespace)
+
+	return idx < len(v.namespaces) && v.namespaces[idx] == namespace
+}
+
+// protectedPVCNamespace returns the namespace to record in the ProtectedPVC
+// status of a PVC in the given namespace, which is empty for a PVC in the VRG
+// namespace
+func (v *VRGInstance) protectedPVCNamespace(pvcNamespace string) string {
+	if pvcNamespace == v.instance.Namespace {
+		return ""
+	}
+
+	return pvcNamespace
+}
+
+// setVRGOwner makes the VRG the controller owner of a resource in the VRG
+// namespace. A resource in another namespace is labeled with the VRG instead,
+// as owner references across namespaces are not supported.
+func (v *VRGInstance) setVRGOwner(object client.Object) error {
+	if object.GetNamespace() == v.instance.Namespace {
+		return ctrl.SetControllerReference(v.instance, object, v.reconciler.Scheme)
+	}
+
+	objectLabels := object.GetLabels()
+	if objectLabels == nil {
+		objectLabels = map[string]string{}
+	}
+
+	objectLabels[vrgOwnerNameLabel] = v.instance.Name
+	objectLabels[vrgOwnerNamespaceLabel] = v.instance.Namespace
+	object.SetLabels(objectLabels)
+
+	return nil
+}
+
+// vrgOwnerLabelsMapFunc maps a resource labeled with its owner VRG to a
+// reconcile request for the VRG
+func vrgOwnerLabelsMapFunc(object client.Object) []reconcile.Request {
+	name, namespace := object.GetLabels()[vrgOwnerNameLabel], object.GetLabels()[vrgOwnerNamespaceLabel]
+	if name == "" || namespace == "" {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}}
+}
+
+// vrgNamespaceMapFunc maps a namespace to reconcile requests for the VRGs
+// whose namespace selector matches it, and for the VRGs with a namespace
+// selector that report protecting it, as it may no longer match
+func vrgNamespaceMapFunc(reader client.Reader) handler.MapFunc {
+	return func(object client.Object) []reconcile.Request {
+		log := ctrl.Log.WithName("namespacemap").WithName("VolumeReplicationGroup")
+
+		vrgs := &ramendrv1alpha1.VolumeReplicationGroupList{}
+		if err := reader.List(context.TODO(), vrgs,
+			client.MatchingFields{vrgProtectedNamespaceIndexName: vrgNamespaceSelectorIndexKey}); err != nil {
+			log.Error(err, "Failed to list VolumeReplicationGroups with a namespace selector")
+
+			return []reconcile.Request{}
+		}
+
+		requests := []reconcile.Request{}
+
+		for idx := range vrgs.Items {
+			vrg := &vrgs.Items[idx]
+
+			if vrgSelectsNamespace(vrg, object) || vrgReportsProtectedNamespace(vrg, object.GetName()) {
+				log.Info("Queueing VolumeReplicationGroup for namespace",
+					"namespace", object.GetName(), "vrg", vrg.Namespace+"/"+vrg.Name)
+
+				requests = append(requests,
+					reconcile.Request{NamespacedName: types.NamespacedName{Name: vrg.Name, Namespace: vrg.Namespace}})
+			}
+		}
+
+		return requests
+	}
+}
+
+func vrgSelectsNamespace(vrg *ramendrv1alpha1.VolumeReplicationGroup, namespace client.Object) bool {
+	selector, err := metav1.LabelSelectorAsSelector(vrg.Spec.ProtectedNamespaceSelector)
+
+	return err == nil && selector.Matches(labels.Set(namespace.GetLabels()))
+}
+
+func vrgReportsProtectedNamespace(vrg *ramendrv1alpha1.VolumeReplicationGroup, namespace string) bool {
+	for idx := range vrg.Status.ProtectedNamespaces {
+		if vrg.Status.ProtectedNamespaces[idx].Name == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// namespacePredicateFunc sends reconcile requests for namespace creation and
+// deletion, and for updates changing the namespace labels, which may add the
+// namespace to, or remove it from, the namespaces selected by a VRG
+func namespacePredicateFunc() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// updateProtectedNamespacesStatus reports, for each protected namespace, its
+// protected PVCs and DataReady and DataProtected conditions summarizing those
+// of its PVCs
+func (v *VRGInstance) updateProtectedNamespacesStatus() {
+	protectedNamespaces := make([]ramendrv1alpha1.ProtectedNamespace, 0, len(v.namespaces))
+
+	for _, namespace := range v.namespaces {
+		protectedNamespace := ramendrv1alpha1.ProtectedNamespace{Name: namespace}
+
+		// Carry over the conditions, to preserve their transition times
+		for idx := range v.instance.Status.ProtectedNamespaces {
+			if v.instance.Status.ProtectedNamespaces[idx].Name == namespace {
+				protectedNamespace.Conditions = v.instance.Status.ProtectedNamespaces[idx].Conditions
+			}
+		}
+
+		protectedPVCs := []*ramendrv1alpha1.ProtectedPVC{}
+
+		for idx := range v.instance.Status.ProtectedPVCs {
+			protectedPVC := &v.instance.Status.ProtectedPVCs[idx]
+
+			pvcNamespace := protectedPVC.Namespace
+			if pvcNamespace == "" {
+				pvcNamespace = v.instance.Namespace
+			}
+
+			if pvcNamespace == namespace {
+				protectedPVCs = append(protectedPVCs, protectedPVC)
+				protectedNamespace.ProtectedPVCs = append(protectedNamespace.ProtectedPVCs, protectedPVC.Name)
+			}
+		}
+
+		sort.Strings(protectedNamespace.ProtectedPVCs)
+
+		for _, conditionType := range []string{VRGConditionTypeDataReady, VRGConditionTypeDataProtected} {
+			setStatusCondition(&protectedNamespace.Conditions,
+				v.protectedNamespaceCondition(conditionType, protectedPVCs))
+		}
+
+		protectedNamespaces = append(protectedNamespaces, protectedNamespace)
+	}
+
+	v.instance.Status.ProtectedNamespaces = protectedNamespaces
+}
+
+// protectedNamespaceCondition summarizes a condition of the protected PVCs of
+// a namespace: it is true if the condition is true for every PVC, and otherwise
+// takes the status and reason of the first PVC for which it is not
+func (v *VRGInstance) protectedNamespaceCondition(conditionType string,
+	protectedPVCs []*ramendrv1alpha1.ProtectedPVC,
+) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: v.instance.Generation,
+		Reason:             VRGConditionReasonReady,
+		Message:            "All PVCs of the namespace are ready",
+	}
+
+	if len(protectedPVCs) == 0 {
+		condition.Message = "No PVCs of the namespace are protected"
+
+		return condition
+	}
+
+	for _, protectedPVC := range protectedPVCs {
+		pvcCondition := findCondition(protectedPVC.Conditions, conditionType)
+		if pvcCondition == nil {
+			condition.Status = metav1.ConditionUnknown
+			condition.Reason = VRGConditionReasonInitializing
+			condition.Message = fmt.Sprintf("PVC %s condition is not yet known", protectedPVC.Name)
+
+			return condition
+		}
+
+		if pvcCondition.Status != metav1.ConditionTrue {
+			condition.Status = pvcCondition.Status
+			condition.Reason = pvcCondition.Reason
+			condition.Message = fmt.Sprintf("PVC %s: %s", protectedPVC.Name, pvcCondition.Message)
+
+			return condition
+		}
+	}
+
+	return condition
+}
+
+// rejectVolSyncPVCsOutsideVRGNamespace removes the PVCs outside the VRG
+// namespace from the PVCs protected using VolSync, as VolSync protects PVCs in
+// the VRG namespace only, and reports them as not ready
+func (v *VRGInstance) rejectVolSyncPVCsOutsideVRGNamespace() {
+	vrgNamespacePVCs := make([]corev1.PersistentVolumeClaim, 0, len(v.volSyncPVCs))
+
+	for idx := range v.volSyncPVCs {
+		pvc := &v.volSyncPVCs[idx]
+		if pvc.Namespace == v.instance.Namespace {
+			vrgNamespacePVCs = append(vrgNamespacePVCs, *pvc)
+
+			continue
+		}
+
+		msg := fmt.Sprintf("PVC is not protected, as no VolumeReplicationClass matches its provisioner and"+
+			" VolSync protects PVCs in the VolumeReplicationGroup namespace %s only", v.instance.Namespace)
+		v.log.Info(msg, "pvc", pvc.Namespace+"/"+pvc.Name)
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+	}
+
+	v.volSyncPVCs = vrgNamespacePVCs
+}
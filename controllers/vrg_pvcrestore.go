@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// PVC annotations set by the PV controller while binding or provisioning the
+// PVC on the cluster it was uploaded from, which must not be carried over to
+// a restored PVC
+var pvcBindAnnotations = []string{
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.beta.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/selected-node",
+	pvcVRAnnotationProtectedKey,
+}
+
+// pvcForUpload returns a copy of the PVC holding the cluster data needed to
+// recreate it on a peer cluster: its metadata labels and annotations, and its
+// spec, including the volume mode, data source and resources
+func pvcForUpload(pvc *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pvc.Name,
+			Namespace:   pvc.Namespace,
+			Labels:      pvc.Labels,
+			Annotations: pvc.Annotations,
+		},
+		Spec: *pvc.Spec.DeepCopy(),
+	}
+}
+
+// restorePVCClusterData recreates the PVCs uploaded to the S3 store that are
+// bound to the PVs restored from it, and returns the PVCs of the restored PVs,
+// whose binding gates reporting the cluster data ready. PVCs that already
+// exist, for instance because the application deployer recreated them, are
+// left as they are.
+func (v *VRGInstance) restorePVCClusterData(s3ProfileName string) ([]types.NamespacedName, error) {
+	objectStore, err := v.getObjectStore(s3ProfileName)
+	if err != nil {
+		return nil, fmt.Errorf("error when downloading PVCs, err %w", err)
+	}
+
+	pvcList, err := v.reconciler.PVDownloader.DownloadPVCs(objectStore, v.s3KeyPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("error when downloading PVCs, err %w", err)
+	}
+
+	restoredPVs := sets.NewString()
+
+	for idx := range v.instance.Status.PVRestoreResults {
+		switch result := &v.instance.Status.PVRestoreResults[idx]; result.Result {
+		case ramendrv1alpha1.PVRestoreResultRestored, ramendrv1alpha1.PVRestoreResultAlreadyExists:
+			restoredPVs.Insert(result.Name)
+		}
+	}
+
+	restoredPVCs := []types.NamespacedName{}
+
+	for idx := range pvcList {
+		pvc := &pvcList[idx]
+
+		if !restoredPVs.Has(pvc.Spec.VolumeName) {
+			v.log.Info("Skipping PVC restore, as its PV was not restored", "PVC", pvc.Namespace+"/"+pvc.Name,
+				"PV", pvc.Spec.VolumeName)
+
+			continue
+		}
+
+		v.cleanupPVCForRestore(pvc)
+		v.mapPVCStorageClass(pvc)
+
+		if err := v.reconciler.Create(v.ctx, pvc); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to restore PVC %s/%s (%w)", pvc.Namespace, pvc.Name, err)
+		}
+
+		restoredPVCs = append(restoredPVCs, types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name})
+	}
+
+	v.log.Info("Success restoring VolRep PVCs", "Total", len(restoredPVCs))
+
+	return restoredPVCs, nil
+}
+
+// restoredPVCsBound fails unless each of the PVCs of the restored PVs is bound
+func (v *VRGInstance) restoredPVCsBound(pvcNames []types.NamespacedName) error {
+	unbound := []string{}
+
+	for _, pvcName := range pvcNames {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := v.reconciler.Get(v.ctx, pvcName, pvc); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get restored PVC %s (%w)", pvcName, err)
+		}
+
+		if pvc.Status.Phase != corev1.ClaimBound {
+			unbound = append(unbound, pvcName.String())
+		}
+	}
+
+	if len(unbound) != 0 {
+		return fmt.Errorf("restored PVCs not bound yet: %v", unbound)
+	}
+
+	return nil
+}
+
+// cleanupPVCForRestore clears the PVC fields set on the cluster it was
+// uploaded from, keeping the name of the PV it binds to
+func (v *VRGInstance) cleanupPVCForRestore(pvc *corev1.PersistentVolumeClaim) {
+	pvc.ResourceVersion = ""
+	pvc.UID = ""
+	pvc.CreationTimestamp = metav1.Time{}
+	pvc.ManagedFields = nil
+	pvc.OwnerReferences = nil
+	pvc.Finalizers = nil
+	pvc.Status = corev1.PersistentVolumeClaimStatus{}
+
+	for _, annotation := range pvcBindAnnotations {
+		delete(pvc.Annotations, annotation)
+	}
+}
+
+// mapPVCStorageClass replaces the StorageClass of a PVC protected on a peer
+// cluster by its mapping on this cluster, to match its restored PV
+func (v *VRGInstance) mapPVCStorageClass(pvc *corev1.PersistentVolumeClaim) {
+	if pvc.Spec.StorageClassName == nil {
+		return
+	}
+
+	if mapping := v.storageClassMapping(*pvc.Spec.StorageClassName, ""); mapping != nil {
+		storageClassName := mapping.StorageClassName
+		pvc.Spec.StorageClassName = &storageClassName
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// pvcReconciler reconciles a PVC of the VRG, using the VRG instance v
+type pvcReconciler func(v *VRGInstance, pvc *corev1.PersistentVolumeClaim, log logr.Logger) error
+
+// pvcWorkers returns the number of PVCs of the VRG to process concurrently.
+// PVCs replicated by a VolumeGroupReplication share the status of their volume
+// group, and the replication classes are listed by the first PVC to need them
+// if the list failed earlier, so such PVCs are processed one after another.
+func (v *VRGInstance) pvcWorkers() int {
+	if v.instance.Spec.Async.VolumeGroupReplication || !v.vrcUpdated {
+		return 1
+	}
+
+	return v.reconciler.maxConcurrentPVCs
+}
+
+// forEachPVC calls reconcilePVC for each of the pvcs, processing up to
+// pvcWorkers PVCs concurrently, and returns the error of each PVC at its index.
+// Each concurrent call is passed a copy of its PVC, and a VRG instance whose
+// status holds only the ProtectedPVC of the PVC. These are merged back in PVC
+// order once all calls return, so that the VRG status does not depend on the
+// order in which the calls complete.
+func (v *VRGInstance) forEachPVC(pvcs []corev1.PersistentVolumeClaim, reconcilePVC pvcReconciler) []error {
+	pvcLog := func(pvc *corev1.PersistentVolumeClaim) logr.Logger {
+		return v.log.WithValues("pvc", types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}.String())
+	}
+
+	workers := v.pvcWorkers()
+	if workers <= 1 || len(pvcs) <= 1 {
+		return rmnutil.ForEachConcurrently(len(pvcs), 1, func(idx int) error {
+			return reconcilePVC(v, &pvcs[idx], pvcLog(&pvcs[idx]))
+		})
+	}
+
+	pvcInstances := make([]*VRGInstance, len(pvcs))
+	pvcCopies := make([]*corev1.PersistentVolumeClaim, len(pvcs))
+
+	for idx := range pvcs {
+		pvcInstances[idx] = v.pvcInstance(pvcs[idx].Namespace, pvcs[idx].Name)
+		pvcCopies[idx] = pvcs[idx].DeepCopy()
+	}
+
+	errs := rmnutil.ForEachConcurrently(len(pvcs), workers, func(idx int) error {
+		return reconcilePVC(pvcInstances[idx], pvcCopies[idx], pvcLog(pvcCopies[idx]))
+	})
+
+	for idx := range pvcs {
+		pvcs[idx] = *pvcCopies[idx]
+		v.mergeProtectedPVCs(pvcInstances[idx].instance.Status.ProtectedPVCs)
+	}
+
+	return errs
+}
+
+// pvcInstance returns a copy of the VRG instance to reconcile a PVC with. Its
+// status holds a copy of the ProtectedPVC of the PVC, if any, and nothing else
+// of the VRG is to be modified while reconciling the PVC.
+func (v *VRGInstance) pvcInstance(pvcNamespace, pvcName string) *VRGInstance {
+	vrg := *v.instance
+	vrg.Status.ProtectedPVCs = []ramendrv1alpha1.ProtectedPVC{}
+
+	if protectedPVC := v.findProtectedPVC(pvcNamespace, pvcName); protectedPVC != nil {
+		vrg.Status.ProtectedPVCs = append(vrg.Status.ProtectedPVCs, *protectedPVC.DeepCopy())
+	}
+
+	pvcInstance := *v
+	pvcInstance.instance = &vrg
+
+	return &pvcInstance
+}
+
+// mergeProtectedPVCs updates the VRG status with the given ProtectedPVCs,
+// appending those that are not in it yet
+func (v *VRGInstance) mergeProtectedPVCs(protectedPVCs []ramendrv1alpha1.ProtectedPVC) {
+	for idx := range protectedPVCs {
+		if protectedPVC := v.findProtectedPVC(protectedPVCs[idx].Namespace, protectedPVCs[idx].Name); protectedPVC != nil {
+			*protectedPVC = protectedPVCs[idx]
+
+			continue
+		}
+
+		v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, protectedPVCs[idx])
+	}
+}
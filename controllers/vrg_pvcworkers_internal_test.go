@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// slowObjectStore is an object store whose uploads take the latency of a round
+// trip to an S3 store
+type slowObjectStore struct {
+	latency time.Duration
+}
+
+func (s slowObjectStore) UploadPV(pvKeyPrefix, pvKeySuffix string, pv corev1.PersistentVolume) error {
+	time.Sleep(s.latency)
+
+	return nil
+}
+
+func (s slowObjectStore) UploadPVC(pvcKeyPrefix, pvcKeySuffix string, pvc corev1.PersistentVolumeClaim) error {
+	time.Sleep(s.latency)
+
+	return nil
+}
+
+func (slowObjectStore) DownloadPVs(pvKeyPrefix string) ([]corev1.PersistentVolume, error) {
+	return []corev1.PersistentVolume{}, nil
+}
+
+func (slowObjectStore) DownloadPVCs(pvcKeyPrefix string) ([]corev1.PersistentVolumeClaim, error) {
+	return []corev1.PersistentVolumeClaim{}, nil
+}
+
+func (slowObjectStore) ListKeys(keyPrefix string) ([]string, error) { return []string{}, nil }
+
+func (slowObjectStore) DeleteObjects(keyPrefix string) error { return nil }
+
+func (slowObjectStore) GetName() string { return "s3profile" }
+
+func (s slowObjectStore) ObjectStore(ctx context.Context, r client.Reader,
+	s3Profile string, callerTag string, log logr.Logger) (ObjectStorer, error) {
+	return s, nil
+}
+
+// BenchmarkPVUpload uploads the cluster data of the PVs and PVCs of a VRG to an
+// object store, as the VRG reconciler does for its VolRep PVCs, processing up to
+// the given number of PVCs concurrently
+func BenchmarkPVUpload(b *testing.B) {
+	const pvcCount = 64
+
+	pvs := make([]client.Object, pvcCount)
+	pvcs := make([]corev1.PersistentVolumeClaim, pvcCount)
+
+	for idx := range pvcs {
+		pvName := fmt.Sprintf("pv-%d", idx)
+		pvs[idx] = &corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: pvName}}
+		pvcs[idx].ObjectMeta = metav1.ObjectMeta{Name: fmt.Sprintf("pvc-%d", idx), Namespace: "vrg-namespace"}
+		pvcs[idx].Spec.VolumeName = pvName
+	}
+
+	objectStore := slowObjectStore{latency: time.Millisecond}
+	reconciler := &VolumeReplicationGroupReconciler{
+		Client:         fake.NewClientBuilder().WithObjects(pvs...).Build(),
+		Log:            logr.Discard(),
+		PVUploader:     ObjectStorePVUploader{},
+		ObjStoreGetter: objectStore,
+	}
+	uploadPV := func(v *VRGInstance, pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
+		return v.uploadPVToS3Stores(pvc, log)
+	}
+
+	for _, workers := range []int{1, 4, 16, pvcCount} {
+		reconciler.maxConcurrentPVCs = workers
+
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+
+				v := newPVUploadBenchmarkInstance(reconciler, pvcs)
+
+				b.StartTimer()
+
+				for idx, err := range v.forEachPVC(pvcs, uploadPV) {
+					if err != nil {
+						b.Fatalf("upload of pvc %s failed: %v", pvcs[idx].Name, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// newPVUploadBenchmarkInstance returns a VRG instance whose PVCs are protected
+// but not uploaded yet
+func newPVUploadBenchmarkInstance(reconciler *VolumeReplicationGroupReconciler,
+	pvcs []corev1.PersistentVolumeClaim) *VRGInstance {
+	vrg := &ramendrv1alpha1.VolumeReplicationGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "vrg-name", Namespace: "vrg-namespace", Generation: 1},
+		Spec:       ramendrv1alpha1.VolumeReplicationGroupSpec{S3Profiles: []string{"s3profile"}},
+	}
+
+	for idx := range pvcs {
+		vrg.Status.ProtectedPVCs = append(vrg.Status.ProtectedPVCs,
+			ramendrv1alpha1.ProtectedPVC{Name: pvcs[idx].Name, Namespace: pvcs[idx].Namespace})
+	}
+
+	return &VRGInstance{
+		reconciler:     reconciler,
+		ctx:            context.TODO(),
+		log:            logr.Discard(),
+		instance:       vrg,
+		vrcUpdated:     true,
+		namespacedName: vrg.Namespace + "/" + vrg.Name,
+	}
+}
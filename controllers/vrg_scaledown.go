@@ -0,0 +1,374 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ramendr/ramen/controllers/volsync"
+)
+
+const (
+	// Label of a workload scaled down for the final sync of the VolSync PVCs,
+	// set to the UID of the VRG that scaled it down, as a label value can not
+	// hold the namespace and name of the VRG
+	workloadScaledDownLabel = "volumereplicationgroups.ramendr.openshift.io/scaled-down-by"
+
+	// Annotation of a workload scaled down for the final sync of the VolSync
+	// PVCs, recording the replicas it is restored to
+	workloadReplicasAnnotation = "volumereplicationgroups.ramendr.openshift.io/replicas"
+)
+
+// scalableWorkloadKinds are the kinds of the workloads whose pods the VRG
+// stops for the final sync, by setting their spec.replicas to zero
+var scalableWorkloadKinds = []schema.GroupVersionKind{
+	{Group: "apps", Version: "v1", Kind: "Deployment"},
+	{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+	{Group: "", Version: "v1", Kind: "ReplicationController"},
+	{Group: "apps.openshift.io", Version: "v1", Kind: "DeploymentConfig"},
+}
+
+// reconcileWorkloadsScaleDown scales down the workloads using the VolSync PVCs
+// when the VRG is asked to, so that their final sync does not wait for the
+// application to be removed. The workloads are restored once the VRG is no
+// longer asked for a final sync as Primary, such as when the relocation is
+// aborted. Returns true to requeue.
+func (v *VRGInstance) reconcileWorkloadsScaleDown() bool {
+	switch {
+	case v.instance.Spec.RunFinalSync && v.instance.Spec.VolSync.ScaleDownWorkloads:
+		return v.scaleDownWorkloads()
+	case v.instance.Spec.PrepareForFinalSync || v.instance.Spec.RunFinalSync:
+		// Workloads scaled down are left so until the relocation completes or is aborted
+		return false
+	default:
+		return v.restoreScaledDownWorkloads()
+	}
+}
+
+// scaleDownWorkloads scales down the workloads of the pods using the VolSync
+// PVCs, and reports whether pods still use them. Pods not run by a workload
+// that can be scaled, such as those of a Job or a DaemonSet, are reported to
+// be stopped otherwise. Returns true to requeue.
+func (v *VRGInstance) scaleDownWorkloads() bool {
+	if len(v.volSyncPVCs) == 0 &&
+		findCondition(v.instance.Status.Conditions, VRGConditionTypeWorkloadsScaledDown) == nil {
+		return false
+	}
+
+	pods, err := v.listPodsUsingVolSyncPVCs()
+	if err != nil {
+		setVRGWorkloadsScaleDownErrorCondition(&v.instance.Status.Conditions, v.instance.Generation,
+			fmt.Sprintf("Failed to list the pods using the VolSync PVCs (%v)", err))
+
+		return true
+	}
+
+	workloads := map[string]*unstructured.Unstructured{}
+	unscalablePods := []string{}
+
+	for idx := range pods {
+		pod := &pods[idx]
+
+		workload, err := v.getScalableWorkload(pod)
+		if err != nil {
+			setVRGWorkloadsScaleDownErrorCondition(&v.instance.Status.Conditions, v.instance.Generation,
+				fmt.Sprintf("Failed to get the workload of pod %s/%s (%v)", pod.Namespace, pod.Name, err))
+
+			return true
+		}
+
+		if workload == nil {
+			unscalablePods = append(unscalablePods, pod.Namespace+"/"+pod.Name)
+
+			continue
+		}
+
+		workloads[workloadName(workload.GetKind(), workload.GetNamespace(), workload.GetName())] = workload
+	}
+
+	names := make([]string, 0, len(workloads))
+	for name := range workloads {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := v.scaleDownWorkload(workloads[name]); err != nil {
+			setVRGWorkloadsScaleDownErrorCondition(&v.instance.Status.Conditions, v.instance.Generation,
+				fmt.Sprintf("Failed to scale down %s (%v)", name, err))
+
+			return true
+		}
+	}
+
+	switch {
+	case len(unscalablePods) != 0:
+		setVRGWorkloadsScaleDownErrorCondition(&v.instance.Status.Conditions, v.instance.Generation,
+			fmt.Sprintf("Pods %s using the VolSync PVCs are not run by a workload that can be scaled down, "+
+				"and have to be stopped for the final sync", strings.Join(unscalablePods, ", ")))
+
+		return true
+	case len(pods) != 0:
+		setVRGWorkloadsScalingDownCondition(&v.instance.Status.Conditions, v.instance.Generation,
+			fmt.Sprintf("Scaled down %s, waiting for %d pods using the VolSync PVCs to stop",
+				strings.Join(names, ", "), len(pods)))
+
+		return true
+	}
+
+	setVRGWorkloadsScaledDownCondition(&v.instance.Status.Conditions, v.instance.Generation,
+		"Workloads scaled down, no pods use the VolSync PVCs")
+
+	return false
+}
+
+// listPodsUsingVolSyncPVCs returns the pods using the VolSync PVCs, each once
+func (v *VRGInstance) listPodsUsingVolSyncPVCs() ([]corev1.Pod, error) {
+	pods := []corev1.Pod{}
+	podNames := map[types.NamespacedName]struct{}{}
+
+	for idx := range v.volSyncPVCs {
+		pvc := &v.volSyncPVCs[idx]
+
+		podList := &corev1.PodList{}
+		if err := v.reconciler.List(v.ctx, podList,
+			client.MatchingFields{volsync.PodVolumePVCClaimIndexName: pvc.Name},
+			client.InNamespace(pvc.Namespace)); err != nil {
+			return nil, err
+		}
+
+		for _, pod := range podList.Items {
+			podName := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+			if _, found := podNames[podName]; found {
+				continue
+			}
+
+			podNames[podName] = struct{}{}
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods, nil
+}
+
+// getScalableWorkload returns the topmost of the controllers of the pod that
+// can be scaled, such as the Deployment of the ReplicaSet of the pod, or nil
+// if the pod has no controller that can be scaled. A workload that is itself
+// controlled, such as by an operator, may be scaled up again by its controller.
+func (v *VRGInstance) getScalableWorkload(pod *corev1.Pod) (*unstructured.Unstructured, error) {
+	var workload *unstructured.Unstructured
+
+	ownerRef := metav1.GetControllerOf(pod)
+	for ownerRef != nil && isScalableWorkloadKind(ownerRef) {
+		owner := &unstructured.Unstructured{}
+		owner.SetAPIVersion(ownerRef.APIVersion)
+		owner.SetKind(ownerRef.Kind)
+
+		if err := v.reconciler.Get(v.ctx, types.NamespacedName{Namespace: pod.Namespace, Name: ownerRef.Name},
+			owner); err != nil {
+			return nil, err
+		}
+
+		workload = owner
+		ownerRef = metav1.GetControllerOf(owner)
+	}
+
+	return workload, nil
+}
+
+func isScalableWorkloadKind(ownerRef *metav1.OwnerReference) bool {
+	groupVersion, err := schema.ParseGroupVersion(ownerRef.APIVersion)
+	if err != nil {
+		return false
+	}
+
+	for _, gvk := range scalableWorkloadKinds {
+		if gvk.Group == groupVersion.Group && gvk.Kind == ownerRef.Kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scaleDownWorkload scales the workload down to zero replicas, recording its
+// replicas in an annotation the first time only, so that they are kept if the
+// workload is scaled up again meanwhile
+func (v *VRGInstance) scaleDownWorkload(workload *unstructured.Unstructured) error {
+	replicas, found, err := unstructured.NestedInt64(workload.Object, "spec", "replicas")
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		replicas = 1 // Default of spec.replicas
+	}
+
+	_, recorded := workload.GetAnnotations()[workloadReplicasAnnotation]
+	if recorded && replicas == 0 {
+		return nil
+	}
+
+	patch := client.MergeFrom(workload.DeepCopy())
+
+	if !recorded {
+		labels := workload.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		labels[workloadScaledDownLabel] = string(v.instance.UID)
+		workload.SetLabels(labels)
+
+		annotations := workload.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		annotations[workloadReplicasAnnotation] = strconv.FormatInt(replicas, 10)
+		workload.SetAnnotations(annotations)
+	}
+
+	if err := unstructured.SetNestedField(workload.Object, int64(0), "spec", "replicas"); err != nil {
+		return err
+	}
+
+	v.log.Info("Scaling down workload for the final sync", "kind", workload.GetKind(),
+		"namespace", workload.GetNamespace(), "name", workload.GetName(), "replicas", replicas)
+
+	return v.reconciler.Patch(v.ctx, workload, patch)
+}
+
+// restoreScaledDownWorkloads restores the workloads scaled down for the final
+// sync, if any were, and reports it. Returns true to requeue.
+func (v *VRGInstance) restoreScaledDownWorkloads() bool {
+	condition := findCondition(v.instance.Status.Conditions, VRGConditionTypeWorkloadsScaledDown)
+	if condition == nil || condition.Reason == VRGConditionReasonWorkloadsRestored {
+		return false
+	}
+
+	names, err := v.restoreWorkloads()
+	if err != nil {
+		setVRGWorkloadsScaleDownErrorCondition(&v.instance.Status.Conditions, v.instance.Generation,
+			fmt.Sprintf("Failed to restore the workloads scaled down for the final sync (%v)", err))
+
+		return true
+	}
+
+	msg := "No workloads scaled down for the final sync to restore"
+	if len(names) != 0 {
+		msg = fmt.Sprintf("Restored %s scaled down for the final sync", strings.Join(names, ", "))
+	}
+
+	setVRGWorkloadsRestoredCondition(&v.instance.Status.Conditions, v.instance.Generation, msg)
+
+	return false
+}
+
+// restoreWorkloads restores the workloads of the protected namespaces labeled
+// as scaled down by the VRG to their recorded replicas, and returns their
+// names. Kinds not served by the cluster, such as DeploymentConfigs outside of
+// OpenShift, are skipped.
+func (v *VRGInstance) restoreWorkloads() ([]string, error) {
+	names := []string{}
+
+	for _, namespace := range v.namespaces {
+		for _, gvk := range scalableWorkloadKinds {
+			workloadList := &unstructured.UnstructuredList{}
+			workloadList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+			if err := v.reconciler.List(v.ctx, workloadList, client.InNamespace(namespace),
+				client.MatchingLabels{workloadScaledDownLabel: string(v.instance.UID)}); err != nil {
+				if meta.IsNoMatchError(err) {
+					continue
+				}
+
+				return names, fmt.Errorf("failed to list %s workloads in namespace %s (%w)", gvk.Kind, namespace, err)
+			}
+
+			for idx := range workloadList.Items {
+				workload := &workloadList.Items[idx]
+				name := workloadName(gvk.Kind, workload.GetNamespace(), workload.GetName())
+
+				if err := v.restoreWorkload(workload); err != nil {
+					return names, fmt.Errorf("failed to restore %s (%w)", name, err)
+				}
+
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// restoreWorkload scales the workload back to its recorded replicas, and
+// removes the label and annotation of its scale down. A workload scaled up
+// since, such as by an autoscaler or a GitOps tool that owns its replicas, is
+// left as is, rather than restored over.
+func (v *VRGInstance) restoreWorkload(workload *unstructured.Unstructured) error {
+	replicas, err := strconv.ParseInt(workload.GetAnnotations()[workloadReplicasAnnotation], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid %s annotation (%w)", workloadReplicasAnnotation, err)
+	}
+
+	currentReplicas, found, err := unstructured.NestedInt64(workload.Object, "spec", "replicas")
+	if err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(workload.DeepCopy())
+
+	if found && currentReplicas == 0 {
+		v.log.Info("Restoring workload scaled down for the final sync", "kind", workload.GetKind(),
+			"namespace", workload.GetNamespace(), "name", workload.GetName(), "replicas", replicas)
+
+		if err := unstructured.SetNestedField(workload.Object, replicas, "spec", "replicas"); err != nil {
+			return err
+		}
+	} else {
+		v.log.Info("Workload scaled down for the final sync was scaled up since, leaving its replicas",
+			"kind", workload.GetKind(), "namespace", workload.GetNamespace(), "name", workload.GetName())
+	}
+
+	labels := workload.GetLabels()
+	delete(labels, workloadScaledDownLabel)
+	workload.SetLabels(labels)
+
+	annotations := workload.GetAnnotations()
+	delete(annotations, workloadReplicasAnnotation)
+	workload.SetAnnotations(annotations)
+
+	return v.reconciler.Patch(v.ctx, workload, patch)
+}
+
+func workloadName(kind, namespace, name string) string {
+	return fmt.Sprintf("%s %s/%s", kind, namespace, name)
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+	"github.com/ramendr/ramen/controllers/volsync"
+)
+
+// reconcileWarmStandby keeps the warm standby volumes of the VRG ready on the
+// cluster as secondary, or deletes them once the warm standby is disabled.
+// VolSync standby PVCs are restored from the latest image of their
+// ReplicationDestination after each sync, and are adopted by the PVCs restored
+// as the cluster becomes primary. VolRep PVs are restored from the S3 store as
+// the VRG generation changes and once per scheduling interval, and are left for
+// the PVCs restored as the cluster becomes primary to bind to. Returns true to
+// requeue.
+func (v *VRGInstance) reconcileWarmStandby() bool {
+	if !v.instance.Spec.WarmStandby {
+		if findCondition(v.instance.Status.Conditions, VRGConditionTypeStandbyReady) == nil {
+			return false
+		}
+
+		if err := v.deleteWarmStandbyVolumes(); err != nil {
+			v.log.Info("Failed to delete warm standby volumes", "error", err.Error())
+
+			return true
+		}
+
+		setVRGStandbyDisabledCondition(&v.instance.Status.Conditions, v.instance.Generation,
+			"Warm standby is disabled")
+
+		return false
+	}
+
+	readyPVCs, err := v.reconcileVolSyncStandbyPVCs()
+	if err != nil {
+		setVRGStandbyErrorCondition(&v.instance.Status.Conditions, v.instance.Generation,
+			fmt.Sprintf("Failed to restore standby PVCs (%v)", err))
+
+		return true
+	}
+
+	standbyPVs, pvRestoreTime, err := v.reconcileVolRepStandbyPVs()
+	if err != nil {
+		setVRGStandbyErrorCondition(&v.instance.Status.Conditions, v.instance.Generation,
+			fmt.Sprintf("Failed to restore standby PVs (%v)", err))
+
+		return true
+	}
+
+	v.instance.Status.WarmStandby = &ramendrv1alpha1.WarmStandbyStatus{
+		ReadyPVCs:         readyPVCs,
+		TotalPVCs:         len(v.instance.Spec.VolSync.RDSpec),
+		RestoredPVs:       standbyPVs,
+		LastPVRestoreTime: pvRestoreTime,
+	}
+
+	msg := fmt.Sprintf("%d of %d VolSync PVCs restored from their latest image, %d VolRep PVs restored",
+		readyPVCs, len(v.instance.Spec.VolSync.RDSpec), standbyPVs)
+
+	if readyPVCs != len(v.instance.Spec.VolSync.RDSpec) {
+		setVRGStandbyProgressingCondition(&v.instance.Status.Conditions, v.instance.Generation, msg)
+
+		return true
+	}
+
+	setVRGStandbyReadyCondition(&v.instance.Status.Conditions, v.instance.Generation, msg)
+
+	return false
+}
+
+// reconcileVolSyncStandbyPVCs returns the number of VolSync PVCs restored from
+// the latest image of their ReplicationDestination
+func (v *VRGInstance) reconcileVolSyncStandbyPVCs() (int, error) {
+	readyPVCs := 0
+
+	for _, peerRDSpec := range v.instance.Spec.VolSync.RDSpec {
+		rdSpec := v.mapRDSpecStorageClass(peerRDSpec)
+
+		ready, err := v.volSyncHandler.EnsureStandbyPVCfromRD(rdSpec)
+		if err != nil {
+			return readyPVCs, fmt.Errorf("pvc %s (%w)", rdSpec.ProtectedPVC.Name, err)
+		}
+
+		if ready {
+			readyPVCs++
+		}
+	}
+
+	return readyPVCs, nil
+}
+
+// reconcileVolRepStandbyPVs restores the PVs of the VolRep PVCs from the first
+// S3 store that has their cluster data, and returns their number and the time
+// they were restored. PVs that already exist, such as those of a cluster that
+// was primary, are kept, and the unused standby PVs of PVCs no longer in the
+// S3 store are deleted. The PVs are restored again as the VRG generation
+// changes, and once per scheduling interval, to follow the PVCs of the primary
+// cluster.
+func (v *VRGInstance) reconcileVolRepStandbyPVs() (int, *metav1.Time, error) {
+	if v.instance.Spec.Sync.Mode == ramendrv1alpha1.SyncModeEnabled {
+		// The PVs of a synchronously replicated VRG are kept on each cluster
+		return 0, nil, nil
+	}
+
+	if v.warmStandbyPVRefreshDelay() > 0 {
+		return v.instance.Status.WarmStandby.RestoredPVs, v.instance.Status.WarmStandby.LastPVRestoreTime, nil
+	}
+
+	var err error
+
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		if s3ProfileName == NoS3StoreAvailable {
+			continue
+		}
+
+		var pvList []corev1.PersistentVolume
+
+		pvList, err = v.fetchPVClusterDataFromS3Store(s3ProfileName)
+		if err != nil {
+			v.log.Info(fmt.Sprintf("Failed to fetch PV cluster data from S3 profile %s for warm standby",
+				s3ProfileName), "error", err.Error())
+
+			continue
+		}
+
+		if err = v.restoreStandbyPVs(pvList); err != nil {
+			return 0, nil, err
+		}
+
+		if err = v.deleteStandbyPVs(pvList); err != nil {
+			return 0, nil, err
+		}
+
+		now := metav1.Now()
+
+		return len(pvList), &now, nil
+	}
+
+	return 0, nil, err
+}
+
+// warmStandbyPVRefreshDelay returns the time until the standby PVs are to be
+// restored again from the S3 store, or zero if they are to be restored now or
+// are not restored
+func (v *VRGInstance) warmStandbyPVRefreshDelay() time.Duration {
+	status := v.instance.Status.WarmStandby
+	if !v.instance.Spec.WarmStandby || status == nil || status.LastPVRestoreTime == nil {
+		return 0
+	}
+
+	condition := findCondition(v.instance.Status.Conditions, VRGConditionTypeStandbyReady)
+	if condition == nil || condition.ObservedGeneration != v.instance.Generation {
+		return 0
+	}
+
+	interval, err := rmnutil.ParseSchedulingInterval(v.instance.Spec.Async.SchedulingInterval)
+	if err != nil {
+		return 0
+	}
+
+	delay := time.Until(status.LastPVRestoreTime.Add(interval))
+	if delay < 0 {
+		return 0
+	}
+
+	return delay
+}
+
+func (v *VRGInstance) restoreStandbyPVs(pvList []corev1.PersistentVolume) error {
+	for idx := range pvList {
+		pv := &pvList[idx]
+		v.cleanupPVForRestore(pv)
+		v.mapPVStorageClass(pv)
+		v.addPVRestoreAnnotation(pv)
+
+		if pv.Labels == nil {
+			pv.Labels = map[string]string{}
+		}
+
+		pv.Labels[volsync.WarmStandbyLabel] = v.instance.Name
+
+		if err := v.reconciler.Create(v.ctx, pv); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to restore standby PV %s (%w)", pv.Name, err)
+		}
+	}
+
+	v.log.Info("Restored warm standby PVs", "count", len(pvList))
+
+	return nil
+}
+
+// deleteStandbyPVs deletes the standby PVs of the VRG, other than those to
+// keep, that are not bound to a PVC and are claimed by a PVC of a protected
+// namespace
+func (v *VRGInstance) deleteStandbyPVs(pvsToKeep []corev1.PersistentVolume) error {
+	keep := make(map[string]struct{}, len(pvsToKeep))
+	for idx := range pvsToKeep {
+		keep[pvsToKeep[idx].Name] = struct{}{}
+	}
+
+	pvList := &corev1.PersistentVolumeList{}
+	if err := v.reconciler.List(v.ctx, pvList,
+		client.MatchingLabels{volsync.WarmStandbyLabel: v.instance.Name}); err != nil {
+		return fmt.Errorf("failed to list standby PVs (%w)", err)
+	}
+
+	for idx := range pvList.Items {
+		pv := &pvList.Items[idx]
+
+		if _, ok := keep[pv.Name]; ok {
+			continue
+		}
+
+		if pv.Status.Phase != corev1.VolumeAvailable || pv.Spec.ClaimRef == nil ||
+			!v.isProtectedNamespace(pv.Spec.ClaimRef.Namespace) {
+			continue
+		}
+
+		v.log.Info("Deleting standby PV", "name", pv.Name)
+
+		if err := v.reconciler.Delete(v.ctx, pv); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete standby PV %s (%w)", pv.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteWarmStandbyVolumes deletes the VolSync standby PVCs, and the VolRep
+// standby PVs that are not bound to a PVC
+func (v *VRGInstance) deleteWarmStandbyVolumes() error {
+	if err := v.volSyncHandler.DeleteStandbyPVCs(); err != nil {
+		return fmt.Errorf("failed to delete standby PVCs (%w)", err)
+	}
+
+	if err := v.deleteStandbyPVs(nil); err != nil {
+		return err
+	}
+
+	v.instance.Status.WarmStandby = nil
+
+	return nil
+}
+
+// updateVRGStandbyCondition reports that the warm standby volumes of a VRG
+// that became primary are in use
+func (v *VRGInstance) updateVRGStandbyCondition() {
+	if v.instance.Spec.ReplicationState != ramendrv1alpha1.Primary {
+		return
+	}
+
+	condition := findCondition(v.instance.Status.Conditions, VRGConditionTypeStandbyReady)
+	if condition == nil || condition.Reason == VRGConditionReasonStandbyDisabled {
+		return
+	}
+
+	v.instance.Status.WarmStandby = nil
+
+	setVRGStandbyDisabledCondition(&v.instance.Status.Conditions, v.instance.Generation,
+		"Warm standby volumes are in use by the primary")
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// validateStorageClassMappings ensures that every StorageClass the VRG maps
+// volumes to exists on this cluster and, when the mapping names one, uses the
+// expected CSI driver. It is checked before any PV, ReplicationDestination or
+// PVC is created using the mappings.
+func (v *VRGInstance) validateStorageClassMappings() error {
+	peers := map[string]string{}
+
+	for idx := range v.instance.Spec.StorageClassMappings {
+		mapping := &v.instance.Spec.StorageClassMappings[idx]
+
+		peer := mapping.PeerStorageClassName + "/" + mapping.PeerProvisioner
+		if storageClassName, found := peers[peer]; found && storageClassName != mapping.StorageClassName {
+			return fmt.Errorf("peer storage class %s maps to both storage class %s and storage class %s",
+				mapping.PeerStorageClassName, storageClassName, mapping.StorageClassName)
+		}
+
+		peers[peer] = mapping.StorageClassName
+
+		storageClass := &storagev1.StorageClass{}
+		if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: mapping.StorageClassName},
+			storageClass); err != nil {
+			return fmt.Errorf("failed to get storage class %s mapped from peer storage class %s (%w)",
+				mapping.StorageClassName, mapping.PeerStorageClassName, err)
+		}
+
+		if mapping.Provisioner != "" && storageClass.Provisioner != mapping.Provisioner {
+			return fmt.Errorf("storage class %s provisioner is %s, storage class mapping expects %s",
+				storageClass.Name, storageClass.Provisioner, mapping.Provisioner)
+		}
+	}
+
+	return nil
+}
+
+// storageClassMapping returns the mapping of the named peer StorageClass, or
+// nil if the StorageClass is not mapped
+func (v *VRGInstance) storageClassMapping(peerStorageClassName, peerProvisioner string,
+) *ramendrv1alpha1.VRGStorageClassMapping {
+	return rmnutil.StorageClassMappingFind(v.instance.Spec.StorageClassMappings, peerStorageClassName, peerProvisioner)
+}
+
+// mapPVStorageClass rewrites the StorageClass, CSI driver and CSI volume
+// attributes of a PV protected on a peer cluster, before it is restored to
+// this cluster
+func (v *VRGInstance) mapPVStorageClass(pv *corev1.PersistentVolume) {
+	peerProvisioner := ""
+	if pv.Spec.CSI != nil {
+		peerProvisioner = pv.Spec.CSI.Driver
+	}
+
+	mapping := v.storageClassMapping(pv.Spec.StorageClassName, peerProvisioner)
+	if mapping == nil {
+		return
+	}
+
+	v.log.Info("Mapping PV storage class", "PV", pv.Name, "from", pv.Spec.StorageClassName,
+		"to", mapping.StorageClassName)
+
+	rmnutil.StorageClassMappingApplyToPV(mapping, pv)
+}
+
+// mapRDSpecStorageClass returns a copy of the RDSpec whose PVC StorageClass,
+// as protected on the peer cluster, is replaced by its mapping on this cluster.
+// The CSI driver of the peer StorageClass, when known, selects the mapping.
+func (v *VRGInstance) mapRDSpecStorageClass(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+) ramendrv1alpha1.VolSyncReplicationDestinationSpec {
+	mappedRDSpec := *rdSpec.DeepCopy()
+
+	storageClassName := mappedRDSpec.ProtectedPVC.StorageClassName
+	if storageClassName == nil {
+		return mappedRDSpec
+	}
+
+	if mapping := v.storageClassMapping(*storageClassName, mappedRDSpec.ProtectedPVC.StorageProvisioner); mapping != nil {
+		mappedStorageClassName := mapping.StorageClassName
+		mappedRDSpec.ProtectedPVC.StorageClassName = &mappedStorageClassName
+	}
+
+	return mappedRDSpec
+}
+
+// storageClassProvisioner returns the CSI driver of the named StorageClass, or
+// an empty string for a claim without a StorageClass
+func (v *VRGInstance) storageClassProvisioner(storageClassName *string) (string, error) {
+	if storageClassName == nil || *storageClassName == "" {
+		return "", nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: *storageClassName}, storageClass); err != nil {
+		return "", fmt.Errorf("failed to get storage class %s (%w)", *storageClassName, err)
+	}
+
+	return storageClass.Provisioner, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// updateVRGSuspendedCondition reports whether the replication of the VRG is
+// suspended. Only the replication of the PVCs protected using VolSync is
+// paused, hence a VRG with VolRep PVCs only reports that suspension is not
+// supported. The condition is only added once the VRG is first suspended.
+func (v *VRGInstance) updateVRGSuspendedCondition() {
+	if v.instance.Spec.Suspended {
+		switch {
+		case len(v.volRepPVCs) == 0:
+			setVRGSuspendedCondition(&v.instance.Status.Conditions, v.instance.Generation,
+				"Replication of the protected PVCs is suspended")
+		case len(v.volSyncPVCs) == 0:
+			setVRGSuspendNotSupportedCondition(&v.instance.Status.Conditions, v.instance.Generation,
+				"Replication of the PVCs protected using VolumeReplication cannot be suspended")
+		default:
+			setVRGSuspendedCondition(&v.instance.Status.Conditions, v.instance.Generation,
+				fmt.Sprintf("Replication of the PVCs protected using VolSync is suspended, "+
+					"%d PVCs protected using VolumeReplication keep replicating", len(v.volRepPVCs)))
+		}
+
+		return
+	}
+
+	if findCondition(v.instance.Status.Conditions, VRGConditionTypeSuspended) != nil {
+		setVRGResumedCondition(&v.instance.Status.Conditions, v.instance.Generation,
+			"Replication of the protected PVCs is resumed")
+	}
+}
+
+// VolRepSuspendedAnnotation is set on the VolumeReplication, or the
+// VolumeGroupReplication, of a PVC whose replication is suspended, and removed
+// once it is resumed. The VolumeReplication API has no field to pause the
+// replication schedule of a volume, hence replication is paused only by
+// storage that honors the annotation, which ramen cannot tell.
+const VolRepSuspendedAnnotation = "volumereplicationgroups.ramendr.openshift.io/replication-suspended"
+
+// updateVolRepPVCSuspended annotates the replication resource of the VolRep PVC
+// with whether its replication is suspended. As the replication of a VolRep
+// PVC is not known to pause, the PVC reports its Suspended condition false
+// with the SuspendNotSupported reason, rather than suspended. The PVC is
+// otherwise reconciled as usual, so that its finalizer, deletion and PV
+// cluster data upload are acted on while it is suspended.
+func (v *VRGInstance) updateVolRepPVCSuspended(pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
+	suspended := v.instance.Spec.Suspended
+
+	if v.instance.Spec.Async.Mode == ramendrv1alpha1.AsyncModeEnabled {
+		if err := v.annotateVolRepSuspended(pvc, suspended, log); err != nil {
+			return err
+		}
+	}
+
+	protectedPVC := v.findProtectedPVC(pvc.Namespace, pvc.Name)
+
+	if !suspended {
+		if protectedPVC != nil && findCondition(protectedPVC.Conditions, VRGConditionTypeSuspended) != nil {
+			setVRGResumedCondition(&protectedPVC.Conditions, v.instance.Generation,
+				"Replication of the PVC is resumed")
+		}
+
+		return nil
+	}
+
+	if protectedPVC == nil {
+		v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, ramendrv1alpha1.ProtectedPVC{
+			Name:      pvc.Name,
+			Namespace: v.protectedPVCNamespace(pvc.Namespace),
+		})
+		protectedPVC = &v.instance.Status.ProtectedPVCs[len(v.instance.Status.ProtectedPVCs)-1]
+	}
+
+	setVRGSuspendNotSupportedCondition(&protectedPVC.Conditions, v.instance.Generation,
+		"Replication of a PVC protected using VolumeReplication cannot be suspended, "+
+			"its replication resource is annotated as suspended for storage that honors it")
+
+	return nil
+}
+
+// annotateVolRepSuspended sets or removes the suspended annotation of the
+// VolumeReplication of the PVC, or of the VolumeGroupReplication of its volume
+// group
+func (v *VRGInstance) annotateVolRepSuspended(pvc *corev1.PersistentVolumeClaim, suspended bool,
+	log logr.Logger,
+) error {
+	var obj client.Object = &volrep.VolumeReplication{}
+
+	key := types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}
+
+	if v.instance.Spec.Async.VolumeGroupReplication {
+		obj = newVolumeGroupReplication()
+		key.Name = pvc.GetLabels()[pvcVolumeGroupLabel]
+		if key.Name == "" {
+			return nil
+		}
+	}
+
+	if err := v.reconciler.Get(v.ctx, key, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get replication resource %s to annotate it as suspended: %w", key, err)
+	}
+
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[VolRepSuspendedAnnotation]; ok == suspended {
+		return nil
+	}
+
+	if suspended {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		annotations[VolRepSuspendedAnnotation] = "true"
+	} else {
+		delete(annotations, VolRepSuspendedAnnotation)
+	}
+
+	obj.SetAnnotations(annotations)
+
+	if err := v.reconciler.Update(v.ctx, obj); err != nil {
+		return fmt.Errorf("failed to update suspended annotation of replication resource %s: %w", key, err)
+	}
+
+	log.Info("Updated suspended annotation of replication resource", "name", key, "suspended", suspended)
+
+	return nil
+}
+
+// isVolSyncRSPaused returns true if the ReplicationSources of the VRG are to be
+// paused. A final sync requested for a relocation unpauses them.
+func (v *VRGInstance) isVolSyncRSPaused() bool {
+	return v.instance.Spec.Suspended && !v.instance.Spec.RunFinalSync
+}
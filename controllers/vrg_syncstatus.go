@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
+	volrepController "github.com/csi-addons/volume-replication-operator/controllers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// updatePVCSyncStatusFromVR records the replication progress of the PVC of a
+// VolumeReplication in its ProtectedPVC status. The duration of the last
+// completed synchronization is kept while a resync is in progress, to estimate
+// its completion time.
+func (v *VRGInstance) updatePVCSyncStatusFromVR(volRep *volrep.VolumeReplication) {
+	protectedPVC := v.findProtectedPVC(volRep.Namespace, volRep.Name)
+	if protectedPVC == nil {
+		v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, ramendrv1alpha1.ProtectedPVC{
+			Name:      volRep.Name,
+			Namespace: v.protectedPVCNamespace(volRep.Namespace),
+		})
+		protectedPVC = &v.instance.Status.ProtectedPVCs[len(v.instance.Status.ProtectedPVCs)-1]
+	}
+
+	syncStatus := &ramendrv1alpha1.PVCSyncStatus{
+		State:        ramendrv1alpha1.PVCSyncStateUnknown,
+		StartTime:    volRep.Status.LastStartTime,
+		LastSyncTime: volRep.Status.LastCompletionTime,
+		Message:      volRep.Status.Message,
+	}
+
+	if protectedPVC.SyncStatus != nil {
+		syncStatus.LastSyncDuration = protectedPVC.SyncStatus.LastSyncDuration
+	}
+
+	startTime, completionTime := volRep.Status.LastStartTime, volRep.Status.LastCompletionTime
+	if startTime != nil && completionTime != nil && !completionTime.Before(startTime) {
+		syncStatus.LastSyncDuration = &metav1.Duration{Duration: completionTime.Sub(startTime.Time)}
+	}
+
+	resyncing, _ := isVRConditionMet(volRep, volrepController.ConditionResyncing, metav1.ConditionTrue)
+	degraded, _ := isVRConditionMet(volRep, volrepController.ConditionDegraded, metav1.ConditionTrue)
+	completed, _ := isVRConditionMet(volRep, volrepController.ConditionCompleted, metav1.ConditionTrue)
+
+	switch {
+	case resyncing:
+		syncStatus.State = ramendrv1alpha1.PVCSyncStateResyncing
+		syncStatus.EstimatedCompletionTime = estimatedCompletionTime(syncStatus.StartTime,
+			syncStatus.LastSyncDuration)
+	case degraded:
+		syncStatus.State = ramendrv1alpha1.PVCSyncStateDegraded
+	case completed:
+		syncStatus.State = ramendrv1alpha1.PVCSyncStateInSync
+	}
+
+	protectedPVC.SyncStatus = syncStatus
+}
+
+// updatePVCSyncStatusFromRD records the replication progress of a PVC
+// replicated to this cluster by a VolSync ReplicationDestination. A nil
+// ReplicationDestination is not ready to receive data yet. Until the first
+// synchronization completes, the PVC is in its initial synchronization, or
+// resyncing if it was synchronized before, with its completion time estimated
+// from the duration of the last synchronization from this cluster, when it was
+// primary.
+func (v *VRGInstance) updatePVCSyncStatusFromRD(rdSpec ramendrv1alpha1.VolSyncReplicationDestinationSpec,
+	rd *volsyncv1alpha1.ReplicationDestination,
+) {
+	protectedPVC := v.findProtectedPVC(rdSpec.ProtectedPVC.Namespace, rdSpec.ProtectedPVC.Name)
+	if protectedPVC == nil {
+		v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, ramendrv1alpha1.ProtectedPVC{
+			Name:               rdSpec.ProtectedPVC.Name,
+			Namespace:          rdSpec.ProtectedPVC.Namespace,
+			ProtectedByVolSync: true,
+		})
+		protectedPVC = &v.instance.Status.ProtectedPVCs[len(v.instance.Status.ProtectedPVCs)-1]
+	}
+
+	previous := protectedPVC.SyncStatus
+	syncStatus := &ramendrv1alpha1.PVCSyncStatus{
+		State:   ramendrv1alpha1.PVCSyncStateInitialSync,
+		Message: "Waiting for the first synchronization from the primary cluster",
+	}
+
+	if previous != nil && (previous.LastSyncTime != nil || previous.State == ramendrv1alpha1.PVCSyncStateResyncing) {
+		syncStatus.State = ramendrv1alpha1.PVCSyncStateResyncing
+	}
+
+	if rd != nil && rd.Status != nil && rd.Status.LastSyncTime != nil {
+		syncStatus.State = ramendrv1alpha1.PVCSyncStateInSync
+		syncStatus.LastSyncTime = rd.Status.LastSyncTime
+		syncStatus.LastSyncDuration = rd.Status.LastSyncDuration
+		syncStatus.Message = ""
+		protectedPVC.SyncStatus = syncStatus
+
+		return
+	}
+
+	if rd == nil {
+		syncStatus.Message = "Waiting for the ReplicationDestination to be ready"
+	}
+
+	now := metav1.Now()
+	syncStatus.StartTime = &now
+
+	if previous != nil {
+		syncStatus.LastSyncDuration = previous.LastSyncDuration
+
+		if previous.State == syncStatus.State && previous.StartTime != nil {
+			syncStatus.StartTime = previous.StartTime
+		}
+	}
+
+	syncStatus.EstimatedCompletionTime = estimatedCompletionTime(syncStatus.StartTime, syncStatus.LastSyncDuration)
+	protectedPVC.SyncStatus = syncStatus
+}
+
+// estimatedCompletionTime returns the time a synchronization started at the
+// start time completes, if it lasts as long as the last one, or nil if either
+// is unknown
+func estimatedCompletionTime(startTime *metav1.Time, lastSyncDuration *metav1.Duration) *metav1.Time {
+	if startTime == nil || lastSyncDuration == nil {
+		return nil
+	}
+
+	return &metav1.Time{Time: startTime.Add(lastSyncDuration.Duration)}
+}
+
+// updateLastGroupSyncTime sets the VRG last group sync time to the oldest last
+// sync time of its protected PVCs. It is unknown, and cleared, until the data of
+// every protected PVC is synchronized at least once.
+func (v *VRGInstance) updateLastGroupSyncTime() {
+	var lastGroupSyncTime *metav1.Time
+
+	for idx := range v.instance.Status.ProtectedPVCs {
+		syncStatus := v.instance.Status.ProtectedPVCs[idx].SyncStatus
+		if syncStatus == nil || syncStatus.LastSyncTime == nil {
+			v.instance.Status.LastGroupSyncTime = nil
+
+			return
+		}
+
+		if lastGroupSyncTime == nil || syncStatus.LastSyncTime.Before(lastGroupSyncTime) {
+			lastGroupSyncTime = syncStatus.LastSyncTime
+		}
+	}
+
+	v.instance.Status.LastGroupSyncTime = lastGroupSyncTime.DeepCopy()
+}
@@ -0,0 +1,490 @@
+/*
+Copyright 2022 The RamenDR authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+
+	volrep "github.com/csi-addons/volume-replication-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+)
+
+// The VolumeGroupReplication API is not part of the vendored volume replication
+// operator API, hence its resources are handled as unstructured objects.
+var (
+	volumeGroupReplicationGVK = schema.GroupVersionKind{
+		Group:   volrep.GroupVersion.Group,
+		Version: volrep.GroupVersion.Version,
+		Kind:    "VolumeGroupReplication",
+	}
+	volumeGroupReplicationClassListGVK = schema.GroupVersionKind{
+		Group:   volrep.GroupVersion.Group,
+		Version: volrep.GroupVersion.Version,
+		Kind:    "VolumeGroupReplicationClassList",
+	}
+)
+
+// pvcVolumeGroupLabel labels a PVC with the name of the VolumeGroupReplication
+// resource that replicates it. The VolumeGroupReplication selects its PVCs
+// using this label.
+const pvcVolumeGroupLabel = "volumereplicationgroups.ramendr.openshift.io/volume-group"
+
+func newVolumeGroupReplication() *unstructured.Unstructured {
+	vgr := &unstructured.Unstructured{}
+	vgr.SetGroupVersionKind(volumeGroupReplicationGVK)
+
+	return vgr
+}
+
+// volumeGroupReplicationStatus is the subset of the VolumeGroupReplication
+// status consumed by the VRG
+type volumeGroupReplicationStatus struct {
+	State              string             `json:"state,omitempty"`
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// volumeGroupName returns the name of the VolumeGroupReplication resource that
+// replicates the PVCs of the VRG in the given namespace with the given
+// provisioner
+func (v *VRGInstance) volumeGroupName(namespace, provisioner string) string {
+	sum := sha256.Sum256([]byte(namespace + "/" + provisioner))
+
+	return v.instance.Name + "-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// findVolRepPVC returns the PVC, from the list of PVCs protected using volume
+// replication, with the given namespaced name
+func (v *VRGInstance) findVolRepPVC(namespacedName types.NamespacedName) *corev1.PersistentVolumeClaim {
+	for idx := range v.volRepPVCs {
+		pvc := &v.volRepPVCs[idx]
+		if pvc.Name == namespacedName.Name && pvc.Namespace == namespacedName.Namespace {
+			return pvc
+		}
+	}
+
+	return nil
+}
+
+// volumeGroupPVCs returns the PVCs, from the list of PVCs protected using
+// volume replication, that are labeled as members of the named volume group
+func (v *VRGInstance) volumeGroupPVCs(groupName string) []*corev1.PersistentVolumeClaim {
+	pvcs := []*corev1.PersistentVolumeClaim{}
+
+	for idx := range v.volRepPVCs {
+		pvc := &v.volRepPVCs[idx]
+		if pvc.GetLabels()[pvcVolumeGroupLabel] == groupName {
+			pvcs = append(pvcs, pvc)
+		}
+	}
+
+	return pvcs
+}
+
+// createOrUpdateVGR is the volume group counterpart of createOrUpdateVR. It
+// ensures the PVC is a member of the VolumeGroupReplication resource of its
+// provisioner, creating or updating the resource as required, and reports the
+// status of the group as the status of the PVC.
+func (v *VRGInstance) createOrUpdateVGR(pvcNamespacedName types.NamespacedName,
+	state volrep.ReplicationState, log logr.Logger) (bool, error) {
+	const available = true
+
+	pvc := v.findVolRepPVC(pvcNamespacedName)
+	if pvc == nil {
+		return !available, fmt.Errorf("failed to find PersistentVolumeClaim (%s) in VolumeReplicationGroup (%s/%s)",
+			pvcNamespacedName, v.instance.Namespace, v.instance.Name)
+	}
+
+	storageClass, err := v.getStorageClass(pvcNamespacedName)
+	if err != nil {
+		msg := "Failed to get the storageclass of the PVC"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+
+		return !available, err
+	}
+
+	groupName := v.volumeGroupName(pvc.Namespace, storageClass.Provisioner)
+
+	if err := v.addVolumeGroupLabelToPVC(pvc, groupName, log); err != nil {
+		msg := "Failed to label PVC as a member of its volume group"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+
+		return !available, err
+	}
+
+	vgr := newVolumeGroupReplication()
+
+	vgrNamespacedName := types.NamespacedName{Name: groupName, Namespace: pvc.Namespace}
+	if err := v.reconciler.Get(v.ctx, vgrNamespacedName, vgr); err != nil {
+		if !errors.IsNotFound(err) {
+			msg := "Failed to get VolumeGroupReplication resource"
+			v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonErrorUnknown, msg)
+
+			return !available, fmt.Errorf("failed to get VolumeGroupReplication resource (%s), %w",
+				vgrNamespacedName, err)
+		}
+
+		if err := v.createVGR(vgrNamespacedName, pvcNamespacedName, storageClass.Provisioner, state); err != nil {
+			log.Error(err, "Failed to create VolumeGroupReplication resource", "resource", vgrNamespacedName)
+			rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+				rmnutil.EventReasonVRCreateFailed, err.Error())
+
+			msg := "Failed to create VolumeGroupReplication resource"
+			v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+
+			return !available, err
+		}
+
+		msg := "Created VolumeGroupReplication resource for PVC"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
+
+		return !available, nil
+	}
+
+	return v.updateVGR(vgr, pvc, storageClass.Provisioner, state, log)
+}
+
+func (v *VRGInstance) updateVGR(vgr *unstructured.Unstructured, pvc *corev1.PersistentVolumeClaim,
+	provisioner string, state volrep.ReplicationState, log logr.Logger) (bool, error) {
+	const available = true
+
+	status := volumeGroupReplicationStatus{}
+	if statusMap, found, _ := unstructured.NestedMap(vgr.Object, "status"); found {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusMap, &status); err != nil {
+			return !available, fmt.Errorf("failed to parse status of VolumeGroupReplication resource (%s/%s), %w",
+				vgr.GetNamespace(), vgr.GetName(), err)
+		}
+	}
+
+	v.updateProtectedVolumeGroup(vgr.GetName(), provisioner, status)
+
+	currentState, _, _ := unstructured.NestedString(vgr.Object, "spec", "replicationState")
+
+	// A group demoted to remove its members, as the VRG is deleted, is not promoted again
+	if state == volrep.Primary && currentState == string(volrep.Secondary) &&
+		!v.instance.GetDeletionTimestamp().IsZero() {
+		return available, nil
+	}
+
+	if currentState == string(state) {
+		log.Info("VolumeGroupReplication and VolumeReplicationGroup state match. Proceeding to status check")
+
+		// Evaluate the group status as the status of a VolumeReplication
+		// resource of the PVC, to report it in the same way
+		return v.checkVRStatus(&volrep.VolumeReplication{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       pvc.Name,
+				Namespace:  pvc.Namespace,
+				Generation: vgr.GetGeneration(),
+			},
+			Status: volrep.VolumeReplicationStatus{
+				ObservedGeneration: status.ObservedGeneration,
+				Conditions:         status.Conditions,
+			},
+		})
+	}
+
+	// A group is demoted only once all of its PVCs are ready to be Secondary
+	if state == volrep.Secondary && !v.volumeGroupReadyForSecondary(vgr.GetName()) {
+		msg := "Waiting for all PVCs of the volume group to be ready to become Secondary"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
+
+		return !available, nil
+	}
+
+	if err := unstructured.SetNestedField(vgr.Object, string(state), "spec", "replicationState"); err != nil {
+		return !available, fmt.Errorf("failed to set state of VolumeGroupReplication resource (%s/%s), %w",
+			vgr.GetNamespace(), vgr.GetName(), err)
+	}
+
+	if err := v.reconciler.Update(v.ctx, vgr); err != nil {
+		rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
+			rmnutil.EventReasonVRUpdateFailed, err.Error())
+
+		msg := "Failed to update VolumeGroupReplication resource"
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
+
+		return !available, fmt.Errorf("failed to update VolumeGroupReplication resource (%s/%s) as %s, %w",
+			vgr.GetNamespace(), vgr.GetName(), state, err)
+	}
+
+	log.Info(fmt.Sprintf("Updated VolumeGroupReplication resource (%s/%s) with state %s",
+		vgr.GetNamespace(), vgr.GetName(), state))
+
+	msg := "Updated VolumeGroupReplication resource for PVC"
+	v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
+
+	return !available, nil
+}
+
+// createVGR creates a VolumeGroupReplication resource selecting the PVCs
+// labeled as members of the group
+func (v *VRGInstance) createVGR(vgrNamespacedName, pvcNamespacedName types.NamespacedName, provisioner string,
+	state volrep.ReplicationState,
+) error {
+	volumeReplicationClass, err := v.selectVolumeReplicationClass(pvcNamespacedName)
+	if err != nil {
+		return fmt.Errorf("failed to find the appropriate VolumeReplicationClass (%s) %w",
+			v.instance.Name, err)
+	}
+
+	volumeGroupReplicationClass, err := v.selectVolumeGroupReplicationClass(provisioner)
+	if err != nil {
+		return err
+	}
+
+	vgr := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"volumeGroupReplicationClassName": volumeGroupReplicationClass,
+			"volumeReplicationClassName":      volumeReplicationClass,
+			"replicationState":                string(state),
+			"source": map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						pvcVolumeGroupLabel: vgrNamespacedName.Name,
+					},
+				},
+			},
+		},
+	}}
+	vgr.SetGroupVersionKind(volumeGroupReplicationGVK)
+	vgr.SetName(vgrNamespacedName.Name)
+	vgr.SetNamespace(vgrNamespacedName.Namespace)
+
+	if err := v.setVRGOwner(vgr); err != nil {
+		return fmt.Errorf("failed to set owner reference to VolumeGroupReplication resource (%s), %w",
+			vgrNamespacedName, err)
+	}
+
+	v.log.Info("Creating VolumeGroupReplication resource", "resource", vgrNamespacedName)
+
+	if err := v.reconciler.Create(v.ctx, vgr); err != nil {
+		return fmt.Errorf("failed to create VolumeGroupReplication resource (%s), %w", vgrNamespacedName, err)
+	}
+
+	return nil
+}
+
+// selectVolumeGroupReplicationClass returns the name of the
+// VolumeGroupReplicationClass, among those selected by the VRG replication
+// class selector, that matches both the provisioner and the VRG schedule
+func (v *VRGInstance) selectVolumeGroupReplicationClass(provisioner string) (string, error) {
+	classList := &unstructured.UnstructuredList{}
+	classList.SetGroupVersionKind(volumeGroupReplicationClassListGVK)
+
+	selector, err := metav1.LabelSelectorAsSelector(&v.instance.Spec.Async.ReplicationClassSelector)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the replication class selector, %w", err)
+	}
+
+	if err := v.reconciler.List(v.ctx, classList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", fmt.Errorf("failed to list VolumeGroupReplicationClasses, %w", err)
+	}
+
+	for idx := range classList.Items {
+		class := &classList.Items[idx]
+
+		classProvisioner, _, _ := unstructured.NestedString(class.Object, "spec", "provisioner")
+		if classProvisioner != provisioner {
+			continue
+		}
+
+		schedulingInterval, found, _ := unstructured.NestedString(class.Object, "spec", "parameters",
+			"schedulingInterval")
+		if found && schedulingInterval == v.instance.Spec.Async.SchedulingInterval {
+			return class.GetName(), nil
+		}
+	}
+
+	v.log.Info(fmt.Sprintf("No VolumeGroupReplicationClass found to match provisioner and schedule %s/%s",
+		provisioner, v.instance.Spec.Async.SchedulingInterval))
+
+	return "", fmt.Errorf("no VolumeGroupReplicationClass found to match provisioner and schedule")
+}
+
+// volumeGroupReadyForSecondary returns true if every PVC of the named volume
+// group is being deleted and no longer in use
+func (v *VRGInstance) volumeGroupReadyForSecondary(groupName string) bool {
+	for _, pvc := range v.volumeGroupPVCs(groupName) {
+		if pvc.GetDeletionTimestamp().IsZero() || containsString(pvc.Finalizers, pvcInUse) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *VRGInstance) addVolumeGroupLabelToPVC(pvc *corev1.PersistentVolumeClaim, groupName string,
+	log logr.Logger,
+) error {
+	if pvc.GetLabels()[pvcVolumeGroupLabel] == groupName {
+		return nil
+	}
+
+	if pvc.Labels == nil {
+		pvc.Labels = map[string]string{}
+	}
+
+	pvc.Labels[pvcVolumeGroupLabel] = groupName
+
+	if err := v.reconciler.Update(v.ctx, pvc); err != nil {
+		return fmt.Errorf("failed to add volume group label to PersistentVolumeClaim (%s/%s), %w",
+			pvc.Namespace, pvc.Name, err)
+	}
+
+	log.Info("Added volume group label to PersistentVolumeClaim", "group", groupName)
+
+	return nil
+}
+
+// deleteVGRForPVC removes the PVC from its volume group, and deletes the
+// VolumeGroupReplication resource of the group once no other PVC is a member.
+// The membership of a group is changed only once the group is demoted, as
+// storage may not consistently replicate a Primary group whose members change.
+func (v *VRGInstance) deleteVGRForPVC(pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
+	groupName, ok := pvc.GetLabels()[pvcVolumeGroupLabel]
+	if !ok {
+		return nil
+	}
+
+	lastMember := len(v.volumeGroupPVCs(groupName)) == 1
+	if !lastMember {
+		if err := v.demoteVolumeGroup(pvc.Namespace, groupName, log); err != nil {
+			return err
+		}
+	}
+
+	delete(pvc.Labels, pvcVolumeGroupLabel)
+
+	if err := v.reconciler.Update(v.ctx, pvc); err != nil {
+		return fmt.Errorf("failed to remove volume group label from PersistentVolumeClaim (%s/%s), %w",
+			pvc.Namespace, pvc.Name, err)
+	}
+
+	if !lastMember {
+		return nil
+	}
+
+	vgr := newVolumeGroupReplication()
+	vgr.SetName(groupName)
+	vgr.SetNamespace(pvc.Namespace)
+
+	if err := v.reconciler.Delete(v.ctx, vgr); err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "Failed to delete VolumeGroupReplication resource")
+
+		return fmt.Errorf("failed to delete VolumeGroupReplication resource (%s/%s), %w",
+			pvc.Namespace, groupName, err)
+	}
+
+	v.removeProtectedVolumeGroup(groupName)
+
+	return nil
+}
+
+// demoteVolumeGroup requests the named VolumeGroupReplication resource to be
+// Secondary, and returns an error until it reports it is
+func (v *VRGInstance) demoteVolumeGroup(namespace, groupName string, log logr.Logger) error {
+	vgr := newVolumeGroupReplication()
+
+	if err := v.reconciler.Get(v.ctx, types.NamespacedName{Name: groupName, Namespace: namespace}, vgr); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get VolumeGroupReplication resource (%s/%s), %w", namespace, groupName, err)
+	}
+
+	currentState, _, _ := unstructured.NestedString(vgr.Object, "spec", "replicationState")
+	if currentState != string(volrep.Secondary) {
+		if err := unstructured.SetNestedField(vgr.Object, string(volrep.Secondary), "spec",
+			"replicationState"); err != nil {
+			return fmt.Errorf("failed to set state of VolumeGroupReplication resource (%s/%s), %w",
+				namespace, groupName, err)
+		}
+
+		if err := v.reconciler.Update(v.ctx, vgr); err != nil {
+			return fmt.Errorf("failed to demote VolumeGroupReplication resource (%s/%s), %w",
+				namespace, groupName, err)
+		}
+
+		log.Info("Demoting VolumeGroupReplication resource to change its members", "group", groupName)
+	}
+
+	if state, _, _ := unstructured.NestedString(vgr.Object, "status", "state"); state != string(volrep.SecondaryState) {
+		return fmt.Errorf("waiting for VolumeGroupReplication resource (%s/%s) to be demoted", namespace, groupName)
+	}
+
+	return nil
+}
+
+// updateProtectedVolumeGroup records the members and the status of the named
+// volume group in the VRG status
+func (v *VRGInstance) updateProtectedVolumeGroup(groupName, provisioner string,
+	status volumeGroupReplicationStatus,
+) {
+	pvcNames := []string{}
+	for _, pvc := range v.volumeGroupPVCs(groupName) {
+		pvcNames = append(pvcNames, pvc.Name)
+	}
+
+	sort.Strings(pvcNames)
+
+	protectedVolumeGroup := ramendrv1alpha1.ProtectedVolumeGroup{
+		Name:        groupName,
+		Provisioner: provisioner,
+		PVCs:        pvcNames,
+		State:       status.State,
+		Conditions:  status.Conditions,
+	}
+
+	for idx := range v.instance.Status.ProtectedVolumeGroups {
+		if v.instance.Status.ProtectedVolumeGroups[idx].Name == groupName {
+			v.instance.Status.ProtectedVolumeGroups[idx] = protectedVolumeGroup
+
+			return
+		}
+	}
+
+	v.instance.Status.ProtectedVolumeGroups = append(v.instance.Status.ProtectedVolumeGroups, protectedVolumeGroup)
+}
+
+func (v *VRGInstance) removeProtectedVolumeGroup(groupName string) {
+	groups := v.instance.Status.ProtectedVolumeGroups
+
+	for idx := range groups {
+		if groups[idx].Name == groupName {
+			v.instance.Status.ProtectedVolumeGroups = append(groups[:idx], groups[idx+1:]...)
+
+			return
+		}
+	}
+}
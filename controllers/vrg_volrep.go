@@ -18,6 +18,9 @@ package controllers
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -28,7 +31,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
@@ -40,48 +42,18 @@ import (
 func (v *VRGInstance) reconcileVolRepsAsPrimary() bool {
 	requeue := false
 
-	for idx := range v.volRepPVCs {
-		pvc := &v.volRepPVCs[idx]
-		pvcNamespacedName := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
-		log := v.log.WithValues("pvc", pvcNamespacedName.String())
-
-		requeueResult, skip := v.preparePVCForVRProtection(pvc, log)
-		if requeueResult {
-			requeue = true
-
-			continue
-		}
-
-		if skip {
+	for idx, err := range v.forEachPVC(v.volRepPVCs, reconcileVolRepAsPrimary) {
+		if err == nil {
 			continue
 		}
 
-		if _, err := v.processVRAsPrimary(pvcNamespacedName, log); err != nil {
-			log.Info("Requeuing due to failure in getting or creating VolumeReplication resource for PersistentVolumeClaim",
-				"errorValue", err)
-
-			requeue = true
-
-			continue
-		}
-
-		// Protect the PVC's PV object stored in etcd by uploading it to S3
-		// store(s).  Note that the VRG is responsible only to protect the PV
-		// object of each PVC of the subscription.  However, the PVC object
-		// itself is assumed to be protected along with other k8s objects in the
-		// subscription, such as, the deployment, pods, services, etc., by an
-		// entity external to the VRG a la IaC.
-		if err := v.uploadPVToS3Stores(pvc, log); err != nil {
-			log.Info("Requeuing due to failure to upload PV object to S3 store(s)",
-				"errorValue", err)
-			// TODO: use requeueAfter time duration.
-			requeue = true
-
-			continue
-		}
+		pvc := &v.volRepPVCs[idx]
+		v.log.Info("Requeuing due to failure to reconcile PersistentVolumeClaim",
+			"pvc", types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}.String(), "errorValue", err)
 
-		log.Info("Successfully processed VolumeReplication for PersistentVolumeClaim")
+		requeue = true
 	}
+
 	// We don't need the final sync preparation for VolRep. Just mark it complete
 	if v.instance.Spec.PrepareForFinalSync && len(v.volSyncPVCs) == 0 {
 		v.instance.Status.PrepareForFinalSyncComplete = true
@@ -94,6 +66,48 @@ func (v *VRGInstance) reconcileVolRepsAsPrimary() bool {
 	return requeue
 }
 
+// reconcileVolRepAsPrimary creates/updates the VolumeReplication CR of the pvc
+// and uploads its PV to the S3 stores. Returns an error to requeue.
+func reconcileVolRepAsPrimary(v *VRGInstance, pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
+	pvcNamespacedName := types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}
+
+	requeueResult, skip := v.preparePVCForVRProtection(pvc, log)
+	if requeueResult {
+		return fmt.Errorf("failed to prepare PersistentVolumeClaim for VolumeReplication protection")
+	}
+
+	if skip {
+		return nil
+	}
+
+	if _, err := v.processVRAsPrimary(pvcNamespacedName, log); err != nil {
+		log.Info("Requeuing due to failure in getting or creating VolumeReplication resource for PersistentVolumeClaim",
+			"errorValue", err)
+
+		return err
+	}
+
+	if err := v.updateVolRepPVCSuspended(pvc, log); err != nil {
+		log.Info("Requeuing due to failure to update suspension of VolumeReplication", "errorValue", err)
+
+		return err
+	}
+
+	// Protect the PVC's PV object stored in etcd by uploading it to S3
+	// store(s), along with the PVC object, to recreate it bound to the PV
+	// for applications whose deployer does not recreate their PVCs.
+	if err := v.uploadPVToS3Stores(pvc, log); err != nil {
+		log.Info("Requeuing due to failure to upload PV object to S3 store(s)",
+			"errorValue", err)
+		// TODO: use requeueAfter time duration.
+		return err
+	}
+
+	log.Info("Successfully processed VolumeReplication for PersistentVolumeClaim")
+
+	return nil
+}
+
 // reconcileVolRepsAsSecondary reconciles VolumeReplication resources for the VRG as secondary
 func (v *VRGInstance) reconcileVolRepsAsSecondary() bool {
 	requeue := false
@@ -170,7 +184,7 @@ func (v *VRGInstance) isPVCReadyForSecondary(pvc *corev1.PersistentVolumeClaim,
 		log.Info("VolumeReplication cannot become Secondary, as its PersistentVolumeClaim is not marked for deletion")
 
 		msg := "PVC not being deleted. Not ready to become Secondary"
-		v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonProgressing, msg)
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
 
 		return !ready
 	}
@@ -180,7 +194,7 @@ func (v *VRGInstance) isPVCReadyForSecondary(pvc *corev1.PersistentVolumeClaim,
 		log.Info("VolumeReplication cannot become Secondary, as its PersistentVolumeClaim is still in use")
 
 		msg := "PVC still in use"
-		v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonProgressing, msg)
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
 
 		return !ready
 	}
@@ -212,7 +226,7 @@ func (v *VRGInstance) preparePVCForVRProtection(pvc *corev1.PersistentVolumeClai
 		// Since pvc is skipped, mark the condition for the PVC as progressing. Even for
 		// deletion this applies where if the VR protection finalizer is absent for pvc and
 		// it is being deleted.
-		v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonProgressing, msg)
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonProgressing, msg)
 
 		return !requeue, skip
 	}
@@ -231,7 +245,7 @@ func (v *VRGInstance) protectPVC(pvc *corev1.PersistentVolumeClaim,
 		log.Info("Requeuing, as adding PersistentVolumeClaim finalizer failed", "errorValue", err)
 
 		msg := "Failed to add Protected Finalizer to PVC"
-		v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonError, msg)
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
 
 		return requeue, !skip
 	}
@@ -240,7 +254,7 @@ func (v *VRGInstance) protectPVC(pvc *corev1.PersistentVolumeClaim,
 		log.Info("Requeuing, as retaining PersistentVolume failed", "errorValue", err)
 
 		msg := "Failed to retain PV for PVC"
-		v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonError, msg)
+		v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
 
 		return requeue, !skip
 	}
@@ -251,7 +265,7 @@ func (v *VRGInstance) protectPVC(pvc *corev1.PersistentVolumeClaim,
 			log.Info("Requeuing, as annotating PersistentVolumeClaim failed", "errorValue", err)
 
 			msg := "Failed to add protected annotatation to PVC"
-			v.updatePVCDataReadyCondition(pvc.Name, VRGConditionReasonError, msg)
+			v.updatePVCDataReadyCondition(pvc.Namespace, pvc.Name, VRGConditionReasonError, msg)
 
 			return requeue, !skip
 		}
@@ -402,22 +416,26 @@ func (v *VRGInstance) undoPVRetentionForPVC(pvc corev1.PersistentVolumeClaim, lo
 // Upload PV to the list of S3 stores in the VRG spec
 func (v *VRGInstance) uploadPVToS3Stores(pvc *corev1.PersistentVolumeClaim, log logr.Logger) (err error) {
 	// Find the ProtectedPVC of the given PVC in v.instance.Status.ProtectedPVCs[]
-	protectedPVC := v.findProtectedPVC(pvc.Name)
+	protectedPVC := v.findProtectedPVC(pvc.Namespace, pvc.Name)
 	// Find the ClusterDataProtected condition of the given PVC in ProtectedPVC.Conditions
 	clusterDataProtected := findCondition(protectedPVC.Conditions, VRGConditionTypeClusterDataProtected)
 
-	// Optimization: skip uploading the PV of this PVC if it was uploaded previously
+	// Optimization: skip uploading the PV of this PVC if it was uploaded
+	// previously, and the PVC too unless it changed since it was uploaded
 	if clusterDataProtected != nil && clusterDataProtected.Status == metav1.ConditionTrue &&
 		clusterDataProtected.ObservedGeneration == v.instance.Generation {
-		// v.log.Info("PV cluster data already protected")
-		return nil
+		if protectedPVC.UploadedResourceVersion == pvc.ResourceVersion {
+			return nil
+		}
+
+		return v.uploadPVCToS3Stores(pvc, protectedPVC)
 	}
 
 	// Error out if VRG has no S3 profiles
 	numProfilesToUpload := len(v.instance.Spec.S3Profiles)
 	if numProfilesToUpload == 0 {
 		msg := "Error uploading PV cluster data because VRG spec has no S3 profiles"
-		v.updatePVCClusterDataProtectedCondition(pvc.Name,
+		v.updatePVCClusterDataProtectedCondition(pvc.Namespace, pvc.Name,
 			VRGConditionReasonUploadError, msg)
 		v.log.Info(msg)
 
@@ -436,20 +454,46 @@ func (v *VRGInstance) uploadPVToS3Stores(pvc *corev1.PersistentVolumeClaim, log
 		msg := fmt.Sprintf("Done uploading PV cluster data to %d of %d S3 profile(s): %v",
 			numProfilesUploaded, numProfilesToUpload, s3Profiles)
 		v.log.Info(msg)
-		v.updatePVCClusterDataProtectedCondition(pvc.Name,
+		v.updatePVCClusterDataProtectedCondition(pvc.Namespace, pvc.Name,
 			VRGConditionReasonUploaded, msg)
+
+		protectedPVC.UploadedResourceVersion = pvc.ResourceVersion
 	} else {
 		// Merely defensive as we don't expect to reach here
 		msg := fmt.Sprintf("Uploaded PV cluster data to only  %d of %d S3 profile(s): %v",
 			numProfilesUploaded, numProfilesToUpload, s3Profiles)
 		v.log.Info(msg)
-		v.updatePVCClusterDataProtectedCondition(pvc.Name,
+		v.updatePVCClusterDataProtectedCondition(pvc.Namespace, pvc.Name,
 			VRGConditionReasonUploadError, msg)
 	}
 
 	return nil
 }
 
+// uploadPVCToS3Stores uploads the PVC, whose PV is uploaded already, to the S3
+// stores in the VRG spec, as it changed since it was last uploaded
+func (v *VRGInstance) uploadPVCToS3Stores(pvc *corev1.PersistentVolumeClaim,
+	protectedPVC *ramendrv1alpha1.ProtectedPVC,
+) error {
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		objectStore, err := v.getObjectStore(s3ProfileName)
+		if err == nil {
+			err = v.reconciler.PVUploader.UploadPVC(objectStore, v.s3KeyPrefix(), pvcForUpload(pvc))
+		}
+
+		if err != nil {
+			err = fmt.Errorf("error uploading PVC cluster data to s3Profile %s, %w", s3ProfileName, err)
+			v.updatePVCClusterDataProtectedCondition(pvc.Namespace, pvc.Name, VRGConditionReasonUploadError, err.Error())
+
+			return err
+		}
+	}
+
+	protectedPVC.UploadedResourceVersion = pvc.ResourceVersion
+
+	return nil
+}
+
 func (v *VRGInstance) PVUploadToObjectStore(s3ProfileName string, pvc *corev1.PersistentVolumeClaim) error {
 	if s3ProfileName == "" {
 		return fmt.Errorf("error uploading cluster data of PV %s because VRG spec has no S3 profiles",
@@ -468,12 +512,24 @@ func (v *VRGInstance) PVUploadToObjectStore(s3ProfileName string, pvc *corev1.Pe
 			pvc.Name, s3ProfileName, err)
 	}
 
+	// Record the upload time, to resolve conflicts between PVs bound to the
+	// same claim when restoring
+	if pv.Annotations == nil {
+		pv.Annotations = map[string]string{}
+	}
+
+	pv.Annotations[pvUploadTimeAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+
 	if err := v.reconciler.PVUploader.UploadPV(objectStore, v.s3KeyPrefix(), &pv); err != nil {
 		err := fmt.Errorf("error uploading PV cluster data to s3Profile %s, %w", s3ProfileName, err)
 
 		return err
 	}
 
+	if err := v.reconciler.PVUploader.UploadPVC(objectStore, v.s3KeyPrefix(), pvcForUpload(pvc)); err != nil {
+		return fmt.Errorf("error uploading PVC cluster data to s3Profile %s, %w", s3ProfileName, err)
+	}
+
 	return nil
 }
 
@@ -484,7 +540,7 @@ func (v *VRGInstance) PVUploadToObjectStores(pvc *corev1.PersistentVolumeClaim,
 	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
 		err := v.PVUploadToObjectStore(s3ProfileName, pvc)
 		if err != nil {
-			v.updatePVCClusterDataProtectedCondition(pvc.Name, VRGConditionReasonUploadError, err.Error())
+			v.updatePVCClusterDataProtectedCondition(pvc.Namespace, pvc.Name, VRGConditionReasonUploadError, err.Error())
 			rmnutil.ReportIfNotPresent(v.reconciler.eventRecorder, v.instance, corev1.EventTypeWarning,
 				rmnutil.EventReasonPVUploadFailed, err.Error())
 
@@ -530,6 +586,17 @@ func (ObjectStorePVUploader) UploadPV(objectStore ObjectStorer,
 	return nil
 }
 
+// UploadPVC uploads the cluster data of the input PVC to the s3 store, with a
+// key suffix of the PVC namespace and name
+func (ObjectStorePVUploader) UploadPVC(objectStore ObjectStorer,
+	pvcKeyPrefix string, pvc *corev1.PersistentVolumeClaim) error {
+	if err := objectStore.UploadPVC(pvcKeyPrefix, pvc.Namespace+"/"+pvc.Name, *pvc); err != nil {
+		return fmt.Errorf("error uploading PVC %s/%s, err %w", pvc.Namespace, pvc.Name, err)
+	}
+
+	return nil
+}
+
 // reconcileVRsForDeletion cleans up VR resources managed by VRG and also cleans up changes made to PVCs
 // TODO: Currently removes VR requests unconditionally, needs to ensure it is managed by VRG
 func (v *VRGInstance) reconcileVRsForDeletion() bool {
@@ -613,7 +680,13 @@ func (v *VRGInstance) reconcileVRForDeletion(pvc *corev1.PersistentVolumeClaim,
 
 	// Deleting VR first may end-up recreating the VR if reconcile for this PVC is interrupted, but that is better than
 	// leaking a VR as that would result in leaking a volume on the storage system
-	if err := v.deleteVR(pvcNamespacedName, log); err != nil {
+	if v.instance.Spec.Async.VolumeGroupReplication {
+		err = v.deleteVGRForPVC(pvc, log)
+	} else {
+		err = v.deleteVR(pvcNamespacedName, log)
+	}
+
+	if err != nil {
 		log.Info("Requeuing due to failure in finalizing VolumeReplication resource for PersistentVolumeClaim",
 			"errorValue", err)
 
@@ -685,6 +758,10 @@ func (ObjectStorePVDeleter) DeletePVs(v interface{}, s3ProfileName string) (err
 // related PVC is prepared for VR protection
 func (v *VRGInstance) processVRAsPrimary(vrNamespacedName types.NamespacedName, log logr.Logger) (bool, error) {
 	if v.instance.Spec.Async.Mode == ramendrv1alpha1.AsyncModeEnabled {
+		if v.instance.Spec.Async.VolumeGroupReplication {
+			return v.createOrUpdateVGR(vrNamespacedName, volrep.Primary, log)
+		}
+
 		return v.createOrUpdateVR(vrNamespacedName, volrep.Primary, log)
 	}
 
@@ -697,8 +774,8 @@ func (v *VRGInstance) processVRAsPrimary(vrNamespacedName types.NamespacedName,
 	// condition where both async and sync are enabled at the same time.
 	if v.instance.Spec.Sync.Mode == ramendrv1alpha1.SyncModeEnabled {
 		msg := "PVC in the VolumeReplicationGroup is ready for use"
-		v.updatePVCDataReadyCondition(vrNamespacedName.Name, VRGConditionReasonReady, msg)
-		v.updatePVCDataProtectedCondition(vrNamespacedName.Name, VRGConditionReasonReady, msg)
+		v.updatePVCDataReadyCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonReady, msg)
+		v.updatePVCDataProtectedCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonReady, msg)
 
 		return true, nil
 	}
@@ -710,6 +787,10 @@ func (v *VRGInstance) processVRAsPrimary(vrNamespacedName types.NamespacedName,
 // related PVC is prepared for VR as secondary
 func (v *VRGInstance) processVRAsSecondary(vrNamespacedName types.NamespacedName, log logr.Logger) (bool, error) {
 	if v.instance.Spec.Async.Mode == ramendrv1alpha1.AsyncModeEnabled {
+		if v.instance.Spec.Async.VolumeGroupReplication {
+			return v.createOrUpdateVGR(vrNamespacedName, volrep.Secondary, log)
+		}
+
 		return v.createOrUpdateVR(vrNamespacedName, volrep.Secondary, log)
 	}
 
@@ -722,8 +803,9 @@ func (v *VRGInstance) processVRAsSecondary(vrNamespacedName types.NamespacedName
 	// condition where both async and sync are enabled at the same time.
 	if v.instance.Spec.Sync.Mode == ramendrv1alpha1.SyncModeEnabled {
 		msg := "VolumeReplication resource for the pvc as Secondary is in sync with Primary"
-		v.updatePVCDataReadyCondition(vrNamespacedName.Name, VRGConditionReasonReplicated, msg)
-		v.updatePVCDataProtectedCondition(vrNamespacedName.Name, VRGConditionReasonDataProtected, msg)
+		v.updatePVCDataReadyCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonReplicated, msg)
+		v.updatePVCDataProtectedCondition(vrNamespacedName.Namespace, vrNamespacedName.Name,
+			VRGConditionReasonDataProtected, msg)
 
 		return true, nil
 	}
@@ -754,7 +836,7 @@ func (v *VRGInstance) createOrUpdateVR(vrNamespacedName types.NamespacedName,
 			// is it replicating or not. So, mark the protected pvc as error
 			// with condition.status as Unknown.
 			msg := "Failed to get VolumeReplication resource"
-			v.updatePVCDataReadyCondition(vrNamespacedName.Name, VRGConditionReasonErrorUnknown, msg)
+			v.updatePVCDataReadyCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonErrorUnknown, msg)
 
 			return !available, fmt.Errorf("failed to get VolumeReplication resource"+
 				" (%s/%s) belonging to VolumeReplicationGroup (%s/%s), %w",
@@ -768,7 +850,7 @@ func (v *VRGInstance) createOrUpdateVR(vrNamespacedName types.NamespacedName,
 				rmnutil.EventReasonVRCreateFailed, err.Error())
 
 			msg := "Failed to create VolumeReplication resource"
-			v.updatePVCDataReadyCondition(vrNamespacedName.Name, VRGConditionReasonError, msg)
+			v.updatePVCDataReadyCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonError, msg)
 
 			return !available, fmt.Errorf("failed to create VolumeReplication resource"+
 				" (%s/%s) belonging to VolumeReplicationGroup (%s/%s), %w",
@@ -777,7 +859,7 @@ func (v *VRGInstance) createOrUpdateVR(vrNamespacedName types.NamespacedName,
 
 		// Just created VolRep. Mark status.conditions as Progressing.
 		msg := "Created VolumeReplication resource for PVC"
-		v.updatePVCDataReadyCondition(vrNamespacedName.Name, VRGConditionReasonProgressing, msg)
+		v.updatePVCDataReadyCondition(vrNamespacedName.Namespace, vrNamespacedName.Name, VRGConditionReasonProgressing, msg)
 
 		return !available, nil
 	}
@@ -805,7 +887,7 @@ func (v *VRGInstance) updateVR(volRep *volrep.VolumeReplication,
 			rmnutil.EventReasonVRUpdateFailed, err.Error())
 
 		msg := "Failed to update VolumeReplication resource"
-		v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonError, msg)
+		v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg)
 
 		return !available, fmt.Errorf("failed to update VolumeReplication resource"+
 			" (%s/%s) as %s, belonging to VolumeReplicationGroup (%s/%s), %w",
@@ -817,7 +899,7 @@ func (v *VRGInstance) updateVR(volRep *volrep.VolumeReplication,
 		volRep.Name, volRep.Namespace, state))
 	// Just updated the state of the VolRep. Mark it as progressing.
 	msg := "Updated VolumeReplication resource for PVC"
-	v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonProgressing, msg)
+	v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonProgressing, msg)
 
 	return !available, nil
 }
@@ -848,7 +930,7 @@ func (v *VRGInstance) createVR(vrNamespacedName types.NamespacedName, state volr
 
 	// Let VRG receive notification for any changes to VolumeReplication CR
 	// created by VRG.
-	if err := ctrl.SetControllerReference(v.instance, volRep, v.reconciler.Scheme); err != nil {
+	if err := v.setVRGOwner(volRep); err != nil {
 		return fmt.Errorf("failed to set owner reference to VolumeReplication resource (%s/%s), %w",
 			volRep.Name, volRep.Namespace, err)
 	}
@@ -974,11 +1056,13 @@ func (v *VRGInstance) checkVRStatus(volRep *volrep.VolumeReplication) (bool, err
 			volRep.Name, volRep.Namespace))
 
 		msg := "VolumeReplication generation not updated in status"
-		v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonProgressing, msg)
+		v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonProgressing, msg)
 
 		return !available, nil
 	}
 
+	v.updatePVCSyncStatusFromVR(volRep)
+
 	switch {
 	case v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary:
 		return v.validateVRStatus(volRep, ramendrv1alpha1.Primary), nil
@@ -986,7 +1070,7 @@ func (v *VRGInstance) checkVRStatus(volRep *volrep.VolumeReplication) (bool, err
 		return v.validateVRStatus(volRep, ramendrv1alpha1.Secondary), nil
 	default:
 		msg := "VolumeReplicationGroup state invalid"
-		v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonError, msg)
+		v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg)
 
 		return !available, fmt.Errorf("invalid Replication State %s for VolumeReplicationGroup (%s:%s)",
 			string(v.instance.Spec.ReplicationState), v.instance.Name, v.instance.Namespace)
@@ -1019,10 +1103,10 @@ func (v *VRGInstance) validateVRStatus(volRep *volrep.VolumeReplication, state r
 	conditionMet, msg := isVRConditionMet(volRep, volrepController.ConditionCompleted, metav1.ConditionTrue)
 	if !conditionMet {
 		defaultMsg := fmt.Sprintf("VolumeReplication resource for pvc not %s to %s", action, stateString)
-		v.updatePVCDataReadyConditionHelper(volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataReadyConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.updatePVCDataProtectedConditionHelper(volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataProtectedConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
 		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.Name, volRep.Namespace))
@@ -1033,9 +1117,9 @@ func (v *VRGInstance) validateVRStatus(volRep *volrep.VolumeReplication, state r
 	// if primary, all checks are completed
 	if state == ramendrv1alpha1.Primary {
 		msg = "PVC in the VolumeReplicationGroup is ready for use"
-		v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonReady, msg)
+		v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReady, msg)
 
-		v.updatePVCDataProtectedCondition(volRep.Name, VRGConditionReasonReady, msg)
+		v.updatePVCDataProtectedCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReady, msg)
 
 		v.log.Info(fmt.Sprintf("VolumeReplication resource %s/%s is ready for use", volRep.Name,
 			volRep.Namespace))
@@ -1072,10 +1156,10 @@ func (v *VRGInstance) validateAdditionalVRStatusForSecondary(volRep *volrep.Volu
 
 	conditionMet, msg := isVRConditionMet(volRep, volrepController.ConditionDegraded, metav1.ConditionTrue)
 	if !conditionMet {
-		v.updatePVCDataProtectedConditionHelper(volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataProtectedConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
 			"VolumeReplication resource for pvc is not in Degraded condition while resyncing")
 
-		v.updatePVCDataReadyConditionHelper(volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataReadyConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
 			"VolumeReplication resource for pvc is not in Degraded condition while resyncing")
 
 		v.log.Info(fmt.Sprintf("VolumeReplication resource is not in degraded condition while"+
@@ -1085,8 +1169,8 @@ func (v *VRGInstance) validateAdditionalVRStatusForSecondary(volRep *volrep.Volu
 	}
 
 	msg = "VolumeReplication resource for the pvc is syncing as Secondary"
-	v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonReplicating, msg)
-	v.updatePVCDataProtectedCondition(volRep.Name, VRGConditionReasonReplicating, msg)
+	v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReplicating, msg)
+	v.updatePVCDataProtectedCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReplicating, msg)
 
 	v.log.Info(fmt.Sprintf("VolumeReplication resource for the pvc is syncing as Secondary (%s/%s)",
 		volRep.Name, volRep.Namespace))
@@ -1100,10 +1184,10 @@ func (v *VRGInstance) checkResyncCompletionAsSecondary(volRep *volrep.VolumeRepl
 	conditionMet, msg := isVRConditionMet(volRep, volrepController.ConditionResyncing, metav1.ConditionFalse)
 	if !conditionMet {
 		defaultMsg := "VolumeReplication resource for pvc not syncing as Secondary"
-		v.updatePVCDataReadyConditionHelper(volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataReadyConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.updatePVCDataProtectedConditionHelper(volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataProtectedConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
 		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.Name, volRep.Namespace))
@@ -1114,10 +1198,10 @@ func (v *VRGInstance) checkResyncCompletionAsSecondary(volRep *volrep.VolumeRepl
 	conditionMet, msg = isVRConditionMet(volRep, volrepController.ConditionDegraded, metav1.ConditionFalse)
 	if !conditionMet {
 		defaultMsg := "VolumeReplication resource for pvc is not syncing and is degraded as Secondary"
-		v.updatePVCDataReadyConditionHelper(volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataReadyConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
-		v.updatePVCDataProtectedConditionHelper(volRep.Name, VRGConditionReasonError, msg,
+		v.updatePVCDataProtectedConditionHelper(volRep.Namespace, volRep.Name, VRGConditionReasonError, msg,
 			defaultMsg)
 
 		v.log.Info(fmt.Sprintf("%s (VolRep: %s/%s)", defaultMsg, volRep.Name, volRep.Namespace))
@@ -1126,8 +1210,8 @@ func (v *VRGInstance) checkResyncCompletionAsSecondary(volRep *volrep.VolumeRepl
 	}
 
 	msg = "VolumeReplication resource for the pvc as Secondary is in sync with Primary"
-	v.updatePVCDataReadyCondition(volRep.Name, VRGConditionReasonReplicated, msg)
-	v.updatePVCDataProtectedCondition(volRep.Name, VRGConditionReasonDataProtected, msg)
+	v.updatePVCDataReadyCondition(volRep.Namespace, volRep.Name, VRGConditionReasonReplicated, msg)
+	v.updatePVCDataProtectedCondition(volRep.Namespace, volRep.Name, VRGConditionReasonDataProtected, msg)
 
 	v.log.Info(fmt.Sprintf("data sync completed as both degraded and resyncing are false for"+
 		" secondary VolRep (%s/%s)", volRep.Name, volRep.Namespace))
@@ -1168,24 +1252,24 @@ func isVRConditionMet(volRep *volrep.VolumeReplication,
 // Disabling unparam linter as currently every invokation of this
 // function sends reason as VRGConditionReasonError and the linter
 // complains about this function always receiving the same reason.
-func (v *VRGInstance) updatePVCDataReadyConditionHelper(name, reason, message, defaultMessage string) {
+func (v *VRGInstance) updatePVCDataReadyConditionHelper(namespace, name, reason, message, defaultMessage string) {
 	if message != "" {
-		v.updatePVCDataReadyCondition(name, reason, message)
+		v.updatePVCDataReadyCondition(namespace, name, reason, message)
 
 		return
 	}
 
-	v.updatePVCDataReadyCondition(name, reason, defaultMessage)
+	v.updatePVCDataReadyCondition(namespace, name, reason, defaultMessage)
 }
 
-func (v *VRGInstance) updatePVCDataReadyCondition(pvcName, reason, message string) {
-	if protectedPVC := v.findProtectedPVC(pvcName); protectedPVC != nil {
+func (v *VRGInstance) updatePVCDataReadyCondition(pvcNamespace, pvcName, reason, message string) {
+	if protectedPVC := v.findProtectedPVC(pvcNamespace, pvcName); protectedPVC != nil {
 		setPVCDataReadyCondition(protectedPVC, reason, message, v.instance.Generation)
 		// No need to append it as an already existing entry from the list is being modified.
 		return
 	}
 
-	protectedPVC := &ramendrv1alpha1.ProtectedPVC{Name: pvcName}
+	protectedPVC := &ramendrv1alpha1.ProtectedPVC{Name: pvcName, Namespace: v.protectedPVCNamespace(pvcNamespace)}
 	setPVCDataReadyCondition(protectedPVC, reason, message, v.instance.Generation)
 
 	// created a new instance. Add it to the list
@@ -1196,24 +1280,24 @@ func (v *VRGInstance) updatePVCDataReadyCondition(pvcName, reason, message strin
 // Disabling unparam linter as currently every invokation of this
 // function sends reason as VRGConditionReasonError and the linter
 // complains about this function always receiving the same reason.
-func (v *VRGInstance) updatePVCDataProtectedConditionHelper(name, reason, message, defaultMessage string) {
+func (v *VRGInstance) updatePVCDataProtectedConditionHelper(namespace, name, reason, message, defaultMessage string) {
 	if message != "" {
-		v.updatePVCDataProtectedCondition(name, reason, message)
+		v.updatePVCDataProtectedCondition(namespace, name, reason, message)
 
 		return
 	}
 
-	v.updatePVCDataProtectedCondition(name, reason, defaultMessage)
+	v.updatePVCDataProtectedCondition(namespace, name, reason, defaultMessage)
 }
 
-func (v *VRGInstance) updatePVCDataProtectedCondition(pvcName, reason, message string) {
-	if protectedPVC := v.findProtectedPVC(pvcName); protectedPVC != nil {
+func (v *VRGInstance) updatePVCDataProtectedCondition(pvcNamespace, pvcName, reason, message string) {
+	if protectedPVC := v.findProtectedPVC(pvcNamespace, pvcName); protectedPVC != nil {
 		setPVCDataProtectedCondition(protectedPVC, reason, message, v.instance.Generation)
 		// No need to append it as an already existing entry from the list is being modified.
 		return
 	}
 
-	protectedPVC := &ramendrv1alpha1.ProtectedPVC{Name: pvcName}
+	protectedPVC := &ramendrv1alpha1.ProtectedPVC{Name: pvcName, Namespace: v.protectedPVCNamespace(pvcNamespace)}
 	setPVCDataProtectedCondition(protectedPVC, reason, message, v.instance.Generation)
 
 	// created a new instance. Add it to the list
@@ -1270,14 +1354,14 @@ func setPVCDataProtectedCondition(protectedPVC *ramendrv1alpha1.ProtectedPVC, re
 	}
 }
 
-func (v *VRGInstance) updatePVCClusterDataProtectedCondition(pvcName, reason, message string) {
-	if protectedPVC := v.findProtectedPVC(pvcName); protectedPVC != nil {
+func (v *VRGInstance) updatePVCClusterDataProtectedCondition(pvcNamespace, pvcName, reason, message string) {
+	if protectedPVC := v.findProtectedPVC(pvcNamespace, pvcName); protectedPVC != nil {
 		setPVCClusterDataProtectedCondition(protectedPVC, reason, message, v.instance.Generation)
 		// No need to append it as an already existing entry from the list is being modified.
 		return
 	}
 
-	protectedPVC := &ramendrv1alpha1.ProtectedPVC{Name: pvcName}
+	protectedPVC := &ramendrv1alpha1.ProtectedPVC{Name: pvcName, Namespace: v.protectedPVCNamespace(pvcNamespace)}
 	setPVCClusterDataProtectedCondition(protectedPVC, reason, message, v.instance.Generation)
 	v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, *protectedPVC)
 }
@@ -1406,10 +1490,12 @@ func (v *VRGInstance) removeFinalizerFromPVC(pvc *corev1.PersistentVolumeClaim,
 }
 
 // findProtectedPVC returns the &VRG.Status.ProtectedPVC[x] for the given pvcName
-func (v *VRGInstance) findProtectedPVC(pvcName string) *ramendrv1alpha1.ProtectedPVC {
+func (v *VRGInstance) findProtectedPVC(pvcNamespace, pvcName string) *ramendrv1alpha1.ProtectedPVC {
+	namespace := v.protectedPVCNamespace(pvcNamespace)
+
 	for index := range v.instance.Status.ProtectedPVCs {
 		protectedPVC := &v.instance.Status.ProtectedPVCs[index]
-		if protectedPVC.Name == pvcName {
+		if protectedPVC.Name == pvcName && v.protectedPVCNamespace(protectedPVC.Namespace) == namespace {
 			return protectedPVC
 		}
 	}
@@ -1473,8 +1559,10 @@ func (v *VRGInstance) fetchAndRestorePV() (bool, error) {
 		v.log.Info(fmt.Sprintf("Found %d PVs in s3 store whose profile name is %s",
 			len(pvList), s3ProfileName))
 
-		err = v.sanityCheckPVClusterData(pvList)
+		pvList, conflictResults, err := v.sanityCheckPVClusterData(pvList)
 		if err != nil {
+			v.setPVRestoreResults(s3ProfileName, conflictResults)
+
 			errMsg := fmt.Sprintf("error found during sanity check of PV cluster data in S3 store %s", s3ProfileName)
 			v.log.Info(errMsg)
 			v.log.Error(err, fmt.Sprintf("Resolve PV conflict in the S3 store %s to deploy the application", s3ProfileName))
@@ -1482,13 +1570,29 @@ func (v *VRGInstance) fetchAndRestorePV() (bool, error) {
 			return success, fmt.Errorf("%s: %w", errMsg, err)
 		}
 
-		err = v.restorePVClusterData(pvList)
+		restoreResults, err := v.restorePVClusterData(pvList)
+		v.setPVRestoreResults(s3ProfileName, append(conflictResults, restoreResults...))
+
 		if err != nil {
 			success = false
 			// go to the next profile
 			continue
 		}
 
+		restoredPVCs, err := v.restorePVCClusterData(s3ProfileName)
+		if err != nil {
+			v.log.Error(err, fmt.Sprintf("error restoring PVC cluster data from S3 profile %s", s3ProfileName))
+
+			success = false
+			// go to the next profile
+			continue
+		}
+
+		// The PVs are restored, wait for their PVCs to bind to them
+		if err := v.restoredPVCsBound(restoredPVCs); err != nil {
+			return false, err
+		}
+
 		v.log.Info(fmt.Sprintf("Restored %d PVs using profile %s", len(pvList), s3ProfileName))
 
 		success = true
@@ -1514,9 +1618,10 @@ func (v *VRGInstance) fetchPVClusterDataFromS3Store(s3ProfileName string) ([]cor
 	return v.reconciler.PVDownloader.DownloadPVs(objectStore, s3KeyPrefix)
 }
 
-// sanityCheckPVClusterData returns an error if there are PVs in the input
-// pvList that have conflicting claimRefs that point to the same PVC name but
-// different PVC UID.
+// sanityCheckPVClusterData detects PVs in the input pvList that have
+// conflicting claimRefs that point to the same PVC name but different PVC UID.
+// It returns the PVs to restore, and a conflict result for each PV that is not
+// restored.
 //
 // Under normal circumstances, each PV in the S3 store will point to a unique
 // PVC and the sanity check will succeed.  In the case of failover related
@@ -1529,29 +1634,146 @@ func (v *VRGInstance) fetchPVClusterDataFromS3Store(s3ProfileName string) ([]cor
 // store, thus resulting in ambiguous PVs for the same PVC.  If the S3 store
 // ends up in such a situation, Ramen cannot determine with certainty which PV
 // among the conflicting PVs should be restored to the cluster, and thus fails
-// the sanity check.
-func (v *VRGInstance) sanityCheckPVClusterData(pvList []corev1.PersistentVolume) error {
-	pvMap := map[string]corev1.PersistentVolume{}
-	// Scan the PVs and create a map of PVs that have conflicting claimRefs
-	for _, thisPV := range pvList {
-		claimRef := thisPV.Spec.ClaimRef
-		claimKey := fmt.Sprintf("%s/%s", claimRef.Namespace, claimRef.Name)
-
-		prevPV, found := pvMap[claimKey]
-		if !found {
-			pvMap[claimKey] = thisPV
+// the sanity check, unless the PV restore policy resolves the conflict by
+// restoring the most recently uploaded PV, or allows a partial restore that
+// skips the conflicting PVs.
+func (v *VRGInstance) sanityCheckPVClusterData(pvList []corev1.PersistentVolume) (
+	[]corev1.PersistentVolume, []ramendrv1alpha1.PVRestoreResult, error,
+) {
+	claimKeys := []string{}
+	pvsByClaimKey := map[string][]corev1.PersistentVolume{}
+	// Scan the PVs and group the PVs that have conflicting claimRefs
+	for idx := range pvList {
+		claimKey := pvClaimKey(&pvList[idx])
+
+		if _, found := pvsByClaimKey[claimKey]; !found {
+			claimKeys = append(claimKeys, claimKey)
+		}
+
+		pvsByClaimKey[claimKey] = append(pvsByClaimKey[claimKey], pvList[idx])
+	}
+
+	pvsToRestore := make([]corev1.PersistentVolume, 0, len(pvList))
+	conflictResults := []ramendrv1alpha1.PVRestoreResult{}
+	unresolved := []string{}
+
+	for _, claimKey := range claimKeys {
+		pvs := pvsByClaimKey[claimKey]
+		if len(pvs) == 1 {
+			pvsToRestore = append(pvsToRestore, pvs[0])
 
 			continue
 		}
 
-		msg := fmt.Sprintf("when restoring PV cluster data, detected conflicting claimKey %s in PVs %s and %s",
-			claimKey, prevPV.Name, thisPV.Name)
+		pvNames := make([]string, 0, len(pvs))
+		for idx := range pvs {
+			pvNames = append(pvNames, pvs[idx].Name)
+		}
+
+		msg := fmt.Sprintf("when restoring PV cluster data, detected conflicting claimKey %s in PVs %v",
+			claimKey, pvNames)
 		v.log.Info(msg)
 
-		return fmt.Errorf(msg)
+		newest := -1
+		if v.instance.Spec.PVRestore.ConflictResolution == ramendrv1alpha1.PVRestoreConflictResolutionNewest {
+			newest = newestUploadedPV(pvs)
+		}
+
+		if newest == -1 {
+			unresolved = append(unresolved, msg)
+		} else {
+			pvsToRestore = append(pvsToRestore, pvs[newest])
+		}
+
+		for idx := range pvs {
+			if idx == newest {
+				continue
+			}
+
+			result := ramendrv1alpha1.PVRestoreResult{
+				Name:      pvs[idx].Name,
+				ClaimName: claimKey,
+				Result:    ramendrv1alpha1.PVRestoreResultConflict,
+				Message:   fmt.Sprintf("PVs %v are bound to the same claim", pvNames),
+			}
+
+			if newest != -1 {
+				result.Message = fmt.Sprintf("PV %s bound to the same claim was uploaded more recently",
+					pvs[newest].Name)
+			}
+
+			conflictResults = append(conflictResults, result)
+		}
+	}
+
+	if len(unresolved) != 0 && !v.instance.Spec.PVRestore.AllowPartial {
+		return nil, conflictResults, fmt.Errorf("%s", strings.Join(unresolved, "; "))
 	}
 
-	return nil
+	return pvsToRestore, conflictResults, nil
+}
+
+// newestUploadedPV returns the index of the PV uploaded most recently, or -1
+// if the upload time of a PV is unknown or the most recent one is not unique
+func newestUploadedPV(pvs []corev1.PersistentVolume) int {
+	newest := -1
+
+	var newestUploadTime time.Time
+
+	for idx := range pvs {
+		uploadTime, err := time.Parse(time.RFC3339Nano, pvs[idx].GetAnnotations()[pvUploadTimeAnnotation])
+		if err != nil {
+			return -1
+		}
+
+		switch {
+		case newest == -1 || uploadTime.After(newestUploadTime):
+			newest = idx
+			newestUploadTime = uploadTime
+		case uploadTime.Equal(newestUploadTime):
+			return -1
+		}
+	}
+
+	return newest
+}
+
+// pvClaimKey returns the namespace/name of the claim the PV is bound to
+func pvClaimKey(pv *corev1.PersistentVolume) string {
+	if pv.Spec.ClaimRef == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s", pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name)
+}
+
+// setPVRestoreResults records the results of restoring PVs from the named S3
+// profile in the VRG status, replacing those of any previous restore
+func (v *VRGInstance) setPVRestoreResults(s3ProfileName string, results []ramendrv1alpha1.PVRestoreResult) {
+	for idx := range results {
+		results[idx].S3ProfileName = s3ProfileName
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	v.instance.Status.PVRestoreResults = results
+}
+
+// pvsNotRestored returns the number of PVs that the last restore did not
+// restore
+func (v *VRGInstance) pvsNotRestored() int {
+	count := 0
+
+	for idx := range v.instance.Status.PVRestoreResults {
+		switch v.instance.Status.PVRestoreResults[idx].Result {
+		case ramendrv1alpha1.PVRestoreResultConflict, ramendrv1alpha1.PVRestoreResultError:
+			count++
+		}
+	}
+
+	return count
 }
 
 type ObjectStorePVDownloader struct{}
@@ -1561,44 +1783,86 @@ func (s ObjectStorePVDownloader) DownloadPVs(objectStore ObjectStorer, s3KeyPref
 	return objectStore.DownloadPVs(s3KeyPrefix)
 }
 
-func (v *VRGInstance) restorePVClusterData(pvList []corev1.PersistentVolume) error {
+func (s ObjectStorePVDownloader) DownloadPVCs(objectStore ObjectStorer, s3KeyPrefix string) (
+	[]corev1.PersistentVolumeClaim, error) {
+	return objectStore.DownloadPVCs(s3KeyPrefix)
+}
+
+// restorePVClusterData creates the PVs and returns the result of restoring
+// each of them. It fails if a PV is not restored, unless the PV restore policy
+// allows a partial restore.
+func (v *VRGInstance) restorePVClusterData(pvList []corev1.PersistentVolume) (
+	[]ramendrv1alpha1.PVRestoreResult, error,
+) {
 	numRestored := 0
+	results := make([]ramendrv1alpha1.PVRestoreResult, 0, len(pvList))
 
 	for idx := range pvList {
 		pv := &pvList[idx]
 		v.cleanupPVForRestore(pv)
+		v.mapPVStorageClass(pv)
 		v.addPVRestoreAnnotation(pv)
 
-		if err := v.reconciler.Create(v.ctx, pv); err != nil {
-			if errors.IsAlreadyExists(err) {
-				err := v.validatePVExistence(pv)
-				if err != nil {
-					v.log.Info("PV exists. Ignoring and moving to next PV", "error", err.Error())
-					// ignoring any errors
-					continue
-				}
-
-				// Valid PV exists and it is managed by Ramen
-				numRestored++
-
-				continue
-			}
+		result := ramendrv1alpha1.PVRestoreResult{
+			Name:      pv.Name,
+			ClaimName: pvClaimKey(pv),
+			Result:    ramendrv1alpha1.PVRestoreResultRestored,
+		}
 
-			v.log.Info("Failed to restore PV", "name", pv.Name, "Error", err)
+		if err := v.reconciler.Create(v.ctx, pv); err != nil {
+			v.pvCreateErrorResult(pv, err, &result)
+		}
 
-			continue
+		switch result.Result {
+		case ramendrv1alpha1.PVRestoreResultRestored, ramendrv1alpha1.PVRestoreResultAlreadyExists:
+			numRestored++
 		}
 
-		numRestored++
+		results = append(results, result)
 	}
 
 	if numRestored != len(pvList) {
-		return fmt.Errorf("failed to restore all PVs. Total %d. Restored %d", len(pvList), numRestored)
+		if !v.instance.Spec.PVRestore.AllowPartial {
+			return results, fmt.Errorf("failed to restore all PVs. Total %d. Restored %d", len(pvList), numRestored)
+		}
+
+		v.log.Info("Partially restored VolRep PVs, as allowed by the PV restore policy",
+			"Total", len(pvList), "Restored", numRestored)
+
+		return results, nil
 	}
 
 	v.log.Info("Success restoring VolRep PVs", "Total", numRestored)
 
-	return nil
+	return results, nil
+}
+
+// pvCreateErrorResult sets the restore result of a PV that failed to be
+// created. A PV that already exists is valid if it was restored by Ramen, and
+// in conflict otherwise.
+func (v *VRGInstance) pvCreateErrorResult(pv *corev1.PersistentVolume, err error,
+	result *ramendrv1alpha1.PVRestoreResult,
+) {
+	if !errors.IsAlreadyExists(err) {
+		v.log.Info("Failed to restore PV", "name", pv.Name, "Error", err)
+
+		result.Result = ramendrv1alpha1.PVRestoreResultError
+		result.Message = err.Error()
+
+		return
+	}
+
+	if err := v.validatePVExistence(pv); err != nil {
+		v.log.Info("PV exists. Ignoring and moving to next PV", "error", err.Error())
+
+		result.Result = ramendrv1alpha1.PVRestoreResultConflict
+		result.Message = err.Error()
+
+		return
+	}
+
+	// Valid PV exists and it is managed by Ramen
+	result.Result = ramendrv1alpha1.PVRestoreResultAlreadyExists
 }
 
 func (v *VRGInstance) updateExistingPVForSync(pv *corev1.PersistentVolume) error {
@@ -11,13 +11,17 @@ import (
 	volrepController "github.com/csi-addons/volume-replication-operator/controllers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
 	vrgController "github.com/ramendr/ramen/controllers"
+	"github.com/ramendr/ramen/controllers/volsync"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,7 +38,10 @@ func init() {
 	rand.Seed(time.Now().Unix())
 }
 
-var UploadedPVs = make(map[string]interface{})
+var (
+	UploadedPVs  = make(map[string]interface{})
+	UploadedPVCs = make(map[string]corev1.PersistentVolumeClaim)
+)
 
 var _ = Describe("Test VolumeReplicationGroup", func() {
 	// Test first restore
@@ -61,8 +68,209 @@ var _ = Describe("Test VolumeReplicationGroup", func() {
 			// waitForPVRestore(S3ProfileName, numPVs)
 			cleanupS3Store()
 		})
+		It("restores the PVCs uploaded along with the PVs, bound to the restored PVs", func() {
+			vtest := newVRGTestCaseCreate(0, restoreTestTemplate, true, false)
+			vrgNamespacedName := vtest.namespace + "/" + vtest.vrgName + "/"
+			pvList := generateFakePVs("pvcrestorepv", 1)
+			pvList[0].Spec.ClaimRef.Namespace = vtest.namespace
+			pvList[0].Spec.ClaimRef.Name = "restored-pvc"
+			storageClassName := "manual"
+			volumeMode := corev1.PersistentVolumeFilesystem
+			UploadedPVCs[s3Profiles[0].S3ProfileName+vrgNamespacedName+vtest.namespace+"/restored-pvc"] =
+				corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "restored-pvc",
+						Namespace:   vtest.namespace,
+						Labels:      map[string]string{"app": "restored"},
+						Annotations: map[string]string{"pv.kubernetes.io/bind-completed": "yes"},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+						},
+						StorageClassName: &storageClassName,
+						VolumeMode:       &volumeMode,
+						VolumeName:       pvList[0].Name,
+					},
+				}
+			populateS3Store(s3Profiles[0].S3ProfileName, vrgNamespacedName, pvList)
+			vtest.VRGTestCaseStart()
+			waitForPVRestore(pvList)
+			pvc := &corev1.PersistentVolumeClaim{}
+			Eventually(func() error {
+				return k8sClient.Get(context.TODO(),
+					types.NamespacedName{Namespace: vtest.namespace, Name: "restored-pvc"}, pvc)
+			}, timeout, interval).Should(Succeed())
+			Expect(pvc.Spec.VolumeName).To(Equal(pvList[0].Name))
+			Expect(pvc.Labels).To(HaveKeyWithValue("app", "restored"))
+			Expect(pvc.Annotations).NotTo(HaveKey("pv.kubernetes.io/bind-completed"))
+			clusterDataReady := func() *metav1.Condition {
+				return meta.FindStatusCondition(vtest.getVRG(vtest.vrgName).Status.Conditions,
+					vrgController.VRGConditionTypeClusterDataReady)
+			}
+			Consistently(func() metav1.ConditionStatus {
+				if condition := clusterDataReady(); condition != nil {
+					return condition.Status
+				}
+
+				return metav1.ConditionUnknown
+			}, interval*4, interval).ShouldNot(Equal(metav1.ConditionTrue))
+			pvc.Status.Phase = corev1.ClaimBound
+			Expect(k8sClient.Status().Update(context.TODO(), pvc)).To(Succeed())
+			Eventually(func() metav1.ConditionStatus {
+				if condition := clusterDataReady(); condition != nil {
+					return condition.Status
+				}
+
+				return metav1.ConditionUnknown
+			}, timeout, interval).Should(Equal(metav1.ConditionTrue))
+			cleanupS3Store()
+		})
+		It("restores only the most recently uploaded of PVs bound to the same claim when resolving to newest", func() {
+			vtest := newVRGTestCaseCreate(0, restoreTestTemplate, true, false)
+			vtest.pvRestore.ConflictResolution = ramendrv1alpha1.PVRestoreConflictResolutionNewest
+			vrgNamespacedName := vtest.namespace + "/" + vtest.vrgName + "/"
+			pvList := generateFakePVs("conflictpv", 2)
+			uploadTime := time.Now().UTC()
+			for idx := range pvList {
+				pvList[idx].Spec.ClaimRef.Name = "PVC_of_conflictpv"
+				pvList[idx].Annotations = map[string]string{
+					"volumereplicationgroups.ramendr.openshift.io/uploaded-at": uploadTime.Add(
+						time.Duration(idx) * time.Minute).Format(time.RFC3339Nano),
+				}
+			}
+			populateS3Store(s3Profiles[0].S3ProfileName, vrgNamespacedName, pvList)
+			vtest.VRGTestCaseStart()
+			waitForPVRestore(pvList[1:])
+			Eventually(func() []ramendrv1alpha1.PVRestoreResult {
+				return vtest.getVRG(vtest.vrgName).Status.PVRestoreResults
+			}, timeout, interval).Should(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"Name":   Equal(pvList[0].Name),
+					"Result": Equal(ramendrv1alpha1.PVRestoreResultConflict),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Name":   Equal(pvList[1].Name),
+					"Result": Equal(ramendrv1alpha1.PVRestoreResultRestored),
+				}),
+			))
+			Expect(errors.IsNotFound(k8sClient.Get(context.TODO(), types.NamespacedName{Name: pvList[0].Name},
+				&corev1.PersistentVolume{}))).To(BeTrue())
+			cleanupS3Store()
+		})
 	})
 
+	// Restores the PVs of the S3 store as warm standby PVs as secondary, refreshes
+	// them as the VRG generation changes, and deletes them once disabled
+	var vrgWarmStandbyTest *vrgTest
+	var standbyPVList []corev1.PersistentVolume
+	Context("warm standby", func() {
+		standbyTestTemplate := &template{
+			ClaimBindInfo:          corev1.ClaimBound,
+			VolumeBindInfo:         corev1.VolumeBound,
+			schedulingInterval:     "1h",
+			storageClassName:       "manual",
+			replicationClassName:   "test-replicationclass",
+			vrcProvisioner:         "manual.storage.com",
+			scProvisioner:          "manual.storage.com",
+			replicationClassLabels: map[string]string{"protection": "ramen"},
+		}
+		standbyCondition := func() *metav1.Condition {
+			v := vrgWarmStandbyTest
+
+			return meta.FindStatusCondition(v.getVRG(v.vrgName).Status.Conditions,
+				vrgController.VRGConditionTypeStandbyReady)
+		}
+		vrgS3Key := func(pv corev1.PersistentVolume) string {
+			v := vrgWarmStandbyTest
+
+			return s3Profiles[0].S3ProfileName + v.namespace + "/" + v.vrgName + "/" + pv.Name
+		}
+		It("restores the PVs of the S3 store as standby PVs of the secondary", func() {
+			vrgWarmStandbyTest = newVRGTestCaseCreate(0, standbyTestTemplate, true, false)
+			v := vrgWarmStandbyTest
+			v.replicationState = ramendrv1alpha1.Secondary
+			v.warmStandby = true
+			standbyPVList = generateFakePVs("standbypv-"+v.uniqueID+"-", 2)
+			for idx := range standbyPVList {
+				standbyPVList[idx].Spec.ClaimRef.Namespace = v.namespace
+			}
+			populateS3Store(s3Profiles[0].S3ProfileName, v.namespace+"/"+v.vrgName+"/", standbyPVList)
+			v.VRGTestCaseStart()
+			waitForPVRestore(standbyPVList)
+			for _, pv := range standbyPVList {
+				restoredPV := &corev1.PersistentVolume{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: pv.Name}, restoredPV)).To(Succeed())
+				Expect(restoredPV.Labels).To(HaveKeyWithValue(volsync.WarmStandbyLabel, v.vrgName))
+			}
+			Eventually(func() metav1.ConditionStatus {
+				if condition := standbyCondition(); condition != nil {
+					return condition.Status
+				}
+
+				return metav1.ConditionUnknown
+			}, vrgtimeout, vrginterval).Should(Equal(metav1.ConditionTrue))
+			status := v.getVRG(v.vrgName).Status.WarmStandby
+			Expect(status).NotTo(BeNil())
+			Expect(status.RestoredPVs).To(Equal(len(standbyPVList)))
+			Expect(status.LastPVRestoreTime).NotTo(BeNil())
+		})
+		It("deletes the unused standby PVs of PVCs no longer in the S3 store as the VRG generation changes", func() {
+			v := vrgWarmStandbyTest
+			for _, pv := range standbyPVList {
+				restoredPV := &corev1.PersistentVolume{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: pv.Name}, restoredPV)).To(Succeed())
+				restoredPV.Status.Phase = corev1.VolumeAvailable
+				Expect(k8sClient.Status().Update(context.TODO(), restoredPV)).To(Succeed())
+			}
+			delete(UploadedPVs, vrgS3Key(standbyPVList[0]))
+			Eventually(func() error {
+				vrg := v.getVRG(v.vrgName)
+				vrg.Spec.PVRestore.AllowPartial = true
+
+				return k8sClient.Update(context.TODO(), vrg)
+			}, vrgtimeout, vrginterval).Should(Succeed())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(context.TODO(),
+					types.NamespacedName{Name: standbyPVList[0].Name}, &corev1.PersistentVolume{}))
+			}, vrgtimeout, vrginterval).Should(BeTrue())
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: standbyPVList[1].Name},
+				&corev1.PersistentVolume{})).To(Succeed())
+			Eventually(func() int {
+				if status := v.getVRG(v.vrgName).Status.WarmStandby; status != nil {
+					return status.RestoredPVs
+				}
+
+				return 0
+			}, vrgtimeout, vrginterval).Should(Equal(1))
+		})
+		It("deletes the unused standby PVs as the warm standby is disabled", func() {
+			v := vrgWarmStandbyTest
+			Eventually(func() error {
+				vrg := v.getVRG(v.vrgName)
+				vrg.Spec.WarmStandby = false
+
+				return k8sClient.Update(context.TODO(), vrg)
+			}, vrgtimeout, vrginterval).Should(Succeed())
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(context.TODO(),
+					types.NamespacedName{Name: standbyPVList[1].Name}, &corev1.PersistentVolume{}))
+			}, vrgtimeout, vrginterval).Should(BeTrue())
+			Eventually(func() string {
+				if condition := standbyCondition(); condition != nil {
+					return condition.Reason
+				}
+
+				return ""
+			}, vrgtimeout, vrginterval).Should(Equal(vrgController.VRGConditionReasonStandbyDisabled))
+			Expect(v.getVRG(v.vrgName).Status.WarmStandby).To(BeNil())
+		})
+		It("cleans up after testing", func() {
+			vrgWarmStandbyTest.cleanup()
+			cleanupS3Store()
+		})
+	})
 	// Try the simple case of creating VRG, PVC, PV and
 	// check whether VolRep resources are created or not
 	var vrgTestCases []*vrgTest
@@ -383,23 +591,409 @@ var _ = Describe("Test VolumeReplicationGroup", func() {
 			v.cleanup()
 		})
 	})
+	var vrgSelectorTests []*vrgTest
+	Context("PVC selector with match expressions and an excluded PVC", func() {
+		It("sets up PVCs and PVs, excludes one PVC and creates the VRG", func() {
+			v := newVRGTestCaseCreate(3, vrgTestTemplate, true, false)
+			v.pvcSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "appclass", Operator: metav1.LabelSelectorOpIn, Values: []string{"platinum", "gold"}},
+					{Key: "environment", Operator: metav1.LabelSelectorOpIn, Values: []string{v.pvcLabels["environment"]}},
+				},
+			}
+			v.createNamespace()
+			v.createSC(v.template)
+			v.createVRC(v.template)
+			v.createPVCandPV(corev1.ClaimBound, corev1.VolumeBound)
+			pvc := v.getPVC(v.pvcNames[0])
+			pvc.Annotations = map[string]string{vrgController.PVCExcludeAnnotation: "true"}
+			Expect(k8sClient.Update(context.TODO(), pvc)).To(Succeed())
+			v.createVRG()
+			vrgSelectorTests = append(vrgSelectorTests, v)
+		})
+		It("waits for VRG to create a VR for each PVC that is not excluded", func() {
+			v := vrgSelectorTests[0]
+			v.waitForVRCountToMatch(len(v.pvcNames) - 1)
+		})
+		It("reports the excluded PVC in VRG status", func() {
+			v := vrgSelectorTests[0]
+			Eventually(func() []string {
+				return v.getVRG(v.vrgName).Status.ExcludedPVCs
+			}, timeout, interval).Should(Equal([]string{v.namespace + "/" + v.pvcNames[0]}))
+		})
+		It("cleans up after testing", func() {
+			v := vrgSelectorTests[0]
+			v.cleanup()
+		})
+	})
+	var vrgMultiNamespaceTests []*vrgTest
+	Context("PVCs in an additional protected namespace", func() {
+		It("sets up PVCs and PVs in two namespaces, with a PVC name used in both, and a VRG protecting both", func() {
+			other := newVRGTestCaseCreate(2, vrgTestTemplate, true, false)
+			other.createNamespace()
+			other.createSC(other.template)
+			other.createVRC(other.template)
+			other.createPVCandPV(corev1.ClaimBound, corev1.VolumeBound)
+
+			v := newVRGTestCaseCreate(1, vrgTestTemplate, true, false)
+			v.pvcLabels = other.pvcLabels
+			v.protectedNamespaces = []string{other.namespace}
+			v.createNamespace()
+			v.createPVCandPV(corev1.ClaimBound, corev1.VolumeBound)
+			sharedPVName := fmt.Sprintf("pv-%v-shared", v.uniqueID)
+			v.createPV(sharedPVName, other.pvcNames[0], corev1.VolumeBound)
+			v.createPVC(other.pvcNames[0], v.namespace, sharedPVName, v.pvcLabels, corev1.ClaimBound)
+			v.pvNames = append(v.pvNames, sharedPVName)
+			v.pvcNames = append(v.pvcNames, other.pvcNames[0])
+			v.createVRG()
+			vrgMultiNamespaceTests = append(vrgMultiNamespaceTests, v, other)
+		})
+		It("waits for VRG to create a VR for each PVC in each namespace", func() {
+			for _, v := range vrgMultiNamespaceTests {
+				v.waitForVRCountToMatch(len(v.pvcNames))
+			}
+		})
+		It("reports each protected namespace in VRG status", func() {
+			v, other := vrgMultiNamespaceTests[0], vrgMultiNamespaceTests[1]
+			Eventually(func() map[string]int {
+				protectedNamespaces := map[string]int{}
+				for _, protectedNamespace := range v.getVRG(v.vrgName).Status.ProtectedNamespaces {
+					protectedNamespaces[protectedNamespace.Name] = len(protectedNamespace.ProtectedPVCs)
+				}
+
+				return protectedNamespaces
+			}, timeout, interval).Should(Equal(map[string]int{v.namespace: 2, other.namespace: 2}))
+		})
+		It("reports a PVC name used in both namespaces once for each namespace", func() {
+			v, other := vrgMultiNamespaceTests[0], vrgMultiNamespaceTests[1]
+			Eventually(func() []string {
+				namespaces := []string{}
+				for _, protectedPVC := range v.getVRG(v.vrgName).Status.ProtectedPVCs {
+					if protectedPVC.Name == other.pvcNames[0] {
+						namespaces = append(namespaces, protectedPVC.Namespace)
+					}
+				}
+
+				return namespaces
+			}, timeout, interval).Should(ConsistOf("", other.namespace))
+		})
+		It("cleans up after testing", func() {
+			v, other := vrgMultiNamespaceTests[0], vrgMultiNamespaceTests[1]
+			v.cleanup()
+			other.cleanupPVCs()
+			other.waitForVRCountToMatch(0)
+			other.cleanupNamespace()
+		})
+	})
+	var vrgNamespaceSelectorTests []*vrgTest
+	Context("PVCs in a namespace labeled to match the VRG namespace selector", func() {
+		It("sets up PVCs and PVs in an unlabeled namespace, and a VRG selecting labeled namespaces", func() {
+			other := newVRGTestCaseCreate(2, vrgTestTemplate, true, false)
+			other.createNamespace()
+			other.createSC(other.template)
+			other.createVRC(other.template)
+			other.createPVCandPV(corev1.ClaimBound, corev1.VolumeBound)
+
+			v := newVRGTestCaseCreate(0, vrgTestTemplate, true, false)
+			v.pvcLabels = other.pvcLabels
+			v.namespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"protect": v.uniqueID}}
+			v.createNamespace()
+			v.createVRG()
+			vrgNamespaceSelectorTests = append(vrgNamespaceSelectorTests, v, other)
+		})
+		It("does not protect the PVCs of the unlabeled namespace", func() {
+			other := vrgNamespaceSelectorTests[1]
+			Consistently(func() int {
+				volRepList := &volrep.VolumeReplicationList{}
+				Expect(k8sClient.List(context.TODO(), volRepList, client.InNamespace(other.namespace))).To(Succeed())
+
+				return len(volRepList.Items)
+			}, 2*time.Second, interval).Should(BeZero())
+		})
+		It("protects the PVCs of the namespace once labeled", func() {
+			v, other := vrgNamespaceSelectorTests[0], vrgNamespaceSelectorTests[1]
+			namespace := &corev1.Namespace{}
+			Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: other.namespace}, namespace)).To(Succeed())
+			namespace.Labels = map[string]string{"protect": v.uniqueID}
+			Expect(k8sClient.Update(context.TODO(), namespace)).To(Succeed())
+			other.waitForVRCountToMatch(len(other.pvcNames))
+		})
+		It("cleans up after testing", func() {
+			v, other := vrgNamespaceSelectorTests[0], vrgNamespaceSelectorTests[1]
+			v.cleanup()
+			other.cleanupPVCs()
+			other.waitForVRCountToMatch(0)
+			other.cleanupNamespace()
+		})
+	})
+	// Reports the replication progress of each PVC, from its VolumeReplication,
+	// and the oldest last sync time of the PVCs as the VRG last group sync time
+	var vrgSyncStatusTest *vrgTest
+	Context("replication sync status", func() {
+		createTestTemplate := &template{
+			ClaimBindInfo:          corev1.ClaimBound,
+			VolumeBindInfo:         corev1.VolumeBound,
+			schedulingInterval:     "1h",
+			storageClassName:       "manual",
+			replicationClassName:   "test-replicationclass",
+			vrcProvisioner:         "manual.storage.com",
+			scProvisioner:          "manual.storage.com",
+			replicationClassLabels: map[string]string{"protection": "ramen"},
+		}
+		completionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		It("sets up PVCs, PVs and a VRG, and promotes the VRs", func() {
+			vrgSyncStatusTest = newVRGTestCaseCreateAndStart(2, createTestTemplate, true, false)
+			vrgSyncStatusTest.waitForVRCountToMatch(2)
+			vrgSyncStatusTest.promoteVolReps()
+		})
+		It("reports the PVCs in sync, and the oldest last sync time as the last group sync time", func() {
+			v := vrgSyncStatusTest
+			for index, pvcName := range v.pvcNames {
+				v.setVolRepSyncTimes(pvcName, completionTime.Add(time.Duration(index)*time.Minute))
+			}
+			Eventually(func() bool {
+				lastGroupSyncTime := v.getVRG(v.vrgName).Status.LastGroupSyncTime
+
+				return lastGroupSyncTime != nil && lastGroupSyncTime.Equal(&completionTime)
+			}, vrgtimeout, vrginterval).Should(BeTrue())
+			for _, protectedPVC := range v.getVRG(v.vrgName).Status.ProtectedPVCs {
+				Expect(protectedPVC.SyncStatus).NotTo(BeNil())
+				Expect(protectedPVC.SyncStatus.State).To(Equal(ramendrv1alpha1.PVCSyncStateInSync))
+				Expect(protectedPVC.SyncStatus.LastSyncDuration).To(Equal(&metav1.Duration{Duration: time.Minute}))
+			}
+		})
+		It("uploads each PVC, and uploads it again as it changes", func() {
+			v := vrgSyncStatusTest
+			pvcName := v.pvcNames[0]
+			Eventually(func() map[string]string {
+				return v.uploadedPVCLabels(pvcName)
+			}, vrgtimeout, vrginterval).ShouldNot(BeNil())
+			pvc := v.getPVC(pvcName)
+			pvc.Labels["uploaded"] = "again"
+			Expect(k8sClient.Update(context.TODO(), pvc)).To(Succeed())
+			Eventually(func() map[string]string {
+				return v.uploadedPVCLabels(pvcName)
+			}, vrgtimeout, vrginterval).Should(HaveKeyWithValue("uploaded", "again"))
+		})
+		It("annotates the VRs as suspended while suspended, and keeps uploading the PVCs", func() {
+			v := vrgSyncStatusTest
+			v.setVRGSuspended(true)
+			for _, pvcName := range v.pvcNames {
+				Eventually(func() map[string]string {
+					return v.getVolRep(pvcName).GetAnnotations()
+				}, vrgtimeout, vrginterval).Should(HaveKeyWithValue(vrgController.VolRepSuspendedAnnotation, "true"))
+			}
+			Eventually(func() string {
+				condition := meta.FindStatusCondition(v.getVRG(v.vrgName).Status.Conditions,
+					vrgController.VRGConditionTypeSuspended)
+				if condition == nil || condition.Status != metav1.ConditionFalse {
+					return ""
+				}
+
+				return condition.Reason
+			}, vrgtimeout, vrginterval).Should(Equal(vrgController.VRGConditionReasonSuspendNotSupported))
+			pvcName := v.pvcNames[1]
+			pvc := v.getPVC(pvcName)
+			pvc.Labels["uploaded"] = "suspended"
+			Expect(k8sClient.Update(context.TODO(), pvc)).To(Succeed())
+			Eventually(func() map[string]string {
+				return v.uploadedPVCLabels(pvcName)
+			}, vrgtimeout, vrginterval).Should(HaveKeyWithValue("uploaded", "suspended"))
+			v.setVRGSuspended(false)
+			for _, pvcName := range v.pvcNames {
+				Eventually(func() map[string]string {
+					return v.getVolRep(pvcName).GetAnnotations()
+				}, vrgtimeout, vrginterval).ShouldNot(HaveKey(vrgController.VolRepSuspendedAnnotation))
+			}
+		})
+		It("cleans up after testing", func() {
+			vrgSyncStatusTest.cleanup()
+		})
+	})
+	var vrgVolumeGroupTest *vrgTest
+	Context("volume group replication", func() {
+		createTestTemplate := &template{
+			ClaimBindInfo:          corev1.ClaimBound,
+			VolumeBindInfo:         corev1.VolumeBound,
+			schedulingInterval:     "1h",
+			storageClassName:       "manual",
+			replicationClassName:   "test-replicationclass",
+			vrcProvisioner:         "manual.storage.com",
+			scProvisioner:          "manual.storage.com",
+			replicationClassLabels: map[string]string{"protection": "ramen"},
+		}
+		It("sets up PVCs, PVs, a VolumeGroupReplicationClass and a VRG replicating its PVCs as a group", func() {
+			v := newVRGTestCaseCreate(2, createTestTemplate, true, false)
+			v.volumeGroupReplication = true
+			v.replicationClassSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "protection",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"ramen"},
+				}},
+			}
+			v.createVGRC("test-volumegroupreplicationclass")
+			v.VRGTestCaseStart()
+			vrgVolumeGroupTest = v
+		})
+		It("creates a VolumeGroupReplication selecting the PVCs instead of a VR per PVC", func() {
+			v := vrgVolumeGroupTest
+			vgr := v.waitForVGR()
+			groupName := vgr.GetName()
+			selector, _, _ := unstructured.NestedStringMap(vgr.Object, "spec", "source", "selector", "matchLabels")
+			Expect(selector).To(Equal(map[string]string{vrgVolumeGroupLabel: groupName}))
+			className, _, _ := unstructured.NestedString(vgr.Object, "spec", "volumeGroupReplicationClassName")
+			Expect(className).To(Equal("test-volumegroupreplicationclass"))
+			for _, pvcName := range v.pvcNames {
+				pvcName := pvcName
+				Eventually(func() string {
+					return v.getPVC(pvcName).GetLabels()[vrgVolumeGroupLabel]
+				}, vrgtimeout, vrginterval).Should(Equal(groupName))
+			}
+			v.waitForVRCountToMatch(0)
+		})
+		It("reports the group status in VRG status as the VolumeGroupReplication status changes", func() {
+			v := vrgVolumeGroupTest
+			v.setVGRStatus(volrep.PrimaryState)
+			Eventually(func() []ramendrv1alpha1.ProtectedVolumeGroup {
+				return v.getVRG(v.vrgName).Status.ProtectedVolumeGroups
+			}, vrgtimeout, vrginterval).Should(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name":        Equal(v.waitForVGR().GetName()),
+				"Provisioner": Equal(createTestTemplate.scProvisioner),
+				"PVCs":        Equal(v.pvcNames),
+				"State":       Equal(string(volrep.PrimaryState)),
+			})))
+		})
+		It("demotes the group before removing its members as the VRG is deleted", func() {
+			v := vrgVolumeGroupTest
+			Expect(k8sClient.Delete(context.TODO(), v.getVRG(v.vrgName))).To(Succeed())
+			Eventually(func() string {
+				state, _, _ := unstructured.NestedString(v.waitForVGR().Object, "spec", "replicationState")
+
+				return state
+			}, vrgtimeout, vrginterval).Should(Equal(string(volrep.Secondary)))
+			for _, pvcName := range v.pvcNames {
+				Expect(v.getPVC(pvcName).GetLabels()).To(HaveKey(vrgVolumeGroupLabel))
+			}
+			groupName := v.waitForVGR().GetName()
+			v.setVGRStatus(volrep.SecondaryState)
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(context.TODO(),
+					types.NamespacedName{Name: groupName, Namespace: v.namespace}, newVGR()))
+			}, vrgtimeout, vrginterval).Should(BeTrue())
+			for _, pvcName := range v.pvcNames {
+				Expect(v.getPVC(pvcName).GetLabels()).NotTo(HaveKey(vrgVolumeGroupLabel))
+			}
+		})
+		It("cleans up after testing", func() {
+			v := vrgVolumeGroupTest
+			vgrc := &unstructured.Unstructured{}
+			vgrc.SetGroupVersionKind(volrep.GroupVersion.WithKind("VolumeGroupReplicationClass"))
+			vgrc.SetName("test-volumegroupreplicationclass")
+			Expect(k8sClient.Delete(context.TODO(), vgrc)).To(Succeed())
+			v.cleanupPVCs()
+			v.cleanupNamespace()
+			v.cleanupSC()
+			v.cleanupVRC()
+		})
+	})
 	// TODO: Add tests to move VRG to Secondary
 	// TODO: Add tests to ensure delete as Secondary (check if delete as Primary is tested above)
 })
 
+// vrgVolumeGroupLabel labels the PVCs replicated by a VolumeGroupReplication
+const vrgVolumeGroupLabel = "volumereplicationgroups.ramendr.openshift.io/volume-group"
+
+func newVGR() *unstructured.Unstructured {
+	vgr := &unstructured.Unstructured{}
+	vgr.SetGroupVersionKind(volrep.GroupVersion.WithKind("VolumeGroupReplication"))
+
+	return vgr
+}
+
+func (v *vrgTest) createVGRC(name string) {
+	By("creating VolumeGroupReplicationClass " + name)
+
+	vgrc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"provisioner": v.template.scProvisioner,
+			"parameters": map[string]interface{}{
+				"schedulingInterval": v.template.schedulingInterval,
+			},
+		},
+	}}
+	vgrc.SetGroupVersionKind(volrep.GroupVersion.WithKind("VolumeGroupReplicationClass"))
+	vgrc.SetName(name)
+	vgrc.SetLabels(v.template.replicationClassLabels)
+
+	Expect(k8sClient.Create(context.TODO(), vgrc)).To(Succeed())
+}
+
+// waitForVGR waits for the VolumeGroupReplication of the VRG PVCs to exist and
+// returns it
+func (v *vrgTest) waitForVGR() *unstructured.Unstructured {
+	vgrs := &unstructured.UnstructuredList{}
+	vgrs.SetGroupVersionKind(volrep.GroupVersion.WithKind("VolumeGroupReplicationList"))
+
+	Eventually(func() int {
+		Expect(k8sClient.List(context.TODO(), vgrs, client.InNamespace(v.namespace))).To(Succeed())
+
+		return len(vgrs.Items)
+	}, vrgtimeout, vrginterval).Should(Equal(1))
+
+	return &vgrs.Items[0]
+}
+
+// setVGRStatus sets the VolumeGroupReplication of the VRG PVCs to report that
+// it completed its transition to the given state
+func (v *vrgTest) setVGRStatus(state volrep.State) {
+	vgr := v.waitForVGR()
+	conditions := []interface{}{}
+
+	for _, condition := range []metav1.Condition{
+		{Type: volrepController.ConditionCompleted, Reason: volrepController.Promoted, Status: metav1.ConditionTrue},
+		{Type: volrepController.ConditionDegraded, Reason: volrepController.Healthy, Status: metav1.ConditionFalse},
+		{Type: volrepController.ConditionResyncing, Reason: volrepController.NotResyncing, Status: metav1.ConditionFalse},
+	} {
+		conditions = append(conditions, map[string]interface{}{
+			"type":               condition.Type,
+			"reason":             condition.Reason,
+			"status":             string(condition.Status),
+			"message":            "",
+			"observedGeneration": vgr.GetGeneration(),
+			"lastTransitionTime": time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+
+	Expect(unstructured.SetNestedField(vgr.Object, map[string]interface{}{
+		"state":              string(state),
+		"observedGeneration": vgr.GetGeneration(),
+		"conditions":         conditions,
+	}, "status")).To(Succeed())
+	Expect(k8sClient.Status().Update(context.TODO(), vgr)).To(Succeed())
+}
+
 type vrgTest struct {
-	uniqueID         string
-	namespace        string
-	pvNames          []string
-	pvcNames         []string
-	vrgName          string
-	storageClass     string
-	replicationClass string
-	pvcLabels        map[string]string
-	pvcCount         int
-	checkBind        bool
-	vrgFirst         bool
-	template         *template
+	uniqueID                 string
+	namespace                string
+	pvNames                  []string
+	pvcNames                 []string
+	vrgName                  string
+	storageClass             string
+	replicationClass         string
+	pvcLabels                map[string]string
+	pvcSelector              *metav1.LabelSelector
+	replicationClassSelector *metav1.LabelSelector
+	volumeGroupReplication   bool
+	protectedNamespaces      []string
+	namespaceSelector        *metav1.LabelSelector
+	pvRestore                ramendrv1alpha1.PVRestorePolicy
+	replicationState         ramendrv1alpha1.ReplicationState
+	warmStandby              bool
+	pvcCount                 int
+	checkBind                bool
+	vrgFirst                 bool
+	template                 *template
 }
 
 type template struct {
@@ -510,6 +1104,7 @@ func (v *vrgTest) createPVCandPV(claimBindInfo corev1.PersistentVolumeClaimPhase
 
 func cleanupS3Store() {
 	UploadedPVs = make(map[string]interface{})
+	UploadedPVCs = make(map[string]corev1.PersistentVolumeClaim)
 }
 
 func generateFakePVs(pvNamePrefix string, count int) []corev1.PersistentVolume {
@@ -727,18 +1322,36 @@ func (v *vrgTest) createVRG() {
 	schedulingInterval := "1h"
 	replicationClassLabels := map[string]string{"protection": "ramen"}
 
+	pvcSelector := metav1.LabelSelector{MatchLabels: v.pvcLabels}
+	if v.pvcSelector != nil {
+		pvcSelector = *v.pvcSelector
+	}
+
+	replicationClassSelector := metav1.LabelSelector{MatchLabels: replicationClassLabels}
+	if v.replicationClassSelector != nil {
+		replicationClassSelector = *v.replicationClassSelector
+	}
+
+	replicationState := ramendrv1alpha1.Primary
+	if v.replicationState != "" {
+		replicationState = v.replicationState
+	}
+
 	vrg := &ramendrv1alpha1.VolumeReplicationGroup{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      v.vrgName,
 			Namespace: v.namespace,
 		},
 		Spec: ramendrv1alpha1.VolumeReplicationGroupSpec{
-			PVCSelector:      metav1.LabelSelector{MatchLabels: v.pvcLabels},
-			ReplicationState: "primary",
+			PVCSelector:                pvcSelector,
+			ProtectedNamespaces:        v.protectedNamespaces,
+			ProtectedNamespaceSelector: v.namespaceSelector,
+			ReplicationState:           replicationState,
 			Async: ramendrv1alpha1.VRGAsyncSpec{
 				Mode:                     ramendrv1alpha1.AsyncModeEnabled,
 				SchedulingInterval:       schedulingInterval,
-				ReplicationClassSelector: metav1.LabelSelector{MatchLabels: replicationClassLabels},
+				ReplicationClassSelector: replicationClassSelector,
+				VolumeGroupReplication:   v.volumeGroupReplication,
 			},
 			Sync: ramendrv1alpha1.VRGSyncSpec{
 				Mode: ramendrv1alpha1.SyncModeDisabled,
@@ -746,7 +1359,9 @@ func (v *vrgTest) createVRG() {
 			VolSync: ramendrv1alpha1.VolSyncSpec{
 				Disabled: true,
 			},
-			S3Profiles: []string{s3Profiles[0].S3ProfileName},
+			S3Profiles:  []string{s3Profiles[0].S3ProfileName},
+			PVRestore:   v.pvRestore,
+			WarmStandby: v.warmStandby,
 		},
 	}
 	err := k8sClient.Create(context.TODO(), vrg)
@@ -845,6 +1460,18 @@ func (v *vrgTest) getPV(pvName string) *corev1.PersistentVolume {
 	return pv
 }
 
+// uploadedPVCLabels returns the labels of the named PVC as uploaded to the S3
+// store, or nil if it is not uploaded
+func (v *vrgTest) uploadedPVCLabels(pvcName string) map[string]string {
+	for key, pvc := range UploadedPVCs {
+		if strings.HasSuffix(key, "/"+v.vrgName+"/"+v.namespace+"/"+pvcName) {
+			return pvc.Labels
+		}
+	}
+
+	return nil
+}
+
 func (v *vrgTest) getPVC(pvcName string) *corev1.PersistentVolumeClaim {
 	key := types.NamespacedName{
 		Namespace: v.namespace,
@@ -1088,6 +1715,39 @@ func (v *vrgTest) promoteVolReps() {
 	}
 }
 
+// setVolRepSyncTimes sets the VolumeReplication of a PVC to report that its
+// last sync took a minute and completed at the given time
+func (v *vrgTest) setVolRepSyncTimes(pvcName string, completionTime time.Time) {
+	volRep := &volrep.VolumeReplication{}
+	Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: v.namespace},
+		volRep)).To(Succeed())
+
+	startTime := metav1.NewTime(completionTime.Add(-time.Minute))
+	lastCompletionTime := metav1.NewTime(completionTime)
+	volRep.Status.LastStartTime = &startTime
+	volRep.Status.LastCompletionTime = &lastCompletionTime
+
+	Expect(k8sClient.Status().Update(context.TODO(), volRep)).To(Succeed(),
+		"failed to update the status of VolRep %s", volRep.Name)
+}
+
+func (v *vrgTest) getVolRep(pvcName string) *volrep.VolumeReplication {
+	volRep := &volrep.VolumeReplication{}
+	Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Name: pvcName, Namespace: v.namespace},
+		volRep)).To(Succeed())
+
+	return volRep
+}
+
+func (v *vrgTest) setVRGSuspended(suspended bool) {
+	Eventually(func() error {
+		vrg := v.getVRG(v.vrgName)
+		vrg.Spec.Suspended = suspended
+
+		return k8sClient.Update(context.TODO(), vrg)
+	}, vrgtimeout, vrginterval).Should(Succeed())
+}
+
 func (v *vrgTest) waitForVolRepPromotion(vrNamespacedName types.NamespacedName, vrgready bool) {
 	updatedVolRep := volrep.VolumeReplication{}
 
@@ -1204,6 +1864,20 @@ func (s FakePVDownloader) DownloadPVs(objStore vrgController.ObjectStorer, keyPr
 	return pvList, nil
 }
 
+func (s FakePVDownloader) DownloadPVCs(objStore vrgController.ObjectStorer, keyPrefix string) (
+	[]corev1.PersistentVolumeClaim, error) {
+	pvcList := []corev1.PersistentVolumeClaim{}
+	fullPrefix := objStore.GetName() + keyPrefix
+
+	for k, v := range UploadedPVCs {
+		if strings.HasPrefix(k, fullPrefix) {
+			pvcList = append(pvcList, v)
+		}
+	}
+
+	return pvcList, nil
+}
+
 type FakePVUploader struct{}
 
 func (s FakePVUploader) UploadPV(objectStore vrgController.ObjectStorer,
@@ -1214,6 +1888,14 @@ func (s FakePVUploader) UploadPV(objectStore vrgController.ObjectStorer,
 	return nil
 }
 
+func (s FakePVUploader) UploadPVC(objectStore vrgController.ObjectStorer,
+	pvcKeyPrefix string, pvc *corev1.PersistentVolumeClaim) error {
+	key := objectStore.GetName() + pvcKeyPrefix + pvc.Namespace + "/" + pvc.Name
+	UploadedPVCs[key] = *pvc
+
+	return nil
+}
+
 type FakePVDeleter struct{}
 
 func (s FakePVDeleter) DeletePVs(v interface{}, s3ProfileName string) error {
@@ -1221,5 +1903,9 @@ func (s FakePVDeleter) DeletePVs(v interface{}, s3ProfileName string) error {
 		delete(UploadedPVs, key)
 	}
 
+	for key := range UploadedPVCs {
+		delete(UploadedPVCs, key)
+	}
+
 	return nil
 }
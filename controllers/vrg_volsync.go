@@ -19,8 +19,14 @@ package controllers
 import (
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/go-logr/logr"
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/volsync"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -33,14 +39,19 @@ func (v *VRGInstance) restorePVsForVolSync() error {
 		return nil
 	}
 
+	// A failover to a point in time restores the PVCs from their recovery points
+	v.volSyncHandler.SetRecoveryPointTime(v.instance.Spec.VolSync.RecoveryPointTime)
+
 	numPVsRestored := 0
 
-	for _, rdSpec := range v.instance.Spec.VolSync.RDSpec {
+	for _, peerRDSpec := range v.instance.Spec.VolSync.RDSpec {
+		rdSpec := v.mapRDSpecStorageClass(peerRDSpec)
+
 		err := v.volSyncHandler.EnsurePVCfromRD(rdSpec)
 		if err != nil {
 			v.log.Info(fmt.Sprintf("Unable to ensure PVC %v -- err: %v", rdSpec, err))
 
-			protectedPVC := v.findProtectedPVC(rdSpec.ProtectedPVC.Name)
+			protectedPVC := v.findProtectedPVC(rdSpec.ProtectedPVC.Namespace, rdSpec.ProtectedPVC.Name)
 			if protectedPVC == nil {
 				protectedPVC = &ramendrv1alpha1.ProtectedPVC{}
 				rdSpec.ProtectedPVC.DeepCopyInto(protectedPVC)
@@ -55,7 +66,7 @@ func (v *VRGInstance) restorePVsForVolSync() error {
 
 		numPVsRestored++
 
-		protectedPVC := v.findProtectedPVC(rdSpec.ProtectedPVC.Name)
+		protectedPVC := v.findProtectedPVC(rdSpec.ProtectedPVC.Namespace, rdSpec.ProtectedPVC.Name)
 		if protectedPVC == nil {
 			protectedPVC = &ramendrv1alpha1.ProtectedPVC{}
 			rdSpec.ProtectedPVC.DeepCopyInto(protectedPVC)
@@ -74,7 +85,6 @@ func (v *VRGInstance) restorePVsForVolSync() error {
 	return nil
 }
 
-//nolint:funlen,gocognit,cyclop
 func (v *VRGInstance) reconcileVolSyncAsPrimary() (requeue bool) {
 	v.log.Info(fmt.Sprintf("Reconciling VolSync as Primary. VolSyncPVCs %d. VolSyncSpec %+v",
 		len(v.volSyncPVCs), v.instance.Spec.VolSync))
@@ -92,63 +102,42 @@ func (v *VRGInstance) reconcileVolSyncAsPrimary() (requeue bool) {
 		return
 	}
 
-	finalSyncPrepareCount := 0
+	if err := v.volSyncHandler.CleanupRecoveryPointsNotInSpecList(v.instance.Spec.VolSync.RDSpec); err != nil {
+		v.log.Error(err, "Failed to cleanup the recovery points retained when this VRG instance was secondary")
 
-	// First time: Add all VolSync PVCs to the protected PVC list and set their ready condition to initializing
-	for _, pvc := range v.volSyncPVCs {
-		newProtectedPVC := &ramendrv1alpha1.ProtectedPVC{
-			Name:               pvc.Name,
-			ProtectedByVolSync: true,
-			StorageClassName:   pvc.Spec.StorageClassName,
-			Labels:             pvc.Labels,
-			AccessModes:        pvc.Spec.AccessModes,
-			Resources:          pvc.Spec.Resources,
-		}
+		requeue = true
 
-		protectedPVC := v.findProtectedPVC(pvc.Name)
-		if protectedPVC == nil {
-			protectedPVC = newProtectedPVC
-			v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, *protectedPVC)
-		} else if !reflect.DeepEqual(protectedPVC, newProtectedPVC) {
-			newProtectedPVC.DeepCopyInto(protectedPVC)
-		}
+		return
+	}
 
-		// Not much need for VolSyncReplicationSourceSpec anymore - but keeping it around in case we want
-		// to add anything to it later to control anything in the ReplicationSource
-		rsSpec := ramendrv1alpha1.VolSyncReplicationSourceSpec{
-			ProtectedPVC: *protectedPVC,
-		}
+	if err := v.setVolSyncMover(); err != nil {
+		v.log.Error(err, "Failed to set up the VolSync mover")
 
-		if v.instance.Spec.PrepareForFinalSync {
-			prepared, err := v.volSyncHandler.PreparePVCForFinalSync(pvc.Name)
-			if err != nil || !prepared {
-				requeue = true
+		requeue = true
 
-				continue
-			}
+		return
+	}
 
-			finalSyncPrepareCount++
-		}
+	// Remove any ReplicationSources to destinations that the PVCs are no longer replicated to
+	if err := v.volSyncHandler.CleanupRSNotInDestinations(); err != nil {
+		v.log.Error(err, "Failed to cleanup the ReplicationSources to destinations no longer replicated to")
 
-		// reconcile RS and if runFinalSync is true, then one final sync will be run
-		finalSyncComplete, rs, err := v.volSyncHandler.ReconcileRS(rsSpec, v.instance.Spec.RunFinalSync)
-		if err != nil { //nolint:gocritic
-			v.log.Info(fmt.Sprintf("Failed to reconcile VolSync Replication Source for rsSpec %v. Error %v",
-				rsSpec, err))
+		requeue = true
 
-			setVRGConditionTypeVolSyncRepSourceSetupError(&protectedPVC.Conditions, v.instance.Generation,
-				"VolSync setup failed")
+		return
+	}
 
-			requeue = true
-		} else if rs == nil {
-			requeue = true
-		} else {
-			setVRGConditionTypeVolSyncRepSourceSetupComplete(&protectedPVC.Conditions, v.instance.Generation, "Ready")
-		}
+	v.pruneVolSyncMetrics()
 
-		if v.instance.Spec.RunFinalSync && !finalSyncComplete {
-			requeue = true
+	// First time: Add all VolSync PVCs to the protected PVC list and set their ready condition to initializing
+	for idx, err := range v.forEachPVC(v.volSyncPVCs, reconcileVolSyncAsPrimary) {
+		if err == nil {
+			continue
 		}
+
+		v.log.Info(fmt.Sprintf("Failed to reconcile VolSync PVC %s. Error %v", v.volSyncPVCs[idx].Name, err))
+
+		requeue = true
 	}
 
 	if requeue {
@@ -157,6 +146,15 @@ func (v *VRGInstance) reconcileVolSyncAsPrimary() (requeue bool) {
 		return requeue
 	}
 
+	if err := v.volSyncHandler.DeleteStaleSSHSecrets(); err != nil {
+		v.log.Error(err, "Failed to delete the VolSync ssh secrets of previous rotations")
+
+		return true
+	}
+
+	// All ReplicationSources use the ssh secret now
+	v.instance.Status.VolSyncSSHSecretName = v.volSyncHandler.GetSSHSecretName()
+
 	if v.instance.Spec.PrepareForFinalSync {
 		v.instance.Status.PrepareForFinalSyncComplete = true
 	}
@@ -170,6 +168,168 @@ func (v *VRGInstance) reconcileVolSyncAsPrimary() (requeue bool) {
 	return requeue
 }
 
+// reconcileVolSyncAsPrimary adds the pvc to the protected PVC list and
+// reconciles its ReplicationSource. Returns an error to requeue.
+func reconcileVolSyncAsPrimary(v *VRGInstance, pvc *corev1.PersistentVolumeClaim, log logr.Logger) error {
+	storageProvisioner, err := v.storageClassProvisioner(pvc.Spec.StorageClassName)
+	if err != nil {
+		return err
+	}
+
+	newProtectedPVC := &ramendrv1alpha1.ProtectedPVC{
+		Name:               pvc.Name,
+		ProtectedByVolSync: true,
+		StorageClassName:   pvc.Spec.StorageClassName,
+		StorageProvisioner: storageProvisioner,
+		Labels:             pvc.Labels,
+		AccessModes:        pvc.Spec.AccessModes,
+		Resources:          pvc.Spec.Resources,
+		VolumeMode:         pvc.Spec.VolumeMode,
+		Annotations:        protectedPVCAnnotations(pvc.Annotations),
+	}
+
+	protectedPVC := v.findProtectedPVC(pvc.Namespace, pvc.Name)
+	if protectedPVC == nil {
+		v.instance.Status.ProtectedPVCs = append(v.instance.Status.ProtectedPVCs, *newProtectedPVC)
+		protectedPVC = &v.instance.Status.ProtectedPVCs[len(v.instance.Status.ProtectedPVCs)-1]
+	} else if !reflect.DeepEqual(protectedPVC, newProtectedPVC) {
+		newProtectedPVC.DeepCopyInto(protectedPVC)
+	}
+
+	if v.instance.Spec.PrepareForFinalSync {
+		prepared, err := v.volSyncHandler.PreparePVCForFinalSync(pvc.Name)
+		if err != nil {
+			return err
+		}
+
+		if !prepared {
+			return fmt.Errorf("pvc %s not prepared for final sync yet", pvc.Name)
+		}
+	}
+
+	v.updatePVCCopyMethod(protectedPVC, pvc.Spec.StorageClassName, pvc.Spec.AccessModes)
+
+	// Replicate to each of the destinations, even if the replication to another one fails
+	var (
+		rss               []*volsyncv1alpha1.ReplicationSource
+		reconcileErr      error
+		finalSyncComplete = true
+	)
+
+	for _, destination := range v.volSyncHandler.Destinations() {
+		rs, rsFinalSyncComplete, err := v.reconcileVolSyncRS(protectedPVC, destination, log)
+		if err != nil {
+			if reconcileErr == nil {
+				reconcileErr = err
+			}
+
+			continue
+		}
+
+		rss = append(rss, rs)
+		finalSyncComplete = finalSyncComplete && rsFinalSyncComplete
+	}
+
+	rsNames := make([]string, 0, len(rss))
+	for _, rs := range rss {
+		rsNames = append(rsNames, rs.GetName())
+	}
+
+	v.updatePVCMoverScheduled(protectedPVC, rsNames, v.volSyncHandler.GetRSMoverSchedulingFailure)
+
+	if reconcileErr != nil {
+		return reconcileErr
+	}
+
+	setVRGConditionTypeVolSyncRepSourceSetupComplete(&protectedPVC.Conditions, v.instance.Generation, "Ready")
+	protectedPVC.SyncStatus = volsync.PVCSyncStatusFromRSs(rss)
+
+	if v.isVolSyncRSPaused() {
+		setVRGSuspendedCondition(&protectedPVC.Conditions, v.instance.Generation,
+			"Replication suspended, ReplicationSource is paused")
+	}
+
+	if v.instance.Spec.RunFinalSync && !finalSyncComplete {
+		return fmt.Errorf("final sync of pvc %s not complete yet", pvc.Name)
+	}
+
+	return nil
+}
+
+// reconcileVolSyncRS reconciles the ReplicationSource of the protected PVC to
+// the destination, and records the replication to the destination in the
+// status of the protected PVC, when replicated to more than one. Returns true
+// if the final sync to the destination is complete, or an error to requeue.
+func (v *VRGInstance) reconcileVolSyncRS(protectedPVC *ramendrv1alpha1.ProtectedPVC, destination string,
+	log logr.Logger,
+) (*volsyncv1alpha1.ReplicationSource, bool, error) {
+	// Not much need for VolSyncReplicationSourceSpec anymore - but keeping it around in case we want
+	// to add anything to it later to control anything in the ReplicationSource
+	rsSpec := ramendrv1alpha1.VolSyncReplicationSourceSpec{
+		ProtectedPVC: *protectedPVC,
+		Paused:       v.isVolSyncRSPaused(),
+		RsyncAddress: v.findRsyncAddress(protectedPVC.Name, destination),
+		Destination:  destination,
+	}
+
+	// reconcile RS and if runFinalSync is true, then one final sync will be run
+	finalSyncComplete, rs, err := v.volSyncHandler.ReconcileRS(rsSpec, v.instance.Spec.RunFinalSync)
+	if err != nil {
+		log.Info(fmt.Sprintf("Failed to reconcile VolSync Replication Source for rsSpec %v. Error %v",
+			rsSpec, err))
+
+		setVRGConditionTypeVolSyncRepSourceSetupError(&protectedPVC.Conditions, v.instance.Generation,
+			"VolSync setup failed")
+
+		return nil, false, err
+	}
+
+	if rs == nil {
+		return nil, false, fmt.Errorf("ReplicationSource for pvc %s not set up yet", protectedPVC.Name)
+	}
+
+	if destination != "" {
+		updatePVCDestinationStatus(protectedPVC, destination, rs, v.instance.Generation)
+	}
+
+	return rs, finalSyncComplete, nil
+}
+
+// updatePVCDestinationStatus records the replication of the protected PVC to
+// the destination by the ReplicationSource, and whether the PVC is protected
+// on the destination, once synchronized to it at least once
+func updatePVCDestinationStatus(protectedPVC *ramendrv1alpha1.ProtectedPVC, destination string,
+	rs *volsyncv1alpha1.ReplicationSource, observedGeneration int64,
+) {
+	var destinationStatus *ramendrv1alpha1.VolSyncDestinationStatus
+
+	for idx := range protectedPVC.Destinations {
+		if protectedPVC.Destinations[idx].Name == destination {
+			destinationStatus = &protectedPVC.Destinations[idx]
+
+			break
+		}
+	}
+
+	if destinationStatus == nil {
+		protectedPVC.Destinations = append(protectedPVC.Destinations,
+			ramendrv1alpha1.VolSyncDestinationStatus{Name: destination})
+		destinationStatus = &protectedPVC.Destinations[len(protectedPVC.Destinations)-1]
+	}
+
+	destinationStatus.SyncStatus = volsync.PVCSyncStatusFromRSs([]*volsyncv1alpha1.ReplicationSource{rs})
+
+	if destinationStatus.SyncStatus.LastSyncTime == nil {
+		setVRGDataProtectionProgressCondition(&destinationStatus.Conditions, observedGeneration,
+			fmt.Sprintf("Initial synchronization to destination %s in progress", destination))
+
+		return
+	}
+
+	setVRGAsDataProtectedCondition(&destinationStatus.Conditions, observedGeneration,
+		fmt.Sprintf("PVC synchronized to destination %s", destination))
+}
+
 func (v *VRGInstance) reconcileVolSyncAsSecondary() (requeue bool) {
 	v.log.Info("Reconcile VolSync as Secondary", "RDSpec", v.instance.Spec.VolSync.RDSpec)
 
@@ -195,8 +355,28 @@ func (v *VRGInstance) reconcileVolSyncAsSecondary() (requeue bool) {
 	v.instance.Status.PrepareForFinalSyncComplete = false
 	v.instance.Status.FinalSyncComplete = false
 
+	if len(v.instance.Spec.VolSync.RDSpec) != 0 {
+		if err := v.setVolSyncMover(); err != nil {
+			v.log.Error(err, "Failed to set up the VolSync mover")
+
+			requeue = true
+
+			return
+		}
+
+		if err := v.validateStorageClassMappings(); err != nil {
+			v.log.Error(err, "Invalid storage class mappings")
+
+			requeue = true
+
+			return
+		}
+	}
+
 	// Reconcile RDSpec (deletion or replication)
-	for _, rdSpec := range v.instance.Spec.VolSync.RDSpec {
+	for _, peerRDSpec := range v.instance.Spec.VolSync.RDSpec {
+		rdSpec := v.mapRDSpecStorageClass(peerRDSpec)
+
 		v.log.Info("Reconcile RD as Secondary", "RDSpec", rdSpec)
 
 		rd, err := v.volSyncHandler.ReconcileRD(rdSpec)
@@ -208,10 +388,44 @@ func (v *VRGInstance) reconcileVolSyncAsSecondary() (requeue bool) {
 			return
 		}
 
+		v.updatePVCSyncStatusFromRD(rdSpec, rd)
+
+		protectedPVC := v.findProtectedPVC(rdSpec.ProtectedPVC.Namespace, rdSpec.ProtectedPVC.Name)
+		v.updatePVCCopyMethod(protectedPVC, rdSpec.ProtectedPVC.StorageClassName,
+			rdSpec.ProtectedPVC.AccessModes)
+
 		if rd == nil {
 			// Replication destination is not ready yet, indicate we should requeue after the for loop is complete
 			requeue = true
+
+			continue
 		}
+
+		v.updatePVCMoverScheduled(protectedPVC, []string{rd.GetName()},
+			v.volSyncHandler.GetRDMoverSchedulingFailure)
+
+		// Publish the address of the RD, for the DRPC to pass it to the primary VRG
+		rsyncAddress, err := v.volSyncHandler.GetRsyncAddress(rd)
+		if err != nil {
+			v.log.Error(err, "Failed to get the address of VolSync Replication Destination")
+
+			requeue = true
+
+			return
+		}
+
+		protectedPVC.RsyncAddress = rsyncAddress
+
+		recoveryPoints, err := v.volSyncHandler.ReconcileRecoveryPoints(rdSpec.ProtectedPVC.Name)
+		if err != nil {
+			v.log.Error(err, "Failed to reconcile the recovery points of VolSync Replication Destination")
+
+			requeue = true
+
+			return
+		}
+
+		protectedPVC.RecoveryPoints = recoveryPoints
 	}
 
 	if requeue {
@@ -220,6 +434,39 @@ func (v *VRGInstance) reconcileVolSyncAsSecondary() (requeue bool) {
 		return
 	}
 
+	if len(v.instance.Spec.VolSync.RDSpec) != 0 {
+		if err := v.volSyncHandler.DeleteStaleSSHSecrets(); err != nil {
+			v.log.Error(err, "Failed to delete the VolSync ssh secrets of previous rotations")
+
+			requeue = true
+
+			return
+		}
+
+		if err := v.volSyncHandler.CleanupRecoveryPointsNotInSpecList(v.instance.Spec.VolSync.RDSpec); err != nil {
+			v.log.Error(err, "Failed to cleanup the recovery points of PVCs no longer protected")
+
+			requeue = true
+
+			return
+		}
+
+		// ReplicationDestinations named for another destination name are no longer replicated to
+		if err := v.volSyncHandler.CleanupRDNotInSpecList(v.instance.Spec.VolSync.RDSpec); err != nil {
+			v.log.Error(err, "Failed to cleanup the ReplicationDestinations not in the RDSpecs")
+
+			requeue = true
+
+			return
+		}
+	}
+
+	// The ReplicationDestinations, if any, all use the ssh secret now
+	v.volSyncHandler.SetSSHSecretName(v.instance.Spec.VolSync.SSHSecretName)
+	v.instance.Status.VolSyncSSHSecretName = v.volSyncHandler.GetSSHSecretName()
+
+	v.pruneVolSyncMetrics()
+
 	v.log.Info("Successfully reconciled VolSync as Secondary")
 
 	return requeue
@@ -393,3 +640,172 @@ func (v VRGInstance) isVolSyncReplicationSourceSetupComplete() bool {
 
 	return ready
 }
+
+// protectedPVCAnnotations returns the PVC annotations to be set on the PVC
+// restored on a peer cluster, such as those of its CSI driver. Annotations set
+// while binding or provisioning the PVC, and the ACM annotations of the
+// application that deployed it, are left out.
+func protectedPVCAnnotations(annotations map[string]string) map[string]string {
+	var protectedAnnotations map[string]string
+
+	for key, val := range annotations {
+		if isPVCBindAnnotation(key) || strings.HasPrefix(key, "apps.open-cluster-management.io") {
+			continue
+		}
+
+		if protectedAnnotations == nil {
+			protectedAnnotations = map[string]string{}
+		}
+
+		protectedAnnotations[key] = val
+	}
+
+	return protectedAnnotations
+}
+
+func isPVCBindAnnotation(key string) bool {
+	for _, annotation := range pvcBindAnnotations {
+		if key == annotation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updatePVCCopyMethod reports the method VolSync copies the PVC with in the
+// conditions of the protected PVC
+func (v *VRGInstance) updatePVCCopyMethod(protectedPVC *ramendrv1alpha1.ProtectedPVC, storageClassName *string,
+	accessModes []corev1.PersistentVolumeAccessMode,
+) {
+	copyMethod, err := v.volSyncHandler.GetCopyMethod(storageClassName, accessModes)
+	if err != nil {
+		setVRGConditionTypeVolSyncCopyMethodError(&protectedPVC.Conditions, v.instance.Generation,
+			fmt.Sprintf("Invalid copy method: %v", err))
+
+		return
+	}
+
+	setVRGConditionTypeVolSyncCopyMethod(&protectedPVC.Conditions, v.instance.Generation, string(copyMethod),
+		fmt.Sprintf("PVC copied with the %s copy method", copyMethod))
+}
+
+// pruneVolSyncMetrics deletes the metrics of the movers of the
+// ReplicationSources and ReplicationDestinations that were cleaned up
+func (v *VRGInstance) pruneVolSyncMetrics() {
+	if err := v.volSyncHandler.PruneMetrics(); err != nil {
+		v.log.Error(err, "Failed to prune the metrics of VolSync movers no longer replicating")
+	}
+}
+
+// updatePVCMoverScheduled reports whether the pods of the movers of the
+// ReplicationSources or ReplicationDestinations of the PVC are scheduled in the
+// conditions of the protected PVC
+func (v *VRGInstance) updatePVCMoverScheduled(protectedPVC *ramendrv1alpha1.ProtectedPVC, names []string,
+	getMoverSchedulingFailure func(string) (string, error),
+) {
+	if protectedPVC == nil {
+		return
+	}
+
+	for _, name := range names {
+		failure, err := getMoverSchedulingFailure(name)
+		if err != nil {
+			v.log.Error(err, "Failed to get the scheduling of the VolSync mover", "name", name)
+
+			return
+		}
+
+		if failure != "" {
+			v.log.Info("VolSync mover not scheduled", "name", name, "failure", failure)
+			setVRGConditionTypeVolSyncMoverUnschedulable(&protectedPVC.Conditions, v.instance.Generation, failure)
+
+			return
+		}
+	}
+
+	setVRGConditionTypeVolSyncMoverScheduled(&protectedPVC.Conditions, v.instance.Generation,
+		"VolSync mover pods scheduled")
+}
+
+// findRsyncAddress returns the address of the ReplicationDestination on the
+// destination that the DRPC passed for the PVC, if any
+func (v *VRGInstance) findRsyncAddress(pvcName, destination string) *ramendrv1alpha1.VolSyncRsyncAddress {
+	for idx := range v.instance.Spec.VolSync.RSSpec {
+		rsSpec := &v.instance.Spec.VolSync.RSSpec[idx]
+		if rsSpec.ProtectedPVC.Name == pvcName && rsSpec.Destination == destination {
+			return rsSpec.RsyncAddress
+		}
+	}
+
+	return nil
+}
+
+// volSyncScheduledSyncDelay returns the time until the VRG triggers the next
+// synchronization of its ReplicationSources, or of its restic
+// ReplicationDestinations as Secondary, for scheduling intervals that VolSync
+// can not schedule with a cronspec. Returns 0 if VolSync schedules them.
+func (v *VRGInstance) volSyncScheduledSyncDelay() time.Duration {
+	switch {
+	case v.instance.Spec.ReplicationState == ramendrv1alpha1.Primary:
+		if len(v.volSyncPVCs) == 0 || v.instance.Spec.RunFinalSync {
+			return 0
+		}
+	case v.instance.Spec.VolSync.Mover != ramendrv1alpha1.VolSyncMoverRestic || len(v.instance.Spec.VolSync.RDSpec) == 0:
+		return 0
+	}
+
+	nextSync := v.volSyncHandler.NextScheduledSync()
+	if nextSync == nil {
+		return 0
+	}
+
+	// Requeue no earlier than the start of the next interval
+	return time.Until(*nextSync) + time.Second
+}
+
+// setVolSyncMover sets up the VolSync handler to replicate with the restic
+// mover, through restic repositories in the store of the first S3 profile of
+// the VRG, when the VRG selects it, or with the rsync mover as per the VolSync
+// profile and ssh secret of the VRG
+func (v *VRGInstance) setVolSyncMover() error {
+	v.volSyncHandler.SetVolSyncProfile(v.instance.Spec.VolSync.Profile)
+	v.volSyncHandler.SetSSHSecretName(v.instance.Spec.VolSync.SSHSecretName)
+	v.volSyncHandler.SetRecoveryPointRetention(v.instance.Spec.VolSync.RecoveryPointRetention)
+	v.volSyncHandler.SetDestinations(v.instance.Spec.VolSync.Destinations)
+
+	if v.instance.Spec.VolSync.Mover != ramendrv1alpha1.VolSyncMoverRestic {
+		v.volSyncHandler.SetResticRepository(nil)
+
+		return nil
+	}
+
+	for _, s3ProfileName := range v.instance.Spec.S3Profiles {
+		if s3ProfileName == NoS3StoreAvailable {
+			continue
+		}
+
+		s3StoreProfile, err := GetRamenConfigS3StoreProfile(v.ctx, v.reconciler.APIReader, s3ProfileName)
+		if err != nil {
+			return fmt.Errorf("failed to get S3 profile %s for restic repositories (%w)", s3ProfileName, err)
+		}
+
+		accessID, secretAccessKey, err := GetS3Secret(v.ctx, v.reconciler.APIReader, s3StoreProfile.S3SecretRef)
+		if err != nil {
+			return fmt.Errorf("failed to get secret of S3 profile %s for restic repositories (%w)", s3ProfileName, err)
+		}
+
+		v.volSyncHandler.SetResticRepository(&volsync.ResticRepository{
+			S3CompatibleEndpoint: s3StoreProfile.S3CompatibleEndpoint,
+			S3Bucket:             s3StoreProfile.S3Bucket,
+			S3Region:             s3StoreProfile.S3Region,
+			KeyPrefix:            v.s3KeyPrefix() + "volsync/",
+			AccessKeyID:          accessID,
+			SecretAccessKey:      secretAccessKey,
+		})
+
+		return nil
+	}
+
+	return fmt.Errorf("no S3 profile for restic repositories")
+}
@@ -2,16 +2,20 @@ package controllers_test
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -167,7 +171,7 @@ var _ = Describe("VolumeReplicationGroupController", func() {
 						}, rs0)).To(Succeed())
 						Expect(rs0.Spec.SourcePVC).To(Equal(boundPvcs[0].GetName()))
 						Expect(rs0.Spec.Trigger).NotTo(BeNil())
-						Expect(*rs0.Spec.Trigger.Schedule).To(Equal("0 */1 * * *")) // scheduling interval was set to 1h
+						Expect(*rs0.Spec.Trigger.Schedule).To(Equal(expectedHourlyCronSpec(testVsrg))) // scheduling interval was set to 1h
 
 						rs1 := &volsyncv1alpha1.ReplicationSource{}
 						Expect(k8sClient.Get(testCtx, types.NamespacedName{
@@ -175,7 +179,7 @@ var _ = Describe("VolumeReplicationGroupController", func() {
 						}, rs1)).To(Succeed())
 						Expect(rs1.Spec.SourcePVC).To(Equal(boundPvcs[1].GetName()))
 						Expect(rs1.Spec.Trigger).NotTo(BeNil())
-						Expect(*rs1.Spec.Trigger.Schedule).To(Equal("0 */1 * * *")) // scheduling interval was set to 1h
+						Expect(*rs1.Spec.Trigger.Schedule).To(Equal(expectedHourlyCronSpec(testVsrg))) // scheduling interval was set to 1h
 
 						rs2 := &volsyncv1alpha1.ReplicationSource{}
 						Expect(k8sClient.Get(testCtx, types.NamespacedName{
@@ -183,7 +187,7 @@ var _ = Describe("VolumeReplicationGroupController", func() {
 						}, rs2)).To(Succeed())
 						Expect(rs2.Spec.SourcePVC).To(Equal(boundPvcs[2].GetName()))
 						Expect(rs2.Spec.Trigger).NotTo(BeNil())
-						Expect(*rs2.Spec.Trigger.Schedule).To(Equal("0 */1 * * *")) // scheduling interval was set to 1h
+						Expect(*rs2.Spec.Trigger.Schedule).To(Equal(expectedHourlyCronSpec(testVsrg))) // scheduling interval was set to 1h
 					})
 				})
 			})
@@ -347,8 +351,325 @@ var _ = Describe("VolumeReplicationGroupController", func() {
 			})
 		})
 	})
+
+	Describe("Workload scale down for the final sync", func() {
+		testMatchLabels := map[string]string{
+			"ramentest": "scalemedown",
+		}
+
+		var testVsrg *ramendrv1alpha1.VolumeReplicationGroup
+		var testDeployment *appsv1.Deployment
+		var createWorkload func()
+
+		BeforeEach(func() {
+			createWorkload = func() {
+				testDeployment = createDeploymentUsingPVC(testCtx, testNamespace.GetName(), testMatchLabels, 3)
+			}
+		})
+
+		JustBeforeEach(func() {
+			testVsrg = &ramendrv1alpha1.VolumeReplicationGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "test-vrg-east-",
+					Namespace:    testNamespace.GetName(),
+				},
+				Spec: ramendrv1alpha1.VolumeReplicationGroupSpec{
+					ReplicationState: ramendrv1alpha1.Primary,
+					Async: ramendrv1alpha1.VRGAsyncSpec{
+						Mode:               ramendrv1alpha1.AsyncModeEnabled,
+						SchedulingInterval: "1h",
+					},
+					Sync: ramendrv1alpha1.VRGSyncSpec{
+						Mode: ramendrv1alpha1.SyncModeDisabled,
+					},
+					PVCSelector: metav1.LabelSelector{
+						MatchLabels: testMatchLabels,
+					},
+					S3Profiles:   []string{s3Profiles[0].S3ProfileName},
+					RunFinalSync: true,
+					VolSync: ramendrv1alpha1.VolSyncSpec{
+						ScaleDownWorkloads: true,
+					},
+				},
+			}
+
+			Expect(k8sClient.Create(testCtx, testVsrg)).To(Succeed())
+
+			createSecret(testVsrg.GetName(), testNamespace.Name)
+			createSC()
+			createVSC()
+
+			createWorkload()
+		})
+
+		getScaleDownReason := func() string {
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+
+			for _, condition := range testVsrg.Status.Conditions {
+				if condition.Type == "WorkloadsScaledDown" {
+					return condition.Reason
+				}
+			}
+
+			return ""
+		}
+
+		// getDeploymentReplicas gets the deployment afresh, as getting it into the previous one keeps the
+		// labels and annotations since removed
+		getDeploymentReplicas := func() int32 {
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testDeployment), deployment)).To(Succeed())
+			testDeployment = deployment
+
+			return *testDeployment.Spec.Replicas
+		}
+
+		expectDeploymentScaledDown := func() {
+			Eventually(getDeploymentReplicas, testMaxWait, testInterval).Should(BeZero())
+			Expect(testDeployment.GetAnnotations()).To(HaveKeyWithValue(
+				"volumereplicationgroups.ramendr.openshift.io/replicas", "3"))
+			Expect(testDeployment.GetLabels()).To(HaveKeyWithValue(
+				"volumereplicationgroups.ramendr.openshift.io/scaled-down-by", string(testVsrg.GetUID())))
+
+			// The pod is left running, as envtest runs no controllers
+			Eventually(getScaleDownReason, testMaxWait, testInterval).Should(Equal("Progressing"))
+		}
+
+		expectDeploymentRestored := func(replicas int32) {
+			Eventually(getDeploymentReplicas, testMaxWait, testInterval).Should(Equal(replicas))
+			Eventually(func() map[string]string {
+				getDeploymentReplicas()
+
+				return testDeployment.GetLabels()
+			}, testMaxWait, testInterval).ShouldNot(HaveKey(
+				"volumereplicationgroups.ramendr.openshift.io/scaled-down-by"))
+			Expect(testDeployment.GetAnnotations()).NotTo(HaveKey(
+				"volumereplicationgroups.ramendr.openshift.io/replicas"))
+		}
+
+		abortFinalSync := func() {
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+			testVsrg.Spec.RunFinalSync = false
+			Expect(k8sClient.Update(testCtx, testVsrg)).To(Succeed())
+		}
+
+		It("Should scale the deployment down and restore it once the final sync is aborted", func() {
+			expectDeploymentScaledDown()
+
+			abortFinalSync()
+
+			expectDeploymentRestored(3)
+		})
+
+		It("Should not restore the deployment over replicas scaled up since", func() {
+			expectDeploymentScaledDown()
+
+			// Hold the workloads as they are, for the VRG not to scale the deployment down again
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+			testVsrg.Spec.RunFinalSync = false
+			testVsrg.Spec.PrepareForFinalSync = true
+			Expect(k8sClient.Update(testCtx, testVsrg)).To(Succeed())
+
+			// Scale the deployment up, as an autoscaler or a GitOps tool would
+			getDeploymentReplicas()
+			patch := client.MergeFrom(testDeployment.DeepCopy())
+			replicas := int32(5)
+			testDeployment.Spec.Replicas = &replicas
+			Expect(k8sClient.Patch(testCtx, testDeployment, patch)).To(Succeed())
+
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg), testVsrg)).To(Succeed())
+			testVsrg.Spec.PrepareForFinalSync = false
+			Expect(k8sClient.Update(testCtx, testVsrg)).To(Succeed())
+
+			expectDeploymentRestored(5)
+		})
+
+		It("Should restore the deployment once the VRG is deleted", func() {
+			expectDeploymentScaledDown()
+
+			Expect(k8sClient.Delete(testCtx, testVsrg)).To(Succeed())
+
+			expectDeploymentRestored(3)
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testVsrg),
+					&ramendrv1alpha1.VolumeReplicationGroup{}))
+			}, testMaxWait, testInterval).Should(BeTrue())
+		})
+
+		Context("When the pod is run by the replica set of a deployment", func() {
+			var testReplicaSet *appsv1.ReplicaSet
+
+			BeforeEach(func() {
+				createWorkload = func() {
+					testDeployment, testReplicaSet = createReplicaSetOfDeploymentUsingPVC(testCtx,
+						testNamespace.GetName(), testMatchLabels, 3)
+				}
+			})
+
+			It("Should scale the deployment down, rather than its replica set", func() {
+				expectDeploymentScaledDown()
+
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testReplicaSet),
+					testReplicaSet)).To(Succeed())
+				Expect(*testReplicaSet.Spec.Replicas).To(Equal(int32(3)))
+				Expect(testReplicaSet.GetLabels()).NotTo(HaveKey(
+					"volumereplicationgroups.ramendr.openshift.io/scaled-down-by"))
+
+				abortFinalSync()
+
+				expectDeploymentRestored(3)
+			})
+		})
+
+		Context("When the pod is run by a stateful set", func() {
+			var testStatefulSet *appsv1.StatefulSet
+
+			BeforeEach(func() {
+				createWorkload = func() {
+					testStatefulSet = createStatefulSetUsingPVC(testCtx, testNamespace.GetName(), testMatchLabels, 2)
+				}
+			})
+
+			It("Should scale the stateful set down and restore it once the final sync is aborted", func() {
+				getStatefulSetReplicas := func() int32 {
+					statefulSet := &appsv1.StatefulSet{}
+					Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(testStatefulSet),
+						statefulSet)).To(Succeed())
+					testStatefulSet = statefulSet
+
+					return *testStatefulSet.Spec.Replicas
+				}
+
+				Eventually(getStatefulSetReplicas, testMaxWait, testInterval).Should(BeZero())
+				Expect(testStatefulSet.GetAnnotations()).To(HaveKeyWithValue(
+					"volumereplicationgroups.ramendr.openshift.io/replicas", "2"))
+				Eventually(getScaleDownReason, testMaxWait, testInterval).Should(Equal("Progressing"))
+
+				abortFinalSync()
+
+				Eventually(getStatefulSetReplicas, testMaxWait, testInterval).Should(Equal(int32(2)))
+				Expect(testStatefulSet.GetLabels()).NotTo(HaveKey(
+					"volumereplicationgroups.ramendr.openshift.io/scaled-down-by"))
+				Expect(testStatefulSet.GetAnnotations()).NotTo(HaveKey(
+					"volumereplicationgroups.ramendr.openshift.io/replicas"))
+			})
+		})
+	})
 })
 
+// createDeploymentUsingPVC creates a bound PVC mounted by a running pod, and a
+// deployment of the replicas made the controller of the pod, as envtest runs
+// no controllers
+func createDeploymentUsingPVC(ctx context.Context, namespace string, labels map[string]string,
+	replicas int32) *appsv1.Deployment {
+	pvc := createPVCBoundToRunningPod(ctx, namespace, labels)
+
+	deployment := newTestDeployment(namespace, replicas)
+	Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+
+	setControllerOfPodsUsingPVC(ctx, pvc, deployment)
+
+	return deployment
+}
+
+// createReplicaSetOfDeploymentUsingPVC creates a bound PVC mounted by a running
+// pod, a deployment of the replicas, and a replica set of the deployment made
+// the controller of the pod
+func createReplicaSetOfDeploymentUsingPVC(ctx context.Context, namespace string, labels map[string]string,
+	replicas int32) (*appsv1.Deployment, *appsv1.ReplicaSet) {
+	pvc := createPVCBoundToRunningPod(ctx, namespace, labels)
+
+	deployment := newTestDeployment(namespace, replicas)
+	Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: deployment.GetName() + "-",
+			Namespace:    namespace,
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: &replicas,
+			Selector: deployment.Spec.Selector,
+			Template: deployment.Spec.Template,
+		},
+	}
+	Expect(controllerutil.SetControllerReference(deployment, replicaSet, k8sClient.Scheme())).To(Succeed())
+	Expect(k8sClient.Create(ctx, replicaSet)).To(Succeed())
+
+	setControllerOfPodsUsingPVC(ctx, pvc, replicaSet)
+
+	return deployment, replicaSet
+}
+
+// createStatefulSetUsingPVC creates a bound PVC mounted by a running pod, and a
+// stateful set of the replicas made the controller of the pod
+func createStatefulSetUsingPVC(ctx context.Context, namespace string, labels map[string]string,
+	replicas int32) *appsv1.StatefulSet {
+	pvc := createPVCBoundToRunningPod(ctx, namespace, labels)
+
+	podLabels := map[string]string{"app": "scalemedown"}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-statefulset-",
+			Namespace:    namespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "c1", Image: "testimage123"}},
+				},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, statefulSet)).To(Succeed())
+
+	setControllerOfPodsUsingPVC(ctx, pvc, statefulSet)
+
+	return statefulSet
+}
+
+func newTestDeployment(namespace string, replicas int32) *appsv1.Deployment {
+	podLabels := map[string]string{"app": "scalemedown"}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "test-deployment-",
+			Namespace:    namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "c1", Image: "testimage123"}},
+				},
+			},
+		},
+	}
+}
+
+// setControllerOfPodsUsingPVC makes the owner the controller of the pods
+// mounting the PVC
+func setControllerOfPodsUsingPVC(ctx context.Context, pvc *corev1.PersistentVolumeClaim, owner client.Object) {
+	podList := &corev1.PodList{}
+	Expect(k8sClient.List(ctx, podList, client.InNamespace(pvc.GetNamespace()))).To(Succeed())
+
+	for idx := range podList.Items {
+		pod := &podList.Items[idx]
+		if pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName != pvc.GetName() {
+			continue
+		}
+
+		Expect(controllerutil.SetControllerReference(owner, pod, k8sClient.Scheme())).To(Succeed())
+		Expect(k8sClient.Update(ctx, pod)).To(Succeed())
+	}
+}
+
 //nolint:funlen
 func createPVCBoundToRunningPod(ctx context.Context, namespace string,
 	labels map[string]string) *corev1.PersistentVolumeClaim {
@@ -474,3 +795,12 @@ func createVSC() {
 	Expect(err).NotTo(HaveOccurred(),
 		"failed to create/get StorageClass %s", vsc.Name)
 }
+
+// expectedHourlyCronSpec returns the cronspec of an hourly scheduling interval,
+// offset into the hour by a hash of the VRG
+func expectedHourlyCronSpec(vrg *ramendrv1alpha1.VolumeReplicationGroup) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(vrg.GetNamespace() + "/" + vrg.GetName()))
+
+	return fmt.Sprintf("%d * * * *", hash.Sum32()%60)
+}